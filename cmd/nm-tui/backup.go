@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/alphameo/nm-tui/internal/backup"
+	"github.com/charmbracelet/x/term"
+)
+
+// passphraseEnv lets scripts provide the archive passphrase without a prompt.
+const passphraseEnv = "NM_TUI_BACKUP_PASSPHRASE"
+
+const (
+	conflictAsk       = "ask"
+	conflictSkip      = "skip"
+	conflictOverwrite = "overwrite"
+	conflictRename    = "rename"
+)
+
+func runExport(args []string) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	encrypt := fs.Bool("encrypt", false, "protect the archive with a passphrase (or $"+passphraseEnv+")")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: nm-tui export [--encrypt] FILE")
+		fmt.Fprintln(fs.Output(), "Save every Wi-Fi and hotspot profile, secrets included, to FILE (- for stdout).")
+		fs.PrintDefaults()
+	}
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+	path := fs.Arg(0)
+
+	var passphrase string
+	if *encrypt {
+		var err error
+		passphrase, err = readPassphrase(true)
+		if err != nil {
+			return fail(err)
+		}
+	}
+
+	archive, err := backup.Collect(context.Background(), newBackend())
+	if err != nil {
+		return fail(err)
+	}
+
+	out := io.Writer(os.Stdout)
+	if path != "-" {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fail(err)
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		out = f
+	}
+	if err := backup.Write(out, archive, passphrase); err != nil {
+		return fail(err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d profiles to %s\n", len(archive.Profiles), path)
+	return exitOK
+}
+
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	onConflict := fs.String("on-conflict", conflictAsk, "what to do with conflicting profiles: ask, skip, overwrite or rename")
+	dryRun := fs.Bool("dry-run", false, "only show the restore preview")
+	yes := fs.Bool("yes", false, "restore without confirmation")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: nm-tui import [--on-conflict=ask|skip|overwrite|rename] [--dry-run] [--yes] FILE")
+		fmt.Fprintln(fs.Output(), "Restore profiles from an archive created by nm-tui export (- for stdin).")
+		fs.PrintDefaults()
+	}
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+	path := fs.Arg(0)
+
+	interactive := path != "-" && term.IsTerminal(os.Stdin.Fd())
+	if *onConflict == conflictAsk && !interactive {
+		*onConflict = conflictSkip
+	}
+	action, err := conflictAction(*onConflict)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "nm-tui: %v\n", err)
+		return exitUsage
+	}
+
+	archive, err := readArchive(path)
+	if err != nil {
+		return fail(err)
+	}
+
+	networks := newBackend()
+	saved, err := networks.ListProfiles(context.Background())
+	if err != nil {
+		return fail(err)
+	}
+	entries := backup.Plan(archive, saved)
+
+	in := bufio.NewReader(os.Stdin)
+	for i := range entries {
+		e := &entries[i]
+		if e.Conflict == backup.ConflictNone {
+			continue
+		}
+		if *onConflict == conflictAsk {
+			e.Action = askAction(in, e)
+		} else {
+			e.SetAction(action)
+		}
+	}
+
+	printPlan(os.Stdout, entries)
+	if *dryRun {
+		return exitOK
+	}
+	if !*yes && interactive && !confirm(in, "Restore?") {
+		return exitOK
+	}
+
+	report := backup.Restore(context.Background(), networks, entries)
+	fmt.Fprintln(os.Stdout, report)
+	for _, err := range report.Errors {
+		fmt.Fprintf(os.Stderr, "nm-tui: %v\n", err)
+	}
+	if len(report.Errors) > 0 {
+		return exitError
+	}
+	return exitOK
+}
+
+// parseFlags parses args and reports the exit code to stop with when parsing
+// fails or help was requested.
+func parseFlags(fs *flag.FlagSet, args []string) (int, bool) {
+	err := fs.Parse(args)
+	switch {
+	case err == nil:
+		return exitOK, true
+	case errors.Is(err, flag.ErrHelp):
+		return exitOK, false
+	default:
+		return exitUsage, false
+	}
+}
+
+func conflictAction(mode string) (backup.Action, error) {
+	switch mode {
+	case conflictAsk, conflictSkip:
+		return backup.ActionSkip, nil
+	case conflictOverwrite:
+		return backup.ActionOverwrite, nil
+	case conflictRename:
+		return backup.ActionRename, nil
+	default:
+		return 0, fmt.Errorf("invalid --on-conflict value: %q", mode)
+	}
+}
+
+// readArchive reads the archive at path, asking for the passphrase only when
+// the archive turns out to be encrypted.
+func readArchive(path string) (backup.Archive, error) {
+	data, err := readInput(path)
+	if err != nil {
+		return backup.Archive{}, err
+	}
+
+	archive, err := backup.Read(bytes.NewReader(data), "")
+	if !errors.Is(err, backup.ErrPassphraseRequired) {
+		return archive, err
+	}
+	passphrase, err := readPassphrase(false)
+	if err != nil {
+		return backup.Archive{}, err
+	}
+	return backup.Read(bytes.NewReader(data), passphrase)
+}
+
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// readPassphrase takes the passphrase from the environment or prompts for it
+// on the terminal, twice when a new one is being set.
+func readPassphrase(confirmNew bool) (string, error) {
+	if p := os.Getenv(passphraseEnv); p != "" {
+		return p, nil
+	}
+	fd := os.Stdin.Fd()
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("%w: set $%s", backup.ErrPassphraseRequired, passphraseEnv)
+	}
+
+	fmt.Fprint(os.Stderr, "Passphrase: ")
+	p, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	if len(p) == 0 {
+		return "", errors.New("empty passphrase")
+	}
+	if confirmNew {
+		fmt.Fprint(os.Stderr, "Repeat passphrase: ")
+		again, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		if string(again) != string(p) {
+			return "", errors.New("passphrases do not match")
+		}
+	}
+	return string(p), nil
+}
+
+func askAction(in *bufio.Reader, e *backup.Entry) backup.Action {
+	for {
+		fmt.Fprintf(
+			os.Stderr,
+			"%q conflicts by %s with %s. [s]kip, [o]verwrite, [r]ename to %q? ",
+			e.Profile.Name, e.Conflict, strings.Join(e.Existing, ", "), e.NewName,
+		)
+		line, err := in.ReadString('\n')
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "s", "skip":
+			return backup.ActionSkip
+		case "o", "overwrite":
+			return backup.ActionOverwrite
+		case "r", "rename":
+			return backup.ActionRename
+		}
+		if err != nil {
+			return backup.ActionSkip
+		}
+	}
+}
+
+func confirm(in *bufio.Reader, question string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+	line, _ := in.ReadString('\n')
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes"
+}
+
+func printPlan(w io.Writer, entries []backup.Entry) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tSSID\tCONFLICT\tACTION")
+	for _, e := range entries {
+		conflict := e.Conflict.String()
+		if e.Conflict != backup.ConflictNone {
+			conflict += " (" + strings.Join(e.Existing, ", ") + ")"
+		}
+		action := e.Action.String()
+		if e.Action == backup.ActionRename {
+			action += " to " + e.NewName
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", e.Profile.Name, e.Profile.SSID, conflict, action)
+	}
+	_ = tw.Flush()
+}
+
+func fail(err error) int {
+	fmt.Fprintf(os.Stderr, "nm-tui: %v\n", err)
+	return exitError
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/alphameo/nm-tui/internal/config"
+)
+
+func runConfig(args []string) int {
+	flags := flag.NewFlagSet("config", flag.ContinueOnError)
+	flags.Usage = func() {
+		out := flags.Output()
+		fmt.Fprintln(out, "Usage: nm-tui config check|dump [FILE]")
+		fmt.Fprintln(out, "  check  report every mistake in the config with its line and column")
+		fmt.Fprintln(out, "  dump   print the effective config, marking values left at their defaults")
+		fmt.Fprintln(out, "FILE defaults to $XDG_CONFIG_HOME/nm-tui/config.kdl. Both exit with 1 when the config has mistakes.")
+	}
+	if code, ok := parseFlags(flags, args); !ok {
+		return code
+	}
+	if flags.NArg() < 1 || flags.NArg() > 2 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	path := flags.Arg(1)
+	explicit := path != ""
+	if !explicit {
+		var err error
+		if path, err = config.ResolveConfigPath(); err != nil {
+			return fail(err)
+		}
+	}
+
+	var run func(path string, report *config.Report) int
+	switch flags.Arg(0) {
+	case "check":
+		run = checkConfig
+	case "dump":
+		run = dumpConfig
+	default:
+		flags.Usage()
+		return exitUsage
+	}
+
+	report, err := checkConfigFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist) && !explicit:
+		// Running on defaults is fine, there is just nothing to check.
+		return run(path, nil)
+	case err != nil:
+		return fail(err)
+	}
+	return run(path, &report)
+}
+
+func checkConfigFile(path string) (config.Report, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return config.Report{}, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return config.Check(f)
+}
+
+// checkConfig prints the problems of the config at path. A nil report means
+// there is no config file.
+func checkConfig(path string, report *config.Report) int {
+	if report == nil {
+		fmt.Fprintf(os.Stdout, "%s: no config file, defaults are used\n", path)
+		return exitOK
+	}
+	printConfigProblems(os.Stdout, path, report.Problems)
+	if len(report.Problems) > 0 {
+		return exitError
+	}
+	fmt.Fprintf(os.Stdout, "%s: ok\n", path)
+	return exitOK
+}
+
+// dumpConfig prints the config at path merged onto the defaults, as nm-tui
+// would use it. Problems go to stderr, the settings they affect are dumped with
+// their defaults.
+func dumpConfig(path string, report *config.Report) int {
+	cfg := config.DefaultConfig()
+	var set map[string]bool
+	if report != nil {
+		printConfigProblems(os.Stderr, path, report.Problems)
+		// A file that does not decode as a whole is ignored by nm-tui.
+		if user, err := config.LoadFile(path); err != nil {
+			fmt.Fprintf(os.Stderr, "nm-tui: %v, using defaults\n", err)
+		} else {
+			_ = cfg.Merge(user)
+			set = report.Set
+		}
+	}
+
+	if err := config.Dump(os.Stdout, cfg, set); err != nil {
+		return fail(err)
+	}
+	if report != nil && len(report.Problems) > 0 {
+		return exitError
+	}
+	return exitOK
+}
+
+func printConfigProblems(w io.Writer, path string, problems []config.Problem) {
+	for _, p := range problems {
+		fmt.Fprintf(w, "%s:%v\n", path, p)
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	radioWifi = "wifi"
+	radioWWAN = "wwan"
+)
+
+type radioJSON struct {
+	Radio   string `json:"radio"`
+	Enabled bool   `json:"enabled"`
+}
+
+type deviceJSON struct {
+	Device     string `json:"device"`
+	Type       string `json:"type"`
+	State      string `json:"state"`
+	Connection string `json:"connection"`
+}
+
+type statusJSON struct {
+	Networking   bool         `json:"networking"`
+	Connectivity string       `json:"connectivity"`
+	Wifi         bool         `json:"wifi"`
+	WWAN         bool         `json:"wwan"`
+	VPN          []string     `json:"vpn"`
+	Network      *networkJSON `json:"network"`
+	Devices      []deviceJSON `json:"devices"`
+}
+
+func runRadio(args []string) int {
+	fs, asJSON := scriptFlagSet(
+		"radio",
+		"radio [--json] wifi|wwan [on|off]",
+		"Show the state of the Wi-Fi or WWAN radio, or turn it on or off.",
+	)
+	return runScript(fs, args, 1, 2, func(m managers) int {
+		ctx := context.Background()
+		radio := fs.Arg(0)
+		if radio != radioWifi && radio != radioWWAN {
+			fs.Usage()
+			return exitUsage
+		}
+
+		if fs.NArg() == 1 {
+			status, err := m.device.GetRadioStatus(ctx)
+			if err != nil {
+				return fail(err)
+			}
+			enabled := status.EnabledWifi
+			if radio == radioWWAN {
+				enabled = status.EnabledWWAN
+			}
+			if *asJSON {
+				if err := printJSON(os.Stdout, radioJSON{Radio: radio, Enabled: enabled}); err != nil {
+					return fail(err)
+				}
+				return exitOK
+			}
+			fmt.Fprintln(os.Stdout, onOff(enabled))
+			return exitOK
+		}
+
+		var switchRadio func(context.Context) error
+		switch fs.Arg(1) {
+		case "on":
+			switchRadio = m.device.EnableWifi
+			if radio == radioWWAN {
+				switchRadio = m.device.EnableWWAN
+			}
+		case "off":
+			switchRadio = m.device.DisableWifi
+			if radio == radioWWAN {
+				switchRadio = m.device.DisableWWAN
+			}
+		default:
+			fs.Usage()
+			return exitUsage
+		}
+		err := switchRadio(ctx)
+		return reportAction(
+			*asJSON, "radio "+fs.Arg(1), radio,
+			fmt.Sprintf("Turned %s radio %s", radio, fs.Arg(1)), err,
+		)
+	})
+}
+
+func runStatus(args []string) int {
+	fs, asJSON := scriptFlagSet(
+		"status",
+		"status [--json] [--watch] [--interval=DURATION] [--format=waybar|json|template] [--template=TEXT]",
+		"Show networking, connectivity, radios, VPN, the connected network and the network devices.\n"+
+			"--format prints a single status bar line instead, --watch prints a new one on every change.",
+	)
+	watch := fs.Bool("watch", false, "keep running and print a line whenever the status changes")
+	interval := fs.Duration("interval", 2*time.Second, "how often --watch checks the status")
+	format := fs.String("format", "", "status bar line format: waybar, json or template")
+	text := fs.String("template", defaultBarTemplate, "Go template of the line, see README for the fields")
+	return runScript(fs, args, 0, 0, func(m managers) int {
+		if *format == "" && *watch {
+			*format = barFormatTemplate
+		}
+		if *format == "" {
+			status, err := collectStatus(context.Background(), m)
+			if err != nil {
+				return fail(err)
+			}
+			if *asJSON {
+				if err := printJSON(os.Stdout, status); err != nil {
+					return fail(err)
+				}
+				return exitOK
+			}
+			printStatus(os.Stdout, status)
+			return exitOK
+		}
+
+		if *asJSON {
+			fmt.Fprintln(os.Stderr, "nm-tui: --json and --format can't be used together")
+			return exitUsage
+		}
+		if *interval <= 0 {
+			fmt.Fprintln(os.Stderr, "nm-tui: --interval must be positive")
+			return exitUsage
+		}
+		render, err := newBarRenderer(*format, *text, m.cfg.Icons)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "nm-tui: %v\n", err)
+			return exitUsage
+		}
+		if !*watch {
+			status, err := collectStatus(context.Background(), m)
+			if err != nil {
+				return fail(err)
+			}
+			line, err := render(status)
+			if err != nil {
+				return fail(err)
+			}
+			fmt.Fprintln(os.Stdout, line)
+			return exitOK
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return watchStatus(ctx, m, *interval, render)
+	})
+}
+
+func collectStatus(ctx context.Context, m managers) (statusJSON, error) {
+	var s statusJSON
+	var err error
+
+	if s.Networking, err = m.device.IsNetworkingEnabled(ctx); err != nil {
+		return statusJSON{}, err
+	}
+	connectivity, err := m.device.GetConnectivityStatus(ctx)
+	if err != nil {
+		return statusJSON{}, err
+	}
+	s.Connectivity = strings.ToLower(connectivity.String())
+	radio, err := m.device.GetRadioStatus(ctx)
+	if err != nil {
+		return statusJSON{}, err
+	}
+	s.Wifi, s.WWAN = radio.EnabledWifi, radio.EnabledWWAN
+	vpn, err := m.device.ListActiveVPNs(ctx)
+	if err != nil {
+		return statusJSON{}, err
+	}
+	s.VPN = append([]string{}, vpn...)
+
+	devices, err := m.device.ListNetworkDevices(ctx)
+	if err != nil {
+		return statusJSON{}, err
+	}
+	s.Devices = make([]deviceJSON, 0, len(devices))
+	for _, d := range devices {
+		s.Devices = append(s.Devices, deviceJSON(d))
+	}
+
+	if !s.Wifi {
+		return s, nil
+	}
+	networks, err := m.networks.ListNetworks(ctx)
+	if err != nil {
+		return statusJSON{}, err
+	}
+	for _, n := range networks {
+		if n.Active {
+			s.Network = &networkJSON{
+				SSID:     n.SSID,
+				BSSID:    n.BSSID,
+				Active:   n.Active,
+				Security: n.Security,
+				Signal:   n.Signal,
+			}
+			break
+		}
+	}
+	return s, nil
+}
+
+func printStatus(w io.Writer, s statusJSON) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Networking:\t%s\n", onOff(s.Networking))
+	fmt.Fprintf(tw, "Connectivity:\t%s\n", s.Connectivity)
+	fmt.Fprintf(tw, "Wi-Fi radio:\t%s\n", onOff(s.Wifi))
+	fmt.Fprintf(tw, "WWAN radio:\t%s\n", onOff(s.WWAN))
+	network := "none"
+	if s.Network != nil {
+		network = fmt.Sprintf("%s (%d%%, %s)", s.Network.SSID, s.Network.Signal, s.Network.Security)
+	}
+	fmt.Fprintf(tw, "Network:\t%s\n", network)
+	vpn := "none"
+	if len(s.VPN) > 0 {
+		vpn = strings.Join(s.VPN, ", ")
+	}
+	fmt.Fprintf(tw, "VPN:\t%s\n", vpn)
+	_ = tw.Flush()
+
+	if len(s.Devices) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	printDevices(w, s.Devices)
+}
+
+func printDevices(w io.Writer, devices []deviceJSON) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DEVICE\tTYPE\tSTATE\tCONNECTION")
+	for _, d := range devices {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", d.Device, d.Type, d.State, d.Connection)
+	}
+	_ = tw.Flush()
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
@@ -2,68 +2,242 @@ package main
 
 import (
 	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
+	"text/tabwriter"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/alphameo/nm-tui/internal/config"
+	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/alphameo/nm-tui/internal/infra/diag"
 	"github.com/alphameo/nm-tui/internal/infra/logging"
 	"github.com/alphameo/nm-tui/internal/infra/nm"
 	"github.com/alphameo/nm-tui/internal/infra/portal"
+	"github.com/alphameo/nm-tui/internal/state"
 	"github.com/alphameo/nm-tui/internal/ui/models"
+	"github.com/charmbracelet/colorprofile"
 )
 
 // Injects via `go build -ldflags "-X main.version=$(VERSION)"`.
 var version = "dev"
 
+// Exit codes of the TUI and of every subcommand.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+	// exitNotFound tells scripts the network or profile they named is missing.
+	exitNotFound = 3
+	// exitCancelled tells the user closed a subcommand without choosing,
+	// like the picker.
+	exitCancelled = 4
+)
+
+type subcommand struct {
+	name    string
+	summary string
+	// run runs instead of the TUI and returns the process exit code.
+	run func(args []string) int
+}
+
+var subcommands = []subcommand{
+	{"list", "list the Wi-Fi networks in range", runList},
+	{"profiles", "list the saved profiles", runProfiles},
+	{"connect", "connect to a network, in the TUI when run on a terminal", runConnect},
+	{"up", "activate a saved profile", runUp},
+	{"down", "deactivate a saved profile", runDown},
+	{"radio", "show or switch the Wi-Fi and WWAN radios", runRadio},
+	{"status", "show the status, or stream it to a status bar", runStatus},
+	{"pick", "pick a network from a list, connect and exit", runPick},
+	{"export", "back up the saved profiles", runExport},
+	{"import", "restore profiles from a backup", runImport},
+	{"plan", "show how saved profiles differ from a manifest", runPlan},
+	{"apply", "make saved profiles match a manifest", runApply},
+	{"config", "check the config or print the effective one", runConfig},
+}
+
+// backend reaches NetworkManager for both the networks and the device.
+type backend interface {
+	infra.NetworksManager
+	infra.DeviceManager
+}
+
+// backends build a backend by its --backend name.
+var backends = map[string]func() backend{
+	"nmcli": func() backend { return nm.NewCLI() },
+}
+
+// options are the flags given before the subcommand.
+type options struct {
+	backend string
+	tab     string
+	noColor bool
+	// overrides holds the settings given as flags, merged over the config.
+	overrides config.Config
+}
+
+var opts options
+
 func main() {
-	if len(os.Args) > 1 && os.Args[1] == "--version" {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	fs := newFlagSet(&opts)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if flagValue[bool](fs, "version") {
 		fmt.Fprintf(os.Stdout, "nm-tui %s\n", version)
-		os.Exit(0)
+		return exitOK
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "config" {
+			config.SetConfigPath(flagValue[string](fs, f.Name))
+		}
+	})
+	opts.overrides = flagOverrides(fs)
+	if err := checkOptions(); err != nil {
+		fmt.Fprintf(os.Stderr, "nm-tui: %v\n", err)
+		return exitUsage
+	}
+
+	if fs.NArg() == 0 {
+		return runTUI("")
+	}
+	for _, c := range subcommands {
+		if c.name == fs.Arg(0) {
+			return c.run(fs.Args()[1:])
+		}
+	}
+	fmt.Fprintf(os.Stderr, "nm-tui: unknown command %q\n", fs.Arg(0))
+	fs.Usage()
+	return exitUsage
+}
+
+// newFlagSet defines the flags given before the subcommand. The options are
+// set in o, the settings overriding the config are read by flagOverrides.
+func newFlagSet(o *options) *flag.FlagSet {
+	fs := flag.NewFlagSet("nm-tui", flag.ContinueOnError)
+	fs.Bool("version", false, "print the version and exit")
+	fs.String("config", "", "config file `path` (default $XDG_CONFIG_HOME/nm-tui/config.kdl)")
+	fs.String("log-level", "", "log `level`: debug, info, warn or error")
+	fs.String("log-file", "", "log file `path`")
+	fs.Int("rescan-interval", 0, "`seconds` between rescans, 0 disables them")
+	fs.Bool("nerd", false, "use the Nerd Font icon preset")
+	fs.StringVar(&o.backend, "backend", "nmcli", "how to reach NetworkManager: nmcli")
+	fs.StringVar(&o.tab, "tab", models.TabNetworks, "tab to start on: networks, device, diagnostics or monitor")
+	fs.BoolVar(&o.noColor, "no-color", false, "draw without colors")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintln(out, "Usage: nm-tui [flags] [command] [args]")
+		fmt.Fprintln(out, "Without a command the TUI starts.")
+		fmt.Fprintln(out, "\nCommands:")
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		for _, c := range subcommands {
+			fmt.Fprintf(tw, "  %s\t%s\n", c.name, c.summary)
+		}
+		_ = tw.Flush()
+		fmt.Fprintln(out, "Run nm-tui COMMAND --help for the flags of a command.")
+		fmt.Fprintln(out, "\nFlags:")
+		fs.PrintDefaults()
 	}
+	return fs
+}
+
+// flagOverrides returns the settings given as flags in fs. Only the flags
+// given override the config.
+func flagOverrides(fs *flag.FlagSet) config.Config {
+	var res config.Config
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "log-level":
+			if res.Logging == nil {
+				res.Logging = &config.LogConfig{}
+			}
+			res.Logging.Level = new(flagValue[string](fs, f.Name))
+		case "log-file":
+			if res.Logging == nil {
+				res.Logging = &config.LogConfig{}
+			}
+			res.Logging.FilePath = new(flagValue[string](fs, f.Name))
+		case "rescan-interval":
+			res.RescanInterval = new(flagValue[int](fs, f.Name))
+		case "nerd":
+			res.Icons = &config.IconConfig{NerdPreset: new(flagValue[bool](fs, f.Name))}
+		}
+	})
+	return res
+}
+
+// flagValue returns the value of the flag name defined in fs.
+func flagValue[T any](fs *flag.FlagSet, name string) T {
+	return fs.Lookup(name).Value.(flag.Getter).Get().(T)
+}
+
+// checkOptions reports flags with values nm-tui can't use.
+func checkOptions() error {
+	if _, ok := backends[opts.backend]; !ok {
+		return fmt.Errorf("unknown backend %q", opts.backend)
+	}
+	switch opts.tab {
+	case models.TabNetworks, models.TabDevice, models.TabDiagnostics, models.TabMonitor:
+	default:
+		return fmt.Errorf(
+			"unknown tab %q: use %s, %s, %s or %s",
+			opts.tab, models.TabNetworks, models.TabDevice, models.TabDiagnostics, models.TabMonitor,
+		)
+	}
+	cfg := config.DefaultConfig()
+	if errs := cfg.Merge(&opts.overrides); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+func newBackend() backend {
+	return backends[opts.backend]()
+}
+
+// loadConfig merges the config file and then the flags over the defaults.
+func loadConfig() (config.Config, error) {
+	cfg, err := config.LoadOrDefaults()
+	// The overrides were checked by checkOptions.
+	_ = cfg.Merge(&opts.overrides)
+	return cfg, err
+}
 
+// runTUI runs the TUI, with the connector for ssid open unless it's empty.
+func runTUI(ssid string) int {
 	stdLogger := slog.New(slog.NewJSONHandler(
 		os.Stderr,
 		&slog.HandlerOptions{Level: slog.LevelWarn},
 	))
 	slog.SetDefault(stdLogger)
 
-	cfg, cfgErr := config.LoadOrDefaults()
+	cfg, cfgErr := loadConfig()
 	if cfgErr != nil && !errors.Is(cfgErr, fs.ErrNotExist) {
 		stdLogger.Warn("errors in user config, falling back to defaults", "errors", cfgErr)
 	}
-
-	logPath := *cfg.Logging.FilePath
-	logPathDir := filepath.Dir(logPath)
-	if err := os.MkdirAll(logPathDir, 0o700); err != nil {
-		err = fmt.Errorf("create log directory: %w", err)
-		stdLogger.Error(err.Error())
-		panic(err)
+	for _, c := range cfg.Keys.Conflicts() {
+		stdLogger.Warn("conflicting keybinding", "conflict", c)
 	}
-	f, err := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+
+	fileLogger, logFile, err := openLog(cfg)
 	if err != nil {
-		err = fmt.Errorf("open log file: %w", err)
 		stdLogger.Error(err.Error())
-		panic(err)
+		return exitError
 	}
 	defer func() {
-		_ = f.Close()
+		_ = logFile.Close()
 	}()
-
-	logLevel, err := resolveLogLevel(*cfg.Logging.Level)
-	if err != nil {
-		err = fmt.Errorf("log level: %w", err)
-		stdLogger.Error(err.Error())
-		panic(err)
-	}
-	fileLogger := slog.New(slog.NewJSONHandler(f, &slog.HandlerOptions{
-		Level:     logLevel,
-		AddSource: logLevel <= slog.LevelDebug,
-	}))
 	slog.SetDefault(fileLogger)
 	if cfgErr != nil {
 		fileLogger.Warn("errors in user config, falling back to defaults", "errors", cfgErr)
@@ -72,22 +246,75 @@ func main() {
 	fileLogger.Info("The program is running")
 	defer fileLogger.Info("Program is closed")
 
-	nm := nm.NewCLI()
+	nm := newBackend()
 	portalOpener := portal.New()
 	networksMw := logging.NewNetworks(fileLogger, nm)
 	deviceMw := logging.NewDevice(fileLogger, nm)
 	portalMw := logging.NewPortal(fileLogger, portalOpener)
-	model, err := models.NewMainModel(networksMw, deviceMw, portalMw, cfg)
+	proberMw := logging.NewProber(fileLogger, diag.New())
+	store, err := state.Open(state.DefaultPath())
+	if err != nil {
+		fileLogger.Warn("cannot load saved state, starting fresh", "error", err.Error())
+	}
+	model, err := models.NewMainModel(networksMw, deviceMw, portalMw, proberMw, store, cfg)
 	if err != nil {
 		fileLogger.Error("error during model initialization", "errors", err.Error())
-		return
+		return exitError
+	}
+	model.OverrideConfig(&opts.overrides)
+	// The tab was checked with the other flags.
+	_ = model.StartOn(opts.tab)
+	if ssid != "" {
+		model.StartConnector(ssid)
+	}
+
+	watcher, err := config.NewWatcher()
+	if err != nil {
+		fileLogger.Info("config hot-reload disabled", "error", err.Error())
+	} else {
+		defer func() {
+			_ = watcher.Close()
+		}()
+		model.WatchConfig(watcher.Changes())
 	}
 
-	p := tea.NewProgram(model)
+	p := tea.NewProgram(model, programOptions()...)
 	if _, err = p.Run(); err != nil {
 		fileLogger.Error("runtime error", "error", err.Error())
-		return
+		return exitError
+	}
+	return exitOK
+}
+
+// programOptions applies the flags concerning the terminal to a program.
+func programOptions() []tea.ProgramOption {
+	if opts.noColor {
+		return []tea.ProgramOption{tea.WithColorProfile(colorprofile.Ascii)}
+	}
+	return nil
+}
+
+// openLog opens the log file set in cfg and returns a logger writing to it.
+func openLog(cfg config.Config) (*slog.Logger, io.Closer, error) {
+	logPath := *cfg.Logging.FilePath
+	if err := os.MkdirAll(filepath.Dir(logPath), 0o700); err != nil {
+		return nil, nil, fmt.Errorf("create log directory: %w", err)
 	}
+	f, err := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open log file: %w", err)
+	}
+
+	logLevel, err := resolveLogLevel(*cfg.Logging.Level)
+	if err != nil {
+		_ = f.Close()
+		return nil, nil, fmt.Errorf("log level: %w", err)
+	}
+	logger := slog.New(slog.NewJSONHandler(f, &slog.HandlerOptions{
+		Level:     logLevel,
+		AddSource: logLevel <= slog.LevelDebug,
+	}))
+	return logger, f, nil
 }
 
 func resolveLogLevel(level string) (slog.Level, error) {
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/config"
+)
+
+func TestFlagOverrides(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantLevel  *string
+		wantFile   *string
+		wantRescan *int
+		wantNerd   *bool
+	}{
+		{name: "no flags"},
+		{
+			name:      "log file before level",
+			args:      []string{"--log-file", "/tmp/x.log", "--log-level", "debug"},
+			wantLevel: new("debug"),
+			wantFile:  new("/tmp/x.log"),
+		},
+		{
+			name:      "log level before file",
+			args:      []string{"--log-level", "warn", "--log-file", "/tmp/y.log"},
+			wantLevel: new("warn"),
+			wantFile:  new("/tmp/y.log"),
+		},
+		{name: "log file only", args: []string{"--log-file", "/tmp/z.log"}, wantFile: new("/tmp/z.log")},
+		{
+			name:       "rescan interval and nerd",
+			args:       []string{"--rescan-interval", "0", "--nerd"},
+			wantRescan: new(0),
+			wantNerd:   new(true),
+		},
+		{name: "options only", args: []string{"--tab", "device", "--no-color"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var o options
+			fs := newFlagSet(&o)
+			fs.SetOutput(io.Discard)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatalf("Parse(%q): %v", tt.args, err)
+			}
+			got := flagOverrides(fs)
+
+			logging := got.Logging
+			if logging == nil {
+				logging = &config.LogConfig{}
+			}
+			if (got.Logging == nil) != (tt.wantLevel == nil && tt.wantFile == nil) {
+				t.Errorf("Logging = %+v, want level %v, file %v", got.Logging, tt.wantLevel, tt.wantFile)
+			}
+			if !equalPtr(logging.Level, tt.wantLevel) {
+				t.Errorf("log level = %v, want %v", logging.Level, tt.wantLevel)
+			}
+			if !equalPtr(logging.FilePath, tt.wantFile) {
+				t.Errorf("log file = %v, want %v", logging.FilePath, tt.wantFile)
+			}
+			if !equalPtr(got.RescanInterval, tt.wantRescan) {
+				t.Errorf("rescan interval = %v, want %v", got.RescanInterval, tt.wantRescan)
+			}
+			var nerd *bool
+			if got.Icons != nil {
+				nerd = got.Icons.NerdPreset
+			}
+			if !equalPtr(nerd, tt.wantNerd) {
+				t.Errorf("nerd preset = %v, want %v", nerd, tt.wantNerd)
+			}
+		})
+	}
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
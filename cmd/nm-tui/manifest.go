@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/alphameo/nm-tui/internal/manifest"
+	"github.com/charmbracelet/x/term"
+)
+
+func runPlan(args []string) int {
+	fs := flag.NewFlagSet("plan", flag.ContinueOnError)
+	prune := fs.Bool("prune", false, "also delete Wi-Fi profiles missing from the manifest")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: nm-tui plan [--prune] MANIFEST")
+		fmt.Fprintln(fs.Output(), "Show how saved profiles differ from the KDL manifest.")
+		fs.PrintDefaults()
+	}
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	plan, err := loadPlan(newBackend(), fs.Arg(0), *prune)
+	if err != nil {
+		return fail(err)
+	}
+	printManifestPlan(os.Stdout, plan)
+	return exitOK
+}
+
+func runApply(args []string) int {
+	fs := flag.NewFlagSet("apply", flag.ContinueOnError)
+	prune := fs.Bool("prune", false, "also delete Wi-Fi profiles missing from the manifest")
+	yes := fs.Bool("yes", false, "apply without confirmation")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: nm-tui apply [--prune] [--yes] MANIFEST")
+		fmt.Fprintln(fs.Output(), "Create, update and delete saved profiles to match the KDL manifest.")
+		fs.PrintDefaults()
+	}
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	networks := newBackend()
+	plan, err := loadPlan(networks, fs.Arg(0), *prune)
+	if err != nil {
+		return fail(err)
+	}
+	printManifestPlan(os.Stdout, plan)
+	if plan.Empty() {
+		if len(plan.Clashes) > 0 {
+			return exitError
+		}
+		return exitOK
+	}
+	interactive := term.IsTerminal(os.Stdin.Fd())
+	if !*yes && interactive && !confirm(bufio.NewReader(os.Stdin), "Apply?") {
+		return exitOK
+	}
+
+	res := manifest.Apply(context.Background(), networks, plan)
+	fmt.Fprintln(os.Stdout, res)
+	for _, err := range res.Errors {
+		fmt.Fprintf(os.Stderr, "nm-tui: %v\n", err)
+	}
+	if len(res.Errors) > 0 {
+		return exitError
+	}
+	return exitOK
+}
+
+func loadPlan(networks infra.NetworksManager, path string, prune bool) (manifest.Plan, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return manifest.Plan{}, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	m, err := manifest.Load(f)
+	if err != nil {
+		return manifest.Plan{}, err
+	}
+	desired, err := m.Resolve(os.LookupEnv)
+	if err != nil {
+		return manifest.Plan{}, err
+	}
+	return manifest.MakePlan(context.Background(), networks, desired, prune)
+}
+
+func printManifestPlan(w io.Writer, plan manifest.Plan) {
+	for _, c := range plan.Clashes {
+		fmt.Fprintf(w, "! %s: name taken by a %s profile, left alone\n", c.Name, c.Mode)
+	}
+	if plan.Empty() {
+		fmt.Fprintf(w, "No changes, %d profiles match the manifest.\n", len(plan.Unchanged))
+		return
+	}
+
+	for _, c := range plan.Changes {
+		switch c.Kind {
+		case manifest.ChangeCreate:
+			fmt.Fprintf(w, "+ %s (ssid %q)\n", c.Name, c.Desired.SSID)
+		case manifest.ChangeUpdate:
+			fmt.Fprintf(w, "~ %s\n", c.Name)
+		case manifest.ChangeReplace:
+			fmt.Fprintf(w, "-/+ %s\n", c.Name)
+		case manifest.ChangeDelete:
+			fmt.Fprintf(w, "- %s\n", c.Name)
+		}
+		for _, d := range c.Diffs {
+			fmt.Fprintf(w, "    %s: %s -> %s\n", d.Field, d.Old, d.New)
+		}
+	}
+	fmt.Fprintf(
+		w,
+		"Plan: %d to create, %d to update, %d to replace, %d to delete.\n",
+		plan.Count(manifest.ChangeCreate),
+		plan.Count(manifest.ChangeUpdate),
+		plan.Count(manifest.ChangeReplace),
+		plan.Count(manifest.ChangeDelete),
+	)
+}
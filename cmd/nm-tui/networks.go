@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/charmbracelet/x/term"
+)
+
+// wifiPasswordEnv lets scripts provide the password of a new network
+// without a prompt.
+const wifiPasswordEnv = "NM_TUI_WIFI_PASSWORD"
+
+type networkJSON struct {
+	SSID     string `json:"ssid"`
+	BSSID    string `json:"bssid"`
+	Active   bool   `json:"active"`
+	Security string `json:"security"`
+	Signal   int    `json:"signal"`
+}
+
+type profileJSON struct {
+	Name     string     `json:"name"`
+	UUID     string     `json:"uuid"`
+	SSID     string     `json:"ssid"`
+	Active   bool       `json:"active"`
+	Mode     string     `json:"mode"`
+	Priority int        `json:"autoconnect_priority"`
+	LastUsed *time.Time `json:"last_used"`
+}
+
+func runList(args []string) int {
+	fs, asJSON := scriptFlagSet("list", "list [--rescan] [--json]", "List the Wi-Fi networks in range.")
+	rescan := fs.Bool("rescan", false, "scan again instead of using the cached results")
+	return runScript(fs, args, 0, 0, func(m managers) int {
+		list := m.networks.ListNetworks
+		if *rescan {
+			list = m.networks.ListNetworksWithRescan
+		}
+		networks, err := list(context.Background())
+		if err != nil {
+			return fail(err)
+		}
+
+		if *asJSON {
+			out := make([]networkJSON, 0, len(networks))
+			for _, n := range networks {
+				out = append(out, networkJSON{
+					SSID:     n.SSID,
+					BSSID:    n.BSSID,
+					Active:   n.Active,
+					Security: n.Security,
+					Signal:   n.Signal,
+				})
+			}
+			if err := printJSON(os.Stdout, out); err != nil {
+				return fail(err)
+			}
+			return exitOK
+		}
+		printNetworks(os.Stdout, networks)
+		return exitOK
+	})
+}
+
+func runProfiles(args []string) int {
+	fs, asJSON := scriptFlagSet("profiles", "profiles [--json]", "List the saved Wi-Fi and hotspot profiles.")
+	return runScript(fs, args, 0, 0, func(m managers) int {
+		profiles, err := m.networks.ListProfiles(context.Background())
+		if err != nil {
+			return fail(err)
+		}
+
+		if *asJSON {
+			out := make([]profileJSON, 0, len(profiles))
+			for _, p := range profiles {
+				j := profileJSON{
+					Name:     p.Name,
+					UUID:     p.UUID,
+					SSID:     p.SSID,
+					Active:   p.Active,
+					Mode:     p.Mode.String(),
+					Priority: p.AutoconnectPriority,
+				}
+				if !p.LastUsed.IsZero() {
+					j.LastUsed = &p.LastUsed
+				}
+				out = append(out, j)
+			}
+			if err := printJSON(os.Stdout, out); err != nil {
+				return fail(err)
+			}
+			return exitOK
+		}
+		printProfiles(os.Stdout, profiles)
+		return exitOK
+	})
+}
+
+func runConnect(args []string) int {
+	fs, asJSON := scriptFlagSet(
+		"connect",
+		"connect [--ask] [--json] SSID",
+		"Connect to the network in range, using its saved profile when there is one.\n"+
+			"On a terminal the TUI opens with the connector for SSID, unless --json or --ask is given\n"+
+			"or the password is in $"+wifiPasswordEnv+". Otherwise a new secured network takes its password\n"+
+			"from $"+wifiPasswordEnv+" or asks for it with --ask.",
+	)
+	ask := fs.Bool("ask", false, "prompt for the password on the terminal")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	interactive := term.IsTerminal(os.Stdin.Fd()) && term.IsTerminal(os.Stdout.Fd())
+	if fs.NArg() == 1 && interactive && !*asJSON && !*ask && os.Getenv(wifiPasswordEnv) == "" {
+		return runTUI(fs.Arg(0))
+	}
+	return runScript(fs, args, 1, 1, func(m managers) int {
+		ctx := context.Background()
+		ssid := fs.Arg(0)
+
+		networks, err := m.networks.ListNetworks(ctx)
+		if err != nil {
+			return fail(err)
+		}
+		if !slices.ContainsFunc(networks, func(n infra.AvailableNetwork) bool { return n.SSID == ssid }) {
+			return notFound(*asJSON, "connect", ssid, "network")
+		}
+
+		password := os.Getenv(wifiPasswordEnv)
+		if password == "" && *ask {
+			if password, err = readWifiPassword(ssid); err != nil {
+				return fail(err)
+			}
+		}
+		if password == "" {
+			err = m.networks.TryActivateNetwork(ctx, ssid)
+		} else {
+			err = m.networks.ConnectToNetwork(ctx, ssid, password)
+		}
+		return reportAction(*asJSON, "connect", ssid, fmt.Sprintf("Connected to %s", ssid), err)
+	})
+}
+
+func runUp(args []string) int {
+	fs, asJSON := scriptFlagSet("up", "up [--json] PROFILE", "Activate the saved profile: connect to its network or start its hotspot.")
+	return runScript(fs, args, 1, 1, func(m managers) int {
+		return switchProfile(m, *asJSON, "up", fs.Arg(0))
+	})
+}
+
+func runDown(args []string) int {
+	fs, asJSON := scriptFlagSet("down", "down [--json] PROFILE", "Deactivate the saved profile.")
+	return runScript(fs, args, 1, 1, func(m managers) int {
+		return switchProfile(m, *asJSON, "down", fs.Arg(0))
+	})
+}
+
+// switchProfile brings the profile called name up or down.
+func switchProfile(m managers, asJSON bool, action, name string) int {
+	ctx := context.Background()
+	profiles, err := m.networks.ListProfiles(ctx)
+	if err != nil {
+		return fail(err)
+	}
+	if !slices.ContainsFunc(profiles, func(p infra.NetworkProfileShort) bool { return p.Name == name }) {
+		return notFound(asJSON, action, name, "profile")
+	}
+
+	if action == "up" {
+		err = m.networks.ActivateProfile(ctx, name)
+		return reportAction(asJSON, action, name, fmt.Sprintf("Activated %s", name), err)
+	}
+	err = m.networks.DeactivateProfile(ctx, name)
+	return reportAction(asJSON, action, name, fmt.Sprintf("Deactivated %s", name), err)
+}
+
+func readWifiPassword(ssid string) (string, error) {
+	fd := os.Stdin.Fd()
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("no terminal to ask for the password: set $%s", wifiPasswordEnv)
+	}
+	fmt.Fprintf(os.Stderr, "Password for %s: ", ssid)
+	p, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	if len(p) == 0 {
+		return "", errors.New("empty password")
+	}
+	return string(p), nil
+}
+
+func printNetworks(w io.Writer, networks []infra.AvailableNetwork) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ACTIVE\tSSID\tSIGNAL\tSECURITY\tBSSID")
+	for _, n := range networks {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", activeMark(n.Active), n.SSID, n.Signal, n.Security, n.BSSID)
+	}
+	_ = tw.Flush()
+}
+
+func printProfiles(w io.Writer, profiles []infra.NetworkProfileShort) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ACTIVE\tNAME\tSSID\tMODE\tPRIORITY\tLAST USED")
+	for _, p := range profiles {
+		lastUsed := "never"
+		if !p.LastUsed.IsZero() {
+			lastUsed = p.LastUsed.Format(time.DateTime)
+		}
+		fmt.Fprintf(
+			tw, "%s\t%s\t%s\t%s\t%d\t%s\n",
+			activeMark(p.Active), p.Name, p.SSID, p.Mode, p.AutoconnectPriority, lastUsed,
+		)
+	}
+	_ = tw.Flush()
+}
+
+func activeMark(active bool) string {
+	if active {
+		return "*"
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/alphameo/nm-tui/internal/ui/models"
+)
+
+func runPick(args []string) int {
+	fs := flag.NewFlagSet("pick", flag.ContinueOnError)
+	inline := fs.Bool("inline", false, "draw under the prompt instead of taking the whole terminal")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: nm-tui pick [--inline]")
+		fmt.Fprintln(fs.Output(), "Pick a network or saved profile from a filterable list, connect to it and exit.")
+		fs.PrintDefaults()
+		fmt.Fprintln(fs.Output(), "Exit codes: 0 connected, 1 failure, 2 usage error, 4 cancelled.")
+	}
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	m, closeLog, err := openManagers()
+	if err != nil {
+		return fail(err)
+	}
+	defer closeLog()
+
+	picker, err := models.NewPickerModel(m.networks, m.cfg, *inline)
+	if err != nil {
+		return fail(err)
+	}
+	if _, err := tea.NewProgram(picker, programOptions()...).Run(); err != nil {
+		return fail(err)
+	}
+
+	res := picker.Result()
+	switch {
+	case res.Err != nil:
+		return fail(res.Err)
+	case res.Picked == "":
+		return exitCancelled
+	}
+	fmt.Fprintf(os.Stdout, "Connected to %s\n", res.Picked)
+	return exitOK
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/alphameo/nm-tui/internal/config"
+	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/alphameo/nm-tui/internal/infra/logging"
+)
+
+// exitCodesHelp documents the exit codes of the scripting subcommands.
+const exitCodesHelp = "Exit codes: 0 success, 1 failure, 2 usage error, 3 network or profile not found."
+
+// managers are the infra managers behind the scripting subcommands, wrapped
+// in the same logging middleware as in the TUI.
+type managers struct {
+	// cfg is the config the managers were opened with.
+	cfg config.Config
+
+	networks infra.NetworksManager
+	device   infra.DeviceManager
+}
+
+// openManagers returns the managers logging to the log file from the config.
+// The returned function closes the log.
+func openManagers() (managers, func(), error) {
+	// Mistakes in the config are reported by the TUI and `config check`,
+	// the scripts only need the log settings.
+	cfg, _ := loadConfig()
+	logger, logFile, err := openLog(cfg)
+	if err != nil {
+		return managers{}, nil, err
+	}
+	slog.SetDefault(logger)
+
+	cli := newBackend()
+	m := managers{
+		cfg:      cfg,
+		networks: logging.NewNetworks(logger, cli),
+		device:   logging.NewDevice(logger, cli),
+	}
+	return m, func() { _ = logFile.Close() }, nil
+}
+
+// scriptFlagSet returns a flag set with the --json flag shared by the
+// scripting subcommands and a usage message ending with the exit codes.
+func scriptFlagSet(name, usage, about string) (*flag.FlagSet, *bool) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print JSON instead of a table")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: nm-tui "+usage)
+		fmt.Fprintln(fs.Output(), about)
+		fs.PrintDefaults()
+		fmt.Fprintln(fs.Output(), exitCodesHelp)
+	}
+	return fs, asJSON
+}
+
+// runScript parses args and runs fn with the managers, once between minArgs
+// and maxArgs arguments are given.
+func runScript(fs *flag.FlagSet, args []string, minArgs, maxArgs int, fn func(m managers) int) int {
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() < minArgs || fs.NArg() > maxArgs {
+		fs.Usage()
+		return exitUsage
+	}
+
+	m, closeLog, err := openManagers()
+	if err != nil {
+		return fail(err)
+	}
+	defer closeLog()
+	return fn(m)
+}
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// actionResult is the JSON outcome of a subcommand changing the state of
+// the system.
+type actionResult struct {
+	Action string `json:"action"`
+	Target string `json:"target"`
+	OK     bool   `json:"ok"`
+	Error  string `json:"error,omitempty"`
+}
+
+// reportAction prints the outcome of action on target and returns the exit
+// code for err. Without asJSON, success is reported with message.
+func reportAction(asJSON bool, action, target, message string, err error) int {
+	if asJSON {
+		res := actionResult{Action: action, Target: target, OK: err == nil}
+		if err != nil {
+			res.Error = err.Error()
+		}
+		if err := printJSON(os.Stdout, res); err != nil {
+			return fail(err)
+		}
+		if res.OK {
+			return exitOK
+		}
+		return exitError
+	}
+
+	if err != nil {
+		return fail(err)
+	}
+	fmt.Fprintln(os.Stdout, message)
+	return exitOK
+}
+
+// notFound reports a missing network or profile, as JSON if asked.
+func notFound(asJSON bool, action, target, what string) int {
+	err := fmt.Errorf("%s %q not found", what, target)
+	if asJSON {
+		res := actionResult{Action: action, Target: target, Error: err.Error()}
+		if err := printJSON(os.Stdout, res); err != nil {
+			return fail(err)
+		}
+	} else {
+		fmt.Fprintf(os.Stderr, "nm-tui: %v\n", err)
+	}
+	return exitNotFound
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/alphameo/nm-tui/internal/config"
+)
+
+const (
+	barFormatWaybar   = "waybar"
+	barFormatJSON     = "json"
+	barFormatTemplate = "template"
+)
+
+const defaultBarTemplate = `{{.Icon}}{{with .SSID}} {{.}} {{$.Signal}}%{{end}}{{if .VPN}} VPN{{end}}`
+
+// barStatus is the status as seen by status bars and templates.
+type barStatus struct {
+	// Icon is the connection, available or error icon from the config,
+	// depending on whether a network is connected, none is or Wi-Fi is off.
+	Icon         string   `json:"icon"`
+	SignalIcon   string   `json:"signal_icon"`
+	SSID         string   `json:"ssid"`
+	Signal       int      `json:"signal"`
+	Security     string   `json:"security"`
+	Connectivity string   `json:"connectivity"`
+	Networking   bool     `json:"networking"`
+	Wifi         bool     `json:"wifi"`
+	WWAN         bool     `json:"wwan"`
+	VPN          []string `json:"vpn"`
+}
+
+func newBarStatus(s statusJSON, icons *config.IconConfig) barStatus {
+	b := barStatus{
+		SignalIcon:   *icons.Signal,
+		Connectivity: s.Connectivity,
+		Networking:   s.Networking,
+		Wifi:         s.Wifi,
+		WWAN:         s.WWAN,
+		VPN:          s.VPN,
+	}
+	switch {
+	case !s.Networking || !s.Wifi:
+		b.Icon = *icons.Error
+	case s.Network != nil:
+		b.Icon = *icons.Connection
+	default:
+		b.Icon = *icons.Available
+	}
+	if s.Network != nil {
+		b.SSID = s.Network.SSID
+		b.Signal = s.Network.Signal
+		b.Security = s.Network.Security
+	}
+	return b
+}
+
+// classes returns the CSS classes of the status for waybar.
+func (b barStatus) classes() []string {
+	classes := []string{b.Connectivity}
+	switch {
+	case !b.Networking:
+		classes = append(classes, "networking-off")
+	case !b.Wifi:
+		classes = append(classes, "wifi-off")
+	case b.SSID == "":
+		classes = append(classes, "disconnected")
+	default:
+		classes = append(classes, "connected")
+	}
+	if len(b.VPN) > 0 {
+		classes = append(classes, "vpn")
+	}
+	return classes
+}
+
+func (b barStatus) tooltip() string {
+	lines := []string{"Connectivity: " + b.Connectivity}
+	if b.SSID != "" {
+		lines = append(lines, fmt.Sprintf("Network: %s (%d%%, %s)", b.SSID, b.Signal, b.Security))
+	}
+	if len(b.VPN) > 0 {
+		lines = append(lines, "VPN: "+strings.Join(b.VPN, ", "))
+	}
+	lines = append(lines, "Wi-Fi radio: "+onOff(b.Wifi), "WWAN radio: "+onOff(b.WWAN))
+	return strings.Join(lines, "\n")
+}
+
+// waybarLine is the JSON read by a waybar custom module with
+// "return-type": "json".
+type waybarLine struct {
+	Text       string   `json:"text"`
+	Alt        string   `json:"alt"`
+	Tooltip    string   `json:"tooltip"`
+	Class      []string `json:"class"`
+	Percentage int      `json:"percentage"`
+}
+
+// newBarRenderer returns the function rendering a status as a single line
+// in format. The text template fills templates and the text of waybar.
+func newBarRenderer(format, text string, icons *config.IconConfig) (func(statusJSON) (string, error), error) {
+	tmpl, err := template.New("status").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("--template: %w", err)
+	}
+	execute := func(b barStatus) (string, error) {
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, b); err != nil {
+			return "", err
+		}
+		// Bars read one status per line.
+		return strings.ReplaceAll(sb.String(), "\n", " "), nil
+	}
+
+	switch format {
+	case barFormatTemplate:
+		return func(s statusJSON) (string, error) {
+			return execute(newBarStatus(s, icons))
+		}, nil
+	case barFormatJSON:
+		return func(s statusJSON) (string, error) {
+			line, err := json.Marshal(newBarStatus(s, icons))
+			return string(line), err
+		}, nil
+	case barFormatWaybar:
+		return func(s statusJSON) (string, error) {
+			b := newBarStatus(s, icons)
+			text, err := execute(b)
+			if err != nil {
+				return "", err
+			}
+			classes := b.classes()
+			line, err := json.Marshal(waybarLine{
+				Text:       text,
+				Alt:        classes[1],
+				Tooltip:    b.tooltip(),
+				Class:      classes,
+				Percentage: b.Signal,
+			})
+			return string(line), err
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown --format %q: use waybar, json or template", format)
+	}
+}
+
+// watchStatus prints a line whenever the rendered status changes, until ctx
+// is done. Failed checks are reported and retried on the next tick, so a
+// bar keeps its last line while NetworkManager is restarting.
+func watchStatus(ctx context.Context, m managers, interval time.Duration, render func(statusJSON) (string, error)) int {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last string
+	for {
+		status, err := collectStatus(ctx, m)
+		switch {
+		case ctx.Err() != nil:
+			return exitOK
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "nm-tui: %v\n", err)
+		default:
+			line, err := render(status)
+			if err != nil {
+				return fail(err)
+			}
+			if line != last {
+				fmt.Fprintln(os.Stdout, line)
+				last = line
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return exitOK
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/config"
+)
+
+var testBarIcons = &config.IconConfig{
+	Error:      new("E"),
+	Connection: new("C"),
+	Available:  new("A"),
+	Signal:     new("S"),
+}
+
+func connectedStatus() statusJSON {
+	return statusJSON{
+		Networking:   true,
+		Connectivity: "full",
+		Wifi:         true,
+		Network:      &networkJSON{SSID: "Home", Signal: 72, Security: "WPA2", Active: true},
+	}
+}
+
+func TestBarStatus(t *testing.T) {
+	t.Parallel()
+
+	withVPN := connectedStatus()
+	withVPN.VPN = []string{"work", "lab"}
+
+	tests := []struct {
+		name        string
+		status      statusJSON
+		wantIcon    string
+		wantClasses []string
+		wantTooltip string
+	}{
+		{
+			name:        "connected",
+			status:      connectedStatus(),
+			wantIcon:    "C",
+			wantClasses: []string{"full", "connected"},
+			wantTooltip: "Connectivity: full\nNetwork: Home (72%, WPA2)\nWi-Fi radio: on\nWWAN radio: off",
+		},
+		{
+			name:        "connected with VPN",
+			status:      withVPN,
+			wantIcon:    "C",
+			wantClasses: []string{"full", "connected", "vpn"},
+			wantTooltip: "Connectivity: full\nNetwork: Home (72%, WPA2)\nVPN: work, lab\nWi-Fi radio: on\nWWAN radio: off",
+		},
+		{
+			name:        "disconnected",
+			status:      statusJSON{Networking: true, Connectivity: "none", Wifi: true, WWAN: true},
+			wantIcon:    "A",
+			wantClasses: []string{"none", "disconnected"},
+			wantTooltip: "Connectivity: none\nWi-Fi radio: on\nWWAN radio: on",
+		},
+		{
+			name:        "wifi off",
+			status:      statusJSON{Networking: true, Connectivity: "limited"},
+			wantIcon:    "E",
+			wantClasses: []string{"limited", "wifi-off"},
+			wantTooltip: "Connectivity: limited\nWi-Fi radio: off\nWWAN radio: off",
+		},
+		{
+			name:        "networking off",
+			status:      statusJSON{Connectivity: "unknown", Wifi: true},
+			wantIcon:    "E",
+			wantClasses: []string{"unknown", "networking-off"},
+			wantTooltip: "Connectivity: unknown\nWi-Fi radio: on\nWWAN radio: off",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			b := newBarStatus(tt.status, testBarIcons)
+			if b.Icon != tt.wantIcon {
+				t.Errorf("Icon = %q, want %q", b.Icon, tt.wantIcon)
+			}
+			if got := b.classes(); !slices.Equal(got, tt.wantClasses) {
+				t.Errorf("classes() = %q, want %q", got, tt.wantClasses)
+			}
+			if got := b.tooltip(); got != tt.wantTooltip {
+				t.Errorf("tooltip() = %q, want %q", got, tt.wantTooltip)
+			}
+		})
+	}
+}
+
+func TestBarRenderer(t *testing.T) {
+	t.Parallel()
+
+	withVPN := connectedStatus()
+	withVPN.VPN = []string{"work"}
+
+	tests := []struct {
+		name   string
+		format string
+		text   string
+		status statusJSON
+		want   string
+	}{
+		{
+			name:   "default template",
+			format: barFormatTemplate,
+			text:   defaultBarTemplate,
+			status: withVPN,
+			want:   "C Home 72% VPN",
+		},
+		{
+			name:   "default template disconnected",
+			format: barFormatTemplate,
+			text:   defaultBarTemplate,
+			status: statusJSON{Networking: true, Wifi: true, Connectivity: "none"},
+			want:   "A",
+		},
+		{
+			name:   "template on one line",
+			format: barFormatTemplate,
+			text:   "{{.SSID}}\n{{.Signal}}",
+			status: connectedStatus(),
+			want:   "Home 72",
+		},
+		{
+			name:   "json",
+			format: barFormatJSON,
+			status: connectedStatus(),
+			want: `{"icon":"C","signal_icon":"S","ssid":"Home","signal":72,"security":"WPA2",` +
+				`"connectivity":"full","networking":true,"wifi":true,"wwan":false,"vpn":null}`,
+		},
+		{
+			name:   "waybar",
+			format: barFormatWaybar,
+			text:   defaultBarTemplate,
+			status: withVPN,
+			want: `{"text":"C Home 72% VPN","alt":"connected",` +
+				`"tooltip":"Connectivity: full\nNetwork: Home (72%, WPA2)\nVPN: work\nWi-Fi radio: on\nWWAN radio: off",` +
+				`"class":["full","connected","vpn"],"percentage":72}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			render, err := newBarRenderer(tt.format, tt.text, testBarIcons)
+			if err != nil {
+				t.Fatalf("newBarRenderer(%q): %v", tt.format, err)
+			}
+			got, err := render(tt.status)
+			if err != nil {
+				t.Fatalf("render(): %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("render() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBarRendererErrors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := newBarRenderer("i3blocks", defaultBarTemplate, testBarIcons); err == nil {
+		t.Error("newBarRenderer() accepts an unknown format")
+	}
+	if _, err := newBarRenderer(barFormatTemplate, "{{.SSID", testBarIcons); err == nil {
+		t.Error("newBarRenderer() accepts a broken template")
+	}
+	render, err := newBarRenderer(barFormatTemplate, "{{.Missing}}", testBarIcons)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := render(connectedStatus()); err == nil {
+		t.Error("render() of a template with an unknown field succeeds")
+	}
+}
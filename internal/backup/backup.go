@@ -0,0 +1,155 @@
+// Package backup provides export and restore of saved Wi-Fi connection
+// profiles through a versioned, optionally encrypted archive
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/alphameo/nm-tui/internal/infra"
+)
+
+const (
+	// FormatName identifies nm-tui archives among other JSON documents.
+	FormatName = "nm-tui-backup"
+	// FormatVersion is the archive layout written by this build. Archives of
+	// newer versions are rejected.
+	FormatVersion = 1
+)
+
+var (
+	ErrNotArchive         = errors.New("not an nm-tui backup archive")
+	ErrUnsupportedVersion = errors.New("unsupported backup archive version")
+	ErrPassphraseRequired = errors.New("backup archive is encrypted, passphrase required")
+	ErrWrongPassphrase    = errors.New("wrong passphrase or corrupted archive")
+	ErrUnsupportedCipher  = errors.New("unsupported archive encryption")
+)
+
+// Profile is a saved Wi-Fi connection with its secrets.
+type Profile struct {
+	Name                string `json:"name"`
+	SSID                string `json:"ssid"`
+	Password            string `json:"password,omitempty"`
+	Hotspot             bool   `json:"hotspot,omitempty"`
+	Autoconnect         bool   `json:"autoconnect"`
+	AutoconnectPriority int    `json:"autoconnect_priority"`
+	// Hidden is set for networks that don't broadcast their SSID.
+	Hidden bool `json:"hidden,omitempty"`
+	// KeyMgmt is the key management NetworkManager reported, like "sae",
+	// kept for reference: restored profiles are WPA-PSK or open.
+	KeyMgmt string `json:"key_mgmt,omitempty"`
+}
+
+type Archive struct {
+	Created  time.Time
+	Profiles []Profile
+}
+
+// envelope is the on-disk layout. Exactly one of Profiles and Payload is set,
+// depending on whether the archive is encrypted.
+type envelope struct {
+	Format     string      `json:"format"`
+	Version    int         `json:"version"`
+	Created    time.Time   `json:"created"`
+	Encryption *encryption `json:"encryption,omitempty"`
+	Profiles   []Profile   `json:"profiles,omitempty"`
+	Payload    []byte      `json:"payload,omitempty"`
+}
+
+// Collect reads every saved Wi-Fi and hotspot profile with its secrets.
+// Profiles of other connection types are skipped.
+func Collect(ctx context.Context, networks infra.NetworksManager) (Archive, error) {
+	list, err := networks.ListProfiles(ctx)
+	if err != nil {
+		return Archive{}, err
+	}
+
+	archive := Archive{Created: time.Now().UTC()}
+	for _, short := range list {
+		if short.Mode != infra.NetworkInfra && short.Mode != infra.NetworkAccessPoint {
+			continue
+		}
+		info, err := networks.GetProfile(ctx, short.Name)
+		if err != nil {
+			return Archive{}, fmt.Errorf("profile %q: %w", short.Name, err)
+		}
+		archive.Profiles = append(archive.Profiles, Profile{
+			Name:                info.Name,
+			SSID:                info.SSID,
+			Password:            info.Password,
+			Hotspot:             info.Mode == infra.NetworkAccessPoint,
+			Autoconnect:         info.Autoconnect,
+			AutoconnectPriority: info.AutoconnectPriority,
+			Hidden:              info.Hidden,
+			KeyMgmt:             info.KeyMgmt,
+		})
+	}
+	return archive, nil
+}
+
+// Write serializes the archive. A non-empty passphrase encrypts the profiles;
+// the format and creation time stay readable.
+func Write(w io.Writer, archive Archive, passphrase string) error {
+	env := envelope{
+		Format:  FormatName,
+		Version: FormatVersion,
+		Created: archive.Created,
+	}
+	if passphrase == "" {
+		env.Profiles = archive.Profiles
+	} else {
+		plain, err := json.Marshal(archive.Profiles)
+		if err != nil {
+			return err
+		}
+		env.Encryption, env.Payload, err = seal(plain, passphrase, additionalData(env))
+		if err != nil {
+			return err
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(env)
+}
+
+// Read parses an archive written by [Write]. The passphrase is ignored for
+// plain archives and required for encrypted ones.
+func Read(r io.Reader, passphrase string) (Archive, error) {
+	var env envelope
+	if err := json.NewDecoder(r).Decode(&env); err != nil {
+		return Archive{}, fmt.Errorf("%w: %w", ErrNotArchive, err)
+	}
+	if env.Format != FormatName {
+		return Archive{}, ErrNotArchive
+	}
+	if env.Version < 1 || env.Version > FormatVersion {
+		return Archive{}, fmt.Errorf("%w: %d", ErrUnsupportedVersion, env.Version)
+	}
+
+	archive := Archive{Created: env.Created, Profiles: env.Profiles}
+	if env.Encryption == nil {
+		return archive, nil
+	}
+	if passphrase == "" {
+		return Archive{}, ErrPassphraseRequired
+	}
+	plain, err := open(env.Encryption, env.Payload, passphrase, additionalData(env))
+	if err != nil {
+		return Archive{}, err
+	}
+	if err := json.Unmarshal(plain, &archive.Profiles); err != nil {
+		return Archive{}, fmt.Errorf("%w: %w", ErrNotArchive, err)
+	}
+	return archive, nil
+}
+
+// additionalData binds the plain header to the ciphertext, so it cannot be
+// swapped between archives.
+func additionalData(env envelope) []byte {
+	return fmt.Appendf(nil, "%s/%d/%s", env.Format, env.Version, env.Created.Format(time.RFC3339Nano))
+}
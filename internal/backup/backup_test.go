@@ -0,0 +1,191 @@
+package backup_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alphameo/nm-tui/internal/backup"
+	"github.com/alphameo/nm-tui/internal/infra"
+)
+
+// fakeNetworks records the profile operations of a restore. Methods that are
+// not overridden panic through the nil embedded interface.
+type fakeNetworks struct {
+	infra.NetworksManager
+
+	profiles map[string]infra.NetworkProfile
+	order    []string
+	calls    []string
+	failOn   string
+}
+
+func newFakeNetworks(profiles ...infra.NetworkProfile) *fakeNetworks {
+	f := &fakeNetworks{profiles: make(map[string]infra.NetworkProfile)}
+	for _, p := range profiles {
+		f.profiles[p.Name] = p
+		f.order = append(f.order, p.Name)
+	}
+	return f
+}
+
+func (f *fakeNetworks) ListProfiles(context.Context) ([]infra.NetworkProfileShort, error) {
+	var res []infra.NetworkProfileShort
+	for _, name := range f.order {
+		p := f.profiles[name]
+		res = append(res, infra.NetworkProfileShort{Name: p.Name, SSID: p.SSID, Mode: p.Mode})
+	}
+	return res, nil
+}
+
+func (f *fakeNetworks) GetProfile(_ context.Context, name string) (infra.NetworkProfile, error) {
+	return f.profiles[name], nil
+}
+
+func (f *fakeNetworks) DeleteProfile(_ context.Context, name string) error {
+	f.calls = append(f.calls, "delete "+name)
+	return nil
+}
+
+func (f *fakeNetworks) CreateConnectionProfile(_ context.Context, name, ssid, _ string, hidden bool) error {
+	call := "create " + name + " " + ssid
+	if hidden {
+		call += " hidden"
+	}
+	f.calls = append(f.calls, call)
+	if name == f.failOn {
+		return errors.New("nmcli failed")
+	}
+	return nil
+}
+
+func (f *fakeNetworks) CreateHotspotProfile(_ context.Context, name, ssid, _ string) error {
+	f.calls = append(f.calls, "hotspot "+name+" "+ssid)
+	if name == f.failOn {
+		return errors.New("nmcli failed")
+	}
+	return nil
+}
+
+func (f *fakeNetworks) UpdateProfile(_ context.Context, name string, info infra.UpdateProfile) error {
+	call := "update " + name
+	if info.Name != name {
+		call += " as " + info.Name
+	}
+	f.calls = append(f.calls, call)
+	return nil
+}
+
+func sampleArchive() backup.Archive {
+	return backup.Archive{
+		Created: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Profiles: []backup.Profile{
+			{Name: "home", SSID: "home", Password: "correct horse", Autoconnect: true, AutoconnectPriority: 10},
+			{Name: "cafe", SSID: "Cafe Free", Hidden: true, KeyMgmt: "none"},
+			{Name: "laptop-ap", SSID: "laptop", Password: "hotspot!", Hotspot: true},
+		},
+	}
+}
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	networks := newFakeNetworks(
+		infra.NetworkProfile{Name: "home", SSID: "home", Password: "pw", Autoconnect: true, Mode: infra.NetworkInfra, KeyMgmt: "sae", Hidden: true},
+		infra.NetworkProfile{Name: "Wired connection 1", Mode: infra.NetworkNil},
+		infra.NetworkProfile{Name: "ap", SSID: "laptop", Password: "hotspot!", Mode: infra.NetworkAccessPoint},
+	)
+
+	archive, err := backup.Collect(context.Background(), networks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []backup.Profile{
+		{Name: "home", SSID: "home", Password: "pw", Autoconnect: true, Hidden: true, KeyMgmt: "sae"},
+		{Name: "ap", SSID: "laptop", Password: "hotspot!", Hotspot: true},
+	}
+	if !reflect.DeepEqual(archive.Profiles, want) {
+		t.Errorf("Collect() profiles = %+v, want %+v", archive.Profiles, want)
+	}
+}
+
+func TestWriteReadRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		passphrase string
+	}{
+		{"plain", ""},
+		{"encrypted", "open sesame"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			if err := backup.Write(&buf, sampleArchive(), tt.passphrase); err != nil {
+				t.Fatal(err)
+			}
+			if tt.passphrase != "" && strings.Contains(buf.String(), "correct horse") {
+				t.Error("encrypted archive contains a plain text password")
+			}
+
+			got, err := backup.Read(&buf, tt.passphrase)
+			if err != nil {
+				t.Fatalf("Read() error: %v", err)
+			}
+			if want := sampleArchive(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Read() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	t.Parallel()
+
+	var encrypted bytes.Buffer
+	if err := backup.Write(&encrypted, sampleArchive(), "open sesame"); err != nil {
+		t.Fatal(err)
+	}
+	tampered := strings.Replace(encrypted.String(), `"created": "2026`, `"created": "2027`, 1)
+	weak := strings.Replace(encrypted.String(), `"iterations": 600000`, `"iterations": 1`, 1)
+	costly := strings.Replace(encrypted.String(), `"iterations": 600000`, `"iterations": 2000000000`, 1)
+
+	tests := []struct {
+		name       string
+		data       string
+		passphrase string
+		want       error
+	}{
+		{"not json", "hello", "", backup.ErrNotArchive},
+		{"other json", `{"name": "x"}`, "", backup.ErrNotArchive},
+		{"future version", `{"format": "nm-tui-backup", "version": 99}`, "", backup.ErrUnsupportedVersion},
+		{"missing passphrase", encrypted.String(), "", backup.ErrPassphraseRequired},
+		{"wrong passphrase", encrypted.String(), "guess", backup.ErrWrongPassphrase},
+		{"tampered header", tampered, "open sesame", backup.ErrWrongPassphrase},
+		{"too few iterations", weak, "open sesame", backup.ErrUnsupportedCipher},
+		{"too many iterations", costly, "open sesame", backup.ErrUnsupportedCipher},
+		{
+			"unknown cipher",
+			`{"format": "nm-tui-backup", "version": 1, "encryption": {"kdf": "argon2id", "cipher": "xchacha"}}`,
+			"pw",
+			backup.ErrUnsupportedCipher,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := backup.Read(strings.NewReader(tt.data), tt.passphrase)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Read() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package backup
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+)
+
+const (
+	kdfPBKDF2SHA256 = "pbkdf2-sha256"
+	cipherAES256GCM = "aes-256-gcm"
+
+	// kdfIterations follows the OWASP recommendation for PBKDF2-HMAC-SHA256.
+	kdfIterations = 600_000
+	// maxKDFIterations bounds the iterations read from an archive, so a
+	// crafted one can't keep an import busy for hours.
+	maxKDFIterations = 10 * kdfIterations
+	saltLen          = 16
+	keyLen           = 32
+)
+
+// encryption records how the payload was sealed, so future versions can
+// change the parameters while still opening older archives.
+type encryption struct {
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Cipher     string `json:"cipher"`
+	Nonce      []byte `json:"nonce"`
+}
+
+func seal(plain []byte, passphrase string, additional []byte) (*encryption, []byte, error) {
+	enc := &encryption{
+		KDF:        kdfPBKDF2SHA256,
+		Iterations: kdfIterations,
+		Salt:       make([]byte, saltLen),
+		Cipher:     cipherAES256GCM,
+	}
+	if _, err := rand.Read(enc.Salt); err != nil {
+		return nil, nil, err
+	}
+
+	aead, err := enc.aead(passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	enc.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(enc.Nonce); err != nil {
+		return nil, nil, err
+	}
+	return enc, aead.Seal(nil, enc.Nonce, plain, additional), nil
+}
+
+func open(enc *encryption, payload []byte, passphrase string, additional []byte) ([]byte, error) {
+	aead, err := enc.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if len(enc.Nonce) != aead.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+	plain, err := aead.Open(nil, enc.Nonce, payload, additional)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plain, nil
+}
+
+func (e *encryption) aead(passphrase string) (cipher.AEAD, error) {
+	if e.KDF != kdfPBKDF2SHA256 || e.Cipher != cipherAES256GCM {
+		return nil, fmt.Errorf("%w: %s/%s", ErrUnsupportedCipher, e.KDF, e.Cipher)
+	}
+	// Fewer iterations than nm-tui uses would accept a weakly protected
+	// archive.
+	if e.Iterations < kdfIterations || e.Iterations > maxKDFIterations {
+		return nil, fmt.Errorf("%w: %d %s iterations", ErrUnsupportedCipher, e.Iterations, e.KDF)
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, e.Salt, e.Iterations, keyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
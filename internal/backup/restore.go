@@ -0,0 +1,267 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alphameo/nm-tui/internal/infra"
+)
+
+// Conflict describes how an archived profile clashes with saved ones.
+type Conflict int
+
+const ConflictNone Conflict = 0
+
+const (
+	ConflictName Conflict = 1 << iota
+	ConflictSSID
+)
+
+func (c Conflict) String() string {
+	var parts []string
+	if c&ConflictName != 0 {
+		parts = append(parts, "name")
+	}
+	if c&ConflictSSID != 0 {
+		parts = append(parts, "SSID")
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Action is what restoring does with an archived profile.
+type Action int
+
+const (
+	// ActionCreate creates the profile, used when nothing conflicts.
+	ActionCreate Action = iota
+	// ActionSkip leaves the profile out.
+	ActionSkip
+	// ActionOverwrite replaces every conflicting saved profile, deleting them
+	// once the profile is created.
+	ActionOverwrite
+	// ActionRename creates the profile under [Entry.NewName], keeping the
+	// conflicting ones.
+	ActionRename
+)
+
+func (a Action) String() string {
+	switch a {
+	case ActionCreate:
+		return "create"
+	case ActionSkip:
+		return "skip"
+	case ActionOverwrite:
+		return "overwrite"
+	case ActionRename:
+		return "rename"
+	default:
+		return "undefined"
+	}
+}
+
+// Entry is one archived profile in a restore plan.
+type Entry struct {
+	Profile  Profile
+	Conflict Conflict
+	// Existing lists the saved profiles the entry conflicts with.
+	Existing []string
+	Action   Action
+	// NewName is a free name used by [ActionRename].
+	NewName string
+}
+
+// Actions returns the actions that make sense for the entry, starting with
+// the default one.
+func (e *Entry) Actions() []Action {
+	if e.Conflict == ConflictNone {
+		return []Action{ActionCreate, ActionSkip}
+	}
+	return []Action{ActionSkip, ActionOverwrite, ActionRename}
+}
+
+// CycleAction switches the entry to its next available action.
+func (e *Entry) CycleAction() {
+	actions := e.Actions()
+	i := slices.Index(actions, e.Action)
+	e.Action = actions[(i+1)%len(actions)]
+}
+
+// SetAction applies the action if it is available for the entry.
+func (e *Entry) SetAction(action Action) bool {
+	if !slices.Contains(e.Actions(), action) {
+		return false
+	}
+	e.Action = action
+	return true
+}
+
+// TargetName is the name the profile gets once restored.
+func (e *Entry) TargetName() string {
+	if e.Action == ActionRename {
+		return e.NewName
+	}
+	return e.Profile.Name
+}
+
+// Plan matches archived profiles against saved ones by name and SSID.
+// Conflicting entries default to [ActionSkip], the others to [ActionCreate].
+func Plan(archive Archive, saved []infra.NetworkProfileShort) []Entry {
+	taken := make(map[string]bool, len(saved)+len(archive.Profiles))
+	for _, s := range saved {
+		taken[s.Name] = true
+	}
+	for _, p := range archive.Profiles {
+		taken[p.Name] = true
+	}
+
+	entries := make([]Entry, len(archive.Profiles))
+	for i, p := range archive.Profiles {
+		e := Entry{Profile: p}
+		for _, s := range saved {
+			var c Conflict
+			if s.Name == p.Name {
+				c |= ConflictName
+			}
+			if p.SSID != "" && s.SSID == p.SSID {
+				c |= ConflictSSID
+			}
+			if c != ConflictNone {
+				e.Conflict |= c
+				e.Existing = append(e.Existing, s.Name)
+			}
+		}
+		e.Action = e.Actions()[0]
+		e.NewName = freeName(p.Name, taken)
+		taken[e.NewName] = true
+		entries[i] = e
+	}
+	return entries
+}
+
+// freeName appends the lowest counter that makes name unique.
+func freeName(name string, taken map[string]bool) string {
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s (%d)", name, n)
+		if !taken[candidate] {
+			return candidate
+		}
+	}
+}
+
+// Report summarizes a restore.
+type Report struct {
+	Created     int
+	Overwritten int
+	Renamed     int
+	Skipped     int
+	Errors      []error
+}
+
+func (r Report) String() string {
+	s := fmt.Sprintf(
+		"%d created, %d overwritten, %d renamed, %d skipped",
+		r.Created, r.Overwritten, r.Renamed, r.Skipped,
+	)
+	if len(r.Errors) > 0 {
+		s += fmt.Sprintf(", %d failed", len(r.Errors))
+	}
+	return s
+}
+
+// Err joins the errors of failed entries.
+func (r Report) Err() error {
+	return errors.Join(r.Errors...)
+}
+
+// restoringSuffix marks a profile created over a saved one of the same name,
+// until that one is deleted.
+const restoringSuffix = " (restoring)"
+
+// Restore applies the plan. A failing entry does not stop the others.
+func Restore(ctx context.Context, networks infra.NetworksManager, entries []Entry) Report {
+	var report Report
+	deleted := make(map[string]bool)
+	for _, e := range entries {
+		if e.Action == ActionSkip {
+			report.Skipped++
+			continue
+		}
+
+		if err := restoreEntry(ctx, networks, e, deleted); err != nil {
+			report.Errors = append(report.Errors, fmt.Errorf("profile %q: %w", e.Profile.Name, err))
+			continue
+		}
+		switch e.Action {
+		case ActionCreate:
+			report.Created++
+		case ActionOverwrite:
+			report.Overwritten++
+		case ActionRename:
+			report.Renamed++
+		}
+	}
+	return report
+}
+
+// restoreEntry creates the profile of a single entry. deleted remembers the
+// saved profiles already removed by earlier overwrites.
+//
+// An overwrite creates the profile before deleting the conflicting ones, so a
+// failing create leaves them alone. While a saved profile still holds the
+// name, the new one is created under a temporary name and renamed last.
+func restoreEntry(ctx context.Context, networks infra.NetworksManager, e Entry, deleted map[string]bool) error {
+	p := e.Profile
+	name := e.TargetName()
+	createName := name
+	var replaced []string
+	if e.Action == ActionOverwrite {
+		for _, existing := range e.Existing {
+			if !deleted[existing] {
+				replaced = append(replaced, existing)
+			}
+		}
+		if slices.Contains(replaced, name) {
+			createName = name + restoringSuffix
+		}
+	}
+
+	var err error
+	if p.Hotspot {
+		err = networks.CreateHotspotProfile(ctx, createName, p.SSID, p.Password)
+	} else {
+		err = networks.CreateConnectionProfile(ctx, createName, p.SSID, p.Password, p.Hidden)
+	}
+	if err != nil {
+		return err
+	}
+
+	for i, existing := range replaced {
+		if err := networks.DeleteProfile(ctx, existing); err != nil {
+			if i > 0 {
+				return fmt.Errorf(
+					"%w, after deleting %q, restored profile saved as %q",
+					err, replaced[:i], createName,
+				)
+			}
+			return fmt.Errorf("%w, restored profile saved as %q", err, createName)
+		}
+		deleted[existing] = true
+	}
+
+	err = networks.UpdateProfile(ctx, createName, infra.UpdateProfile{
+		Name:                name,
+		Password:            p.Password,
+		Autoconnect:         p.Autoconnect,
+		AutoconnectPriority: p.AutoconnectPriority,
+	})
+	if err != nil && createName != name {
+		return fmt.Errorf("%w, restored profile saved as %q", err, createName)
+	}
+	return err
+}
@@ -0,0 +1,117 @@
+package backup_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/backup"
+	"github.com/alphameo/nm-tui/internal/infra"
+)
+
+func TestPlan(t *testing.T) {
+	t.Parallel()
+
+	saved := []infra.NetworkProfileShort{
+		{Name: "home", SSID: "home"},
+		{Name: "coffee", SSID: "Cafe Free"},
+		{Name: "home (2)", SSID: "elsewhere"},
+	}
+
+	entries := backup.Plan(sampleArchive(), saved)
+
+	type summary struct {
+		name     string
+		conflict backup.Conflict
+		existing []string
+		action   backup.Action
+		newName  string
+	}
+	got := make([]summary, len(entries))
+	for i, e := range entries {
+		got[i] = summary{e.Profile.Name, e.Conflict, e.Existing, e.Action, e.NewName}
+	}
+	want := []summary{
+		{"home", backup.ConflictName | backup.ConflictSSID, []string{"home"}, backup.ActionSkip, "home (3)"},
+		{"cafe", backup.ConflictSSID, []string{"coffee"}, backup.ActionSkip, "cafe (2)"},
+		{"laptop-ap", backup.ConflictNone, nil, backup.ActionCreate, "laptop-ap (2)"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Plan() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestEntryActions(t *testing.T) {
+	t.Parallel()
+
+	conflicting := backup.Entry{Conflict: backup.ConflictName, Action: backup.ActionSkip}
+	var cycled []backup.Action
+	for range 3 {
+		conflicting.CycleAction()
+		cycled = append(cycled, conflicting.Action)
+	}
+	if want := []backup.Action{backup.ActionOverwrite, backup.ActionRename, backup.ActionSkip}; !reflect.DeepEqual(cycled, want) {
+		t.Errorf("CycleAction() sequence = %v, want %v", cycled, want)
+	}
+
+	fresh := backup.Entry{Action: backup.ActionCreate}
+	if fresh.SetAction(backup.ActionOverwrite) {
+		t.Error("SetAction(overwrite) accepted for an entry without conflicts")
+	}
+	if !fresh.SetAction(backup.ActionSkip) || fresh.Action != backup.ActionSkip {
+		t.Error("SetAction(skip) rejected for an entry without conflicts")
+	}
+}
+
+func TestRestore(t *testing.T) {
+	t.Parallel()
+
+	networks := newFakeNetworks()
+	networks.failOn = "broken"
+	entries := []backup.Entry{
+		{Profile: backup.Profile{Name: "home", SSID: "home"}, Action: backup.ActionOverwrite, Existing: []string{"home", "old"}},
+		{Profile: backup.Profile{Name: "home-5g", SSID: "home"}, Action: backup.ActionOverwrite, Existing: []string{"home"}},
+		{Profile: backup.Profile{Name: "cafe", SSID: "cafe", Hidden: true}, Action: backup.ActionRename, NewName: "cafe (2)"},
+		{Profile: backup.Profile{Name: "ap", SSID: "laptop", Hotspot: true}, Action: backup.ActionCreate},
+		{Profile: backup.Profile{Name: "lab", SSID: "lab"}, Action: backup.ActionSkip},
+		{Profile: backup.Profile{Name: "broken", SSID: "x"}, Action: backup.ActionCreate},
+	}
+
+	report := backup.Restore(context.Background(), networks, entries)
+
+	wantCalls := []string{
+		"create home (restoring) home", "delete home", "delete old", "update home (restoring) as home",
+		"create home-5g home", "update home-5g",
+		"create cafe (2) cafe hidden", "update cafe (2)",
+		"hotspot ap laptop", "update ap",
+		"create broken x",
+	}
+	if !reflect.DeepEqual(networks.calls, wantCalls) {
+		t.Errorf("calls =\n%q\nwant\n%q", networks.calls, wantCalls)
+	}
+	if report.Created != 1 || report.Overwritten != 2 || report.Renamed != 1 || report.Skipped != 1 || len(report.Errors) != 1 {
+		t.Errorf("report = %s", report)
+	}
+	if want := "1 created, 2 overwritten, 1 renamed, 1 skipped, 1 failed"; report.String() != want {
+		t.Errorf("report.String() = %q, want %q", report.String(), want)
+	}
+}
+
+func TestRestoreOverwriteKeepsProfileOnFailure(t *testing.T) {
+	t.Parallel()
+
+	networks := newFakeNetworks()
+	networks.failOn = "ap (restoring)"
+	entries := []backup.Entry{
+		{Profile: backup.Profile{Name: "ap", SSID: "laptop", Hotspot: true}, Action: backup.ActionOverwrite, Existing: []string{"ap"}},
+	}
+
+	report := backup.Restore(context.Background(), networks, entries)
+
+	if want := []string{"hotspot ap (restoring) laptop"}; !reflect.DeepEqual(networks.calls, want) {
+		t.Errorf("calls = %q, want %q", networks.calls, want)
+	}
+	if report.Overwritten != 0 || len(report.Errors) != 1 {
+		t.Errorf("report = %s, want the entry failed", report)
+	}
+}
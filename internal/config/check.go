@@ -0,0 +1,191 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/calico32/kdl-go"
+)
+
+// Problem is a mistake in a config file, located at the node it comes from.
+type Problem struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+func (p Problem) Error() string {
+	return fmt.Sprintf("%d:%d: %v", p.Line, p.Column, p.Err)
+}
+
+func (p Problem) Unwrap() error { return p.Err }
+
+// Report is the outcome of [Check].
+type Report struct {
+	Problems []Problem
+	// Set holds the paths of the settings taken from the file, like
+	// "colors accent". Settings with problems or set to "default" are left out.
+	Set map[string]bool
+}
+
+// Check validates the config read from r. Where [LoadOrDefaults] merges what
+// it can, Check reports every syntax error, unknown node, rejected value and
+// key conflict, each at the node causing it. The error is only set if r can't
+// be read.
+func Check(r io.Reader) (Report, error) {
+	report := Report{Set: make(map[string]bool)}
+
+	res, err := kdl.ParseWithDiagnostics(r)
+	if err != nil && res == nil {
+		return report, fmt.Errorf("read config: %w", err)
+	}
+	for _, d := range res.Diagnostics {
+		if d.Severity == kdl.SeverityError {
+			report.Problems = append(report.Problems, Problem{
+				Line:   d.Start.Line,
+				Column: d.Start.Column,
+				Err:    errors.New(d.Message),
+			})
+		}
+	}
+	if res.HasErrors() {
+		return report, nil
+	}
+
+	c := checker{
+		report:    &report,
+		effective: DefaultConfig(),
+		locations: make(map[string]kdl.Location),
+	}
+	c.checkNodes(res.Document.Nodes, nil, reflect.TypeFor[Config]())
+	c.checkKeyConflicts()
+	return report, nil
+}
+
+type checker struct {
+	report *Report
+	// effective is the config built from the valid settings.
+	effective Config
+	// locations maps the paths of the settings to their nodes.
+	locations map[string]kdl.Location
+}
+
+func (c *checker) problem(loc kdl.Location, err error) {
+	c.report.Problems = append(c.report.Problems, Problem{Line: loc.Line, Column: loc.Column, Err: err})
+}
+
+// checkNodes walks nodes decoded into the struct type t. Every setting is
+// decoded and merged on its own, so the errors of a merge belong to it.
+func (c *checker) checkNodes(nodes []*kdl.Node, parents []*kdl.Node, t reflect.Type) {
+	for _, node := range nodes {
+		loc := node.Location()
+		problem := func(err error) { c.problem(loc, err) }
+
+		field, ok := fieldForNode(t, node.Name())
+		if !ok {
+			problem(fmt.Errorf("unknown setting %q", nodePath(append(parents, node))))
+			continue
+		}
+		if section := indirect(field.Type); section.Kind() == reflect.Struct {
+			c.checkNodes(node.Children().Nodes, append(parents, node), section)
+			continue
+		}
+
+		// Decoding into Config converts mismatched values silently, strict
+		// decoding of the lone field does not.
+		value := reflect.New(indirect(field.Type)).Interface()
+		if err := kdl.UnmarshalStrict(node, value); err != nil {
+			problem(fmt.Errorf("%s: %w", node.Name(), err))
+			continue
+		}
+		var cfg Config
+		if err := kdl.UnmarshalDocument(standalone(parents, node), &cfg); err != nil {
+			problem(fmt.Errorf("%s: %w", node.Name(), err))
+			continue
+		}
+		merged := DefaultConfig()
+		errs := merged.Merge(&cfg)
+		for _, err := range errs {
+			problem(err)
+		}
+		path := nodePath(append(parents, node))
+		// Themes are files of their own, so a name is only valid if it loads.
+		if path == "theme name" && len(errs) == 0 {
+			if _, err := LoadTheme(*merged.Theme.Name); err != nil {
+				problem(err)
+				continue
+			}
+		}
+		if len(errs) > 0 {
+			continue
+		}
+		c.effective.Merge(&cfg)
+		c.locations[path] = loc
+		if !isDefaultKeyword(node) {
+			c.report.Set[path] = true
+		}
+	}
+}
+
+// checkKeyConflicts reports the conflicts of the effective key bindings at
+// the shadowed action, or at the one shadowing it if the shadowed one is not
+// in the file.
+func (c *checker) checkKeyConflicts() {
+	for _, conflict := range c.effective.Keys.Conflicts() {
+		loc, ok := c.locations[keyPath(conflict.Shadowed)]
+		if !ok {
+			loc = c.locations[keyPath(conflict.Action)]
+		}
+		c.problem(loc, conflict)
+	}
+}
+
+// keyPath turns the name of a binding, like "main.quit", into its path.
+func keyPath(name string) string {
+	return "keys " + strings.ReplaceAll(name, ".", " ")
+}
+
+// fieldForNode finds the field of the struct type t decoded from the node
+// called name.
+func fieldForNode(t reflect.Type, name string) (reflect.StructField, bool) {
+	for field := range t.Fields() {
+		tag, _, _ := strings.Cut(field.Tag.Get("kdl"), ",")
+		if tag == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// standalone returns a document holding only node, nested in copies of its
+// parents.
+func standalone(parents []*kdl.Node, node *kdl.Node) *kdl.Document {
+	n := node.Clone()
+	for i := len(parents) - 1; i >= 0; i-- {
+		n = kdl.NewNode(parents[i].Name()).AddChild(n)
+	}
+	return kdl.NewDocument(n)
+}
+
+func nodePath(nodes []*kdl.Node) string {
+	names := make([]string, len(nodes))
+	for i, n := range nodes {
+		names[i] = n.Name()
+	}
+	return strings.Join(names, " ")
+}
+
+func isDefaultKeyword(node *kdl.Node) bool {
+	args := node.Arguments()
+	return len(args) == 1 && args[0].String() == DefaultKeyword
+}
@@ -0,0 +1,146 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/config"
+	"github.com/calico32/kdl-go"
+)
+
+func TestCheck(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	type problem struct {
+		line, column int
+		fragment     string
+	}
+	tests := []struct {
+		name     string
+		src      string
+		problems []problem
+		set      []string
+	}{
+		{name: "empty", src: ""},
+		{
+			name: "valid settings",
+			src:  "mouse #false\ncolors {\n    accent \"#865fff\"\n    muted default\n}\n",
+			set:  []string{"mouse", "colors accent"},
+		},
+		{
+			name:     "syntax error",
+			src:      "colors {\n    accent \"#865fff\"\n",
+			problems: []problem{{2, 22, ""}},
+		},
+		{
+			name:     "unknown nodes",
+			src:      "colours {\n}\nkeys {\n    main {\n        quitt q\n    }\n}\n",
+			problems: []problem{{1, 1, `"colours"`}, {5, 9, `"keys main quitt"`}},
+		},
+		{
+			name:     "mismatched types",
+			src:      "mouse yes\nrescan_interval 1.5\n",
+			problems: []problem{{1, 1, "mouse"}, {2, 1, "rescan_interval"}},
+		},
+		{
+			name:     "rejected values",
+			src:      "notification_close_time 0\ncolors {\n    text \"#865fff\"\n    accent nope\n}\n",
+			problems: []problem{{1, 1, "notification_close_time"}, {4, 5, "accent"}},
+			set:      []string{"colors text"},
+		},
+		{
+			name:     "missing theme",
+			src:      "theme {\n    name nope\n}\n",
+			problems: []problem{{2, 5, "not found"}},
+		},
+		{
+			name:     "key conflict",
+			src:      "keys {\n    network_profiles {\n        delete q\n    }\n}\n",
+			problems: []problem{{3, 9, "main.quit shadows network_profiles.delete"}},
+			set:      []string{"keys network_profiles delete"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := config.Check(strings.NewReader(tt.src))
+			if err != nil {
+				t.Fatalf("Check() error: %v", err)
+			}
+
+			if len(report.Problems) != len(tt.problems) {
+				t.Fatalf("Check() problems = %v, want %d", report.Problems, len(tt.problems))
+			}
+			for i, want := range tt.problems {
+				got := report.Problems[i]
+				if got.Line != want.line || got.Column != want.column {
+					t.Errorf("problem %d at %d:%d, want %d:%d", i, got.Line, got.Column, want.line, want.column)
+				}
+				if !strings.Contains(got.Error(), want.fragment) {
+					t.Errorf("problem %d = %q, want it to contain %q", i, got.Error(), want.fragment)
+				}
+			}
+
+			set := make(map[string]bool)
+			for _, path := range tt.set {
+				set[path] = true
+			}
+			if !reflect.DeepEqual(report.Set, set) {
+				t.Errorf("Check() set = %v, want %v", report.Set, set)
+			}
+		})
+	}
+}
+
+func TestCheckExampleConfig(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	f, err := os.Open(filepath.Join("..", "..", "config.example.kdl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	report, err := config.Check(f)
+	if err != nil {
+		t.Fatalf("Check() error: %v", err)
+	}
+	for _, p := range report.Problems {
+		t.Errorf("config.example.kdl:%v", p)
+	}
+}
+
+func TestDump(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.Colors.Accent = new("#865fff")
+	var b strings.Builder
+	if err := config.Dump(&b, cfg, map[string]bool{"colors accent": true}); err != nil {
+		t.Fatalf("Dump() error: %v", err)
+	}
+	out := b.String()
+
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasSuffix(line, "{") || line == "}" {
+			continue
+		}
+		userSet := strings.HasPrefix(line, "accent ")
+		if marked := strings.HasSuffix(line, "// default"); marked == userSet {
+			t.Errorf("line %q: default marker = %v, want %v", line, marked, !userSet)
+		}
+	}
+
+	var got config.Config
+	if err := kdl.Decode(strings.NewReader(out), &got); err != nil {
+		t.Fatalf("decode dump: %v\n%s", err, out)
+	}
+	if !reflect.DeepEqual(got, cfg) {
+		t.Errorf("dump decodes to\n%#v\nwant\n%#v", got, cfg)
+	}
+}
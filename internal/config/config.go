@@ -15,22 +15,32 @@ const (
 )
 
 type Config struct {
-	Colors         *ColorConfig `kdl:"colors"`
-	Keys           *KeyConfig   `kdl:"keys"`
-	Logging        *LogConfig   `kdl:"logging"`
-	Icons          *IconConfig  `kdl:"icons"`
-	NotifCloseTime *int         `kdl:"notification_close_time"`
-	RescanInterval *int         `kdl:"rescan_interval"`
+	Theme          *ThemeConfig    `kdl:"theme"`
+	Colors         *ColorConfig    `kdl:"colors"`
+	Keys           *KeyConfig      `kdl:"keys"`
+	Logging        *LogConfig      `kdl:"logging"`
+	Icons          *IconConfig     `kdl:"icons"`
+	Watchdog       *WatchdogConfig `kdl:"watchdog"`
+	Portal         *PortalConfig   `kdl:"portal"`
+	Monitor        *MonitorConfig  `kdl:"monitor"`
+	NotifCloseTime *int            `kdl:"notification_close_time"`
+	RescanInterval *int            `kdl:"rescan_interval"`
+	Mouse          *bool           `kdl:"mouse"`
 }
 
 func DefaultConfig() Config {
 	return Config{
+		Theme:          DefaultThemeConfig(),
 		Colors:         DefaultColorConfig(),
 		Keys:           DefaultKeys(),
 		Logging:        DefaultLogConfig(),
 		Icons:          DefaultIconConfig(),
+		Watchdog:       DefaultWatchdogConfig(),
+		Portal:         DefaultPortalConfig(),
+		Monitor:        DefaultMonitorConfig(),
 		NotifCloseTime: new(5),
 		RescanInterval: new(10),
+		Mouse:          new(true),
 	}
 }
 
@@ -41,6 +51,10 @@ func (c *Config) Merge(src *Config) []error {
 		errs = append(errs, c.Logging.Merge(src.Logging)...)
 	}
 
+	if src.Theme != nil {
+		errs = append(errs, c.Theme.Merge(src.Theme)...)
+	}
+
 	if src.Colors != nil {
 		errs = append(errs, c.Colors.Merge(src.Colors)...)
 	}
@@ -57,6 +71,18 @@ func (c *Config) Merge(src *Config) []error {
 		errs = append(errs, c.Icons.Merge(src.Icons)...)
 	}
 
+	if src.Watchdog != nil {
+		errs = append(errs, c.Watchdog.Merge(src.Watchdog)...)
+	}
+
+	if src.Portal != nil {
+		errs = append(errs, c.Portal.Merge(src.Portal)...)
+	}
+
+	if src.Monitor != nil {
+		errs = append(errs, c.Monitor.Merge(src.Monitor)...)
+	}
+
 	if src.NotifCloseTime != nil {
 		time := *src.NotifCloseTime
 		err := validatePositiveTime(time)
@@ -78,6 +104,10 @@ func (c *Config) Merge(src *Config) []error {
 			c.RescanInterval = src.RescanInterval
 		}
 	}
+
+	if src.Mouse != nil {
+		c.Mouse = src.Mouse
+	}
 	return errs
 }
 
@@ -86,7 +116,11 @@ func Load() (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("resolve config path: %w", err)
 	}
+	return LoadFile(path)
+}
 
+// LoadFile decodes the config file at path without merging it.
+func LoadFile(path string) (*Config, error) {
 	f, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("open config: %w", err)
@@ -24,10 +24,13 @@ func TestConfigMerge(t *testing.T) {
 	}{
 		{name: "empty source produces no errors", src: &config.Config{}},
 		{name: "nil child sections are handled", src: &config.Config{
-			Logging: &config.LogConfig{},
-			Colors:  &config.ColorConfig{},
-			Keys:    &config.KeyConfig{},
-			Icons:   &config.IconConfig{},
+			Logging:  &config.LogConfig{},
+			Colors:   &config.ColorConfig{},
+			Keys:     &config.KeyConfig{},
+			Icons:    &config.IconConfig{},
+			Watchdog: &config.WatchdogConfig{},
+			Portal:   &config.PortalConfig{},
+			Monitor:  &config.MonitorConfig{},
 		}},
 		{
 			name: "notification close time valid",
@@ -85,6 +88,61 @@ func TestConfigMerge(t *testing.T) {
 			wantErr:   1,
 			fragments: []string{"text color"},
 		},
+		{
+			name: "watchdog settings are merged",
+			src: &config.Config{Watchdog: &config.WatchdogConfig{
+				Enabled:     new(true),
+				GracePeriod: new(10),
+				Backoff:     new(0),
+			}},
+			wantErr:   1,
+			fragments: []string{"watchdog backoff"},
+			check: func(t *testing.T, cfg *config.Config) {
+				if !*cfg.Watchdog.Enabled || *cfg.Watchdog.GracePeriod != 10 {
+					t.Errorf("Watchdog = enabled %v, grace %d, want enabled, grace 10",
+						*cfg.Watchdog.Enabled, *cfg.Watchdog.GracePeriod)
+				}
+				if got, want := *cfg.Watchdog.Backoff, *config.DefaultWatchdogConfig().Backoff; got != want {
+					t.Errorf("Backoff should stay default, got %d want %d", got, want)
+				}
+			},
+		},
+		{
+			name: "monitor settings are merged",
+			src: &config.Config{Monitor: &config.MonitorConfig{
+				SampleInterval: new(2),
+				History:        new(-60),
+			}},
+			wantErr:   1,
+			fragments: []string{"monitor history"},
+			check: func(t *testing.T, cfg *config.Config) {
+				if got := *cfg.Monitor.SampleInterval; got != 2 {
+					t.Errorf("SampleInterval = %d, want 2", got)
+				}
+				if got, want := *cfg.Monitor.History, *config.DefaultMonitorConfig().History; got != want {
+					t.Errorf("History should stay default, got %d want %d", got, want)
+				}
+			},
+		},
+		{
+			name: "portal settings are merged",
+			src: &config.Config{Portal: &config.PortalConfig{
+				CheckURL: new("file:///etc/hostname"),
+				Browser:  new("firefox --new-window"),
+				AutoOpen: new(true),
+			}},
+			wantErr:   1,
+			fragments: []string{"portal check_url", "not http or https"},
+			check: func(t *testing.T, cfg *config.Config) {
+				if got, want := *cfg.Portal.CheckURL, *config.DefaultPortalConfig().CheckURL; got != want {
+					t.Errorf("CheckURL should stay default, got %q want %q", got, want)
+				}
+				if *cfg.Portal.Browser != "firefox --new-window" || !*cfg.Portal.AutoOpen {
+					t.Errorf("Portal = browser %q, auto open %v, want firefox, auto open",
+						*cfg.Portal.Browser, *cfg.Portal.AutoOpen)
+				}
+			},
+		},
 		{
 			name:      "keys merge errors are propagated",
 			src:       &config.Config{Keys: &config.KeyConfig{Toggle: &config.KeyBinding{"notakey"}}},
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/calico32/kdl-go"
+)
+
+// defaultComment marks dumped settings not taken from the config file.
+const defaultComment = "// default"
+
+// Dump writes cfg as KDL. Settings missing from set, as reported by [Check],
+// are commented as defaults.
+func Dump(w io.Writer, cfg Config, set map[string]bool) error {
+	doc, err := kdl.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("encode config: %w", err)
+	}
+
+	var b strings.Builder
+	if err = dumpNodes(&b, doc.Nodes, "", 0, set); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+func dumpNodes(b *strings.Builder, nodes []*kdl.Node, prefix string, depth int, set map[string]bool) error {
+	indent := strings.Repeat("    ", depth)
+	for _, node := range nodes {
+		path := prefix + node.Name()
+		if children := node.Children().Nodes; len(children) > 0 {
+			fmt.Fprintf(b, "%s%s {\n", indent, node.Name())
+			if err := dumpNodes(b, children, path+" ", depth+1, set); err != nil {
+				return err
+			}
+			fmt.Fprintf(b, "%s}\n", indent)
+			continue
+		}
+
+		line, err := kdl.EmitToString(kdl.NewDocument(node))
+		if err != nil {
+			return fmt.Errorf("encode %s: %w", path, err)
+		}
+		b.WriteString(indent + strings.TrimSpace(line))
+		if !set[path] {
+			b.WriteString(" " + defaultComment)
+		}
+		b.WriteByte('\n')
+	}
+	return nil
+}
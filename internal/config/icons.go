@@ -55,6 +55,11 @@ type IconConfig struct {
 	Infra            *string `kdl:"infra"`
 	Mesh             *string `kdl:"mesh"`
 	AdHoc            *string `kdl:"ad_hoc"`
+	Marked           *string `kdl:"marked"`
+	SortAscending    *string `kdl:"sort_ascending"`
+	SortDescending   *string `kdl:"sort_descending"`
+	SignalRising     *string `kdl:"signal_rising"`
+	SignalFalling    *string `kdl:"signal_falling"`
 	Ellipsis         *string `kdl:"ellipsis"`
 	Separator        *string `kdl:"separator"`
 }
@@ -79,6 +84,11 @@ func DefaultNerdIconConfig() *IconConfig {
 		Mesh:             new(" "),
 		AdHoc:            new(""),
 		Separator:        new("•"),
+		Marked:           new("󰄲 "),
+		SortAscending:    new("▲"),
+		SortDescending:   new("▼"),
+		SignalRising:     new("↑"),
+		SignalFalling:    new("↓"),
 		Ellipsis:         new("…"),
 	}
 }
@@ -102,6 +112,11 @@ func DefaultNonNerdIconConfig() *IconConfig {
 		Infra:            new("infr"),
 		Mesh:             new("#"),
 		AdHoc:            new("ah"),
+		Marked:           new("*"),
+		SortAscending:    new("^"),
+		SortDescending:   new("v"),
+		SignalRising:     new("+"),
+		SignalFalling:    new("-"),
 		Separator:        new("|"),
 		Ellipsis:         new("_"),
 	}
@@ -135,6 +150,11 @@ func (c *IconConfig) Merge(src *IconConfig) []error {
 	collect(mergeIcon(c.Infra, src.Infra, "infra"))
 	collect(mergeIcon(c.Mesh, src.Mesh, "mesh"))
 	collect(mergeIcon(c.AdHoc, src.AdHoc, "ad_hoc"))
+	collect(mergeIcon(c.Marked, src.Marked, "marked"))
+	collect(mergeIcon(c.SortAscending, src.SortAscending, "sort_ascending"))
+	collect(mergeIcon(c.SortDescending, src.SortDescending, "sort_descending"))
+	collect(mergeIcon(c.SignalRising, src.SignalRising, "signal_rising"))
+	collect(mergeIcon(c.SignalFalling, src.SignalFalling, "signal_falling"))
 	collect(mergeIcon(c.Ellipsis, src.Ellipsis, "ellipsis"))
 	collect(mergeIcon(c.Separator, src.Separator, "separator"))
 
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+)
+
+// KeyConflict is a key bound to two actions that are active at the same time.
+// Action is matched first, so Shadowed never fires for the key.
+type KeyConflict struct {
+	Key      string
+	Action   string
+	Shadowed string
+}
+
+func (c KeyConflict) Error() string {
+	return fmt.Sprintf("key %q of %s shadows %s", c.Key, c.Action, c.Shadowed)
+}
+
+// namedBinding is a binding with its path in the keys section, like
+// "main.quit".
+type namedBinding struct {
+	name    string
+	binding *KeyBinding
+}
+
+// keyScopes lists the bindings active together in each part of the
+// interface, in the order they are matched there. Sections overlap: main
+// bindings work on every tab, the unsectioned ones on tabs and in dialogs.
+func (k *KeyConfig) keyScopes() [][]namedBinding {
+	main := []namedBinding{
+		{"main.quit", k.Main.Quit},
+		{"main.help", k.Main.Help},
+		{"main.next_tab", k.Main.TabNext},
+		{"main.prev_tab", k.Main.TabPrev},
+		{"main.command_palette", k.Main.CommandPalette},
+	}
+	networks := append(slices.Clone(main),
+		namedBinding{"focus_next", k.FocusNext},
+		namedBinding{"focus_prev", k.FocusPrev},
+		namedBinding{"focus_1", k.Focus1},
+		namedBinding{"focus_2", k.Focus2},
+		namedBinding{"rescan", k.Rescan},
+		namedBinding{"networks.create_profile", k.Networks.CreateProfile},
+		namedBinding{"networks.create_hotspot", k.Networks.CreateHotspot},
+		namedBinding{"networks.open_network_login", k.Networks.OpenCaptivePortal},
+		namedBinding{"networks.quick_hotspot", k.Networks.QuickHotspot},
+		namedBinding{"networks.share_hotspot", k.Networks.ShareHotspot},
+		namedBinding{"networks.export_profiles", k.Networks.ExportProfiles},
+		namedBinding{"networks.import_profiles", k.Networks.ImportProfiles},
+		namedBinding{"sort", k.Sort},
+		namedBinding{"reverse_sort", k.ReverseSort},
+		namedBinding{"filter", k.Filter},
+	)
+	available := append(slices.Clone(networks),
+		namedBinding{"available_networks.connect", k.AvailableNetworks.Connect},
+		namedBinding{"available_networks.activate", k.AvailableNetworks.Activate},
+		namedBinding{"available_networks.deactivate", k.AvailableNetworks.Deactivate},
+		namedBinding{"available_networks.details", k.AvailableNetworks.Details},
+	)
+	profiles := append(slices.Clone(networks),
+		namedBinding{"network_profiles.edit", k.NetworkProfiles.Edit},
+		namedBinding{"network_profiles.activate", k.NetworkProfiles.Activate},
+		namedBinding{"network_profiles.deactivate", k.NetworkProfiles.Deactivate},
+		namedBinding{"network_profiles.delete", k.NetworkProfiles.Delete},
+		namedBinding{"network_profiles.share", k.NetworkProfiles.Share},
+		namedBinding{"network_profiles.mark", k.NetworkProfiles.Mark},
+		namedBinding{"network_profiles.mark_matching", k.NetworkProfiles.MarkMatching},
+		namedBinding{"network_profiles.invert_marks", k.NetworkProfiles.InvertMarks},
+		namedBinding{"network_profiles.clear_marks", k.NetworkProfiles.ClearMarks},
+		namedBinding{"network_profiles.bulk_actions", k.NetworkProfiles.BulkActions},
+	)
+	device := append(slices.Clone(main),
+		namedBinding{"focus_next", k.FocusNext},
+		namedBinding{"focus_prev", k.FocusPrev},
+		namedBinding{"rescan", k.Rescan},
+		namedBinding{"toggle", k.Toggle},
+	)
+	diagnostics := append(slices.Clone(main),
+		namedBinding{"rescan", k.Rescan},
+		namedBinding{"diagnostics.rerun_step", k.Diagnostics.RerunStep},
+		namedBinding{"diagnostics.copy_report", k.Diagnostics.CopyReport},
+	)
+	monitor := append(slices.Clone(main),
+		namedBinding{"focus_next", k.FocusNext},
+		namedBinding{"focus_prev", k.FocusPrev},
+		namedBinding{"rescan", k.Rescan},
+	)
+	dialog := []namedBinding{
+		{"dialog.close", k.Dialog.Close},
+		{"dialog.accept", k.Dialog.Accept},
+		{"dialog.toggle_pw_visibility", k.Dialog.TogglePWVisibility},
+		{"focus_next", k.FocusNext},
+		{"focus_prev", k.FocusPrev},
+		{"toggle", k.Toggle},
+	}
+	return [][]namedBinding{available, profiles, device, diagnostics, monitor, dialog}
+}
+
+// Conflicts finds keys bound to several actions active at the same time, in
+// the order they are matched. k must be fully merged.
+func (k *KeyConfig) Conflicts() []KeyConflict {
+	var conflicts []KeyConflict
+	for _, scope := range k.keyScopes() {
+		owners := make(map[string]string)
+		for _, b := range scope {
+			for _, key := range k.Expand(b.binding) {
+				owner, taken := owners[key]
+				if !taken {
+					owners[key] = b.name
+					continue
+				}
+				c := KeyConflict{Key: key, Action: owner, Shadowed: b.name}
+				if owner != b.name && !slices.Contains(conflicts, c) {
+					conflicts = append(conflicts, c)
+				}
+			}
+		}
+	}
+	return conflicts
+}
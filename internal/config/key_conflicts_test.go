@@ -0,0 +1,78 @@
+package config_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/config"
+)
+
+func TestKeyConflicts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		keys config.KeyConfig
+		want []config.KeyConflict
+	}{
+		{name: "defaults"},
+		{
+			name: "overlapping sections",
+			keys: config.KeyConfig{NetworkProfiles: &config.NetworkProfilesKeys{Delete: &config.KeyBinding{"q", "delete"}}},
+			want: []config.KeyConflict{{Key: "q", Action: "main.quit", Shadowed: "network_profiles.delete"}},
+		},
+		{
+			name: "same tab",
+			keys: config.KeyConfig{Rescan: &config.KeyBinding{"1"}},
+			want: []config.KeyConflict{{Key: "1", Action: "focus_1", Shadowed: "rescan"}},
+		},
+		{
+			name: "reported once for all scopes",
+			keys: config.KeyConfig{Toggle: &config.KeyBinding{"tab"}},
+			want: []config.KeyConflict{{Key: "tab", Action: "focus_next", Shadowed: "toggle"}},
+		},
+		{
+			name: "separate tables",
+			keys: config.KeyConfig{AvailableNetworks: &config.AvailableNetworksKeys{Connect: &config.KeyBinding{"d"}}},
+		},
+		{
+			name: "dialogs replace tabs",
+			keys: config.KeyConfig{Dialog: &config.DialogKeys{Accept: &config.KeyBinding{"r"}}},
+		},
+		{
+			name: "sequences after leader expansion",
+			keys: config.KeyConfig{
+				Rescan:          &config.KeyBinding{"<leader> r"},
+				NetworkProfiles: &config.NetworkProfilesKeys{Delete: &config.KeyBinding{"\\ r"}},
+			},
+			want: []config.KeyConflict{{Key: "\\ r", Action: "rescan", Shadowed: "network_profiles.delete"}},
+		},
+		{
+			name: "key repeated in one binding",
+			keys: config.KeyConfig{Rescan: &config.KeyBinding{"r", "r"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			keys := config.DefaultKeys()
+			if errs := keys.Merge(&tt.keys); len(errs) != 0 {
+				t.Fatalf("Merge() errors: %v", errs)
+			}
+			if got := keys.Conflicts(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Conflicts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyConflictError(t *testing.T) {
+	t.Parallel()
+
+	c := config.KeyConflict{Key: "q", Action: "main.quit", Shadowed: "network_profiles.delete"}
+	want := `key "q" of main.quit shadows network_profiles.delete`
+	if got := c.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
@@ -9,6 +9,12 @@ import (
 	"github.com/calico32/kdl-go"
 )
 
+// LeaderPlaceholder stands for the leader key in key sequences, like
+// "<leader> h".
+const LeaderPlaceholder = "<leader>"
+
+// KeyBinding lists the keys triggering an action. A key may be a sequence of
+// keys pressed one after another, separated by spaces, like "g g".
 type KeyBinding []string
 
 func (k *KeyBinding) UnmarshalKDL(node *kdl.Node) error {
@@ -21,20 +27,29 @@ func (k *KeyBinding) UnmarshalKDL(node *kdl.Node) error {
 }
 
 type KeyConfig struct {
-	Toggle    *KeyBinding `kdl:"toggle"`
-	Rescan    *KeyBinding `kdl:"rescan"`
-	FocusNext *KeyBinding `kdl:"focus_next"`
-	FocusPrev *KeyBinding `kdl:"focus_prev"`
-	Focus1    *KeyBinding `kdl:"focus_1"`
-	Focus2    *KeyBinding `kdl:"focus_2"`
-	Focus3    *KeyBinding `kdl:"focus_3"`
-	Focus4    *KeyBinding `kdl:"focus_4"`
-	Focus5    *KeyBinding `kdl:"focus_5"`
-	Focus6    *KeyBinding `kdl:"focus_6"`
-	Focus7    *KeyBinding `kdl:"focus_7"`
-	Focus8    *KeyBinding `kdl:"focus_8"`
-	Focus9    *KeyBinding `kdl:"focus_9"`
-	Focus10   *KeyBinding `kdl:"focus_10"`
+	Toggle      *KeyBinding `kdl:"toggle"`
+	Rescan      *KeyBinding `kdl:"rescan"`
+	Filter      *KeyBinding `kdl:"filter"`
+	Sort        *KeyBinding `kdl:"sort"`
+	ReverseSort *KeyBinding `kdl:"reverse_sort"`
+	FocusNext   *KeyBinding `kdl:"focus_next"`
+	FocusPrev   *KeyBinding `kdl:"focus_prev"`
+	Focus1      *KeyBinding `kdl:"focus_1"`
+	Focus2      *KeyBinding `kdl:"focus_2"`
+	Focus3      *KeyBinding `kdl:"focus_3"`
+	Focus4      *KeyBinding `kdl:"focus_4"`
+	Focus5      *KeyBinding `kdl:"focus_5"`
+	Focus6      *KeyBinding `kdl:"focus_6"`
+	Focus7      *KeyBinding `kdl:"focus_7"`
+	Focus8      *KeyBinding `kdl:"focus_8"`
+	Focus9      *KeyBinding `kdl:"focus_9"`
+	Focus10     *KeyBinding `kdl:"focus_10"`
+
+	// Leader replaces LeaderPlaceholder in key sequences.
+	Leader *string `kdl:"leader"`
+	// SequenceTimeout is how long a key sequence waits for its next key, in
+	// milliseconds.
+	SequenceTimeout *int `kdl:"sequence_timeout"`
 
 	Main   *MainKeys   `kdl:"main"`
 	Dialog *DialogKeys `kdl:"dialog"`
@@ -42,6 +57,7 @@ type KeyConfig struct {
 	Networks          *NetworksKeys          `kdl:"networks"`
 	AvailableNetworks *AvailableNetworksKeys `kdl:"available_networks"`
 	NetworkProfiles   *NetworkProfilesKeys   `kdl:"network_profiles"`
+	Diagnostics       *DiagnosticsKeys       `kdl:"diagnostics"`
 }
 
 type MainKeys struct {
@@ -49,6 +65,8 @@ type MainKeys struct {
 	TabNext *KeyBinding `kdl:"next_tab"`
 	TabPrev *KeyBinding `kdl:"prev_tab"`
 	Quit    *KeyBinding `kdl:"quit"`
+	// CommandPalette opens a searchable list of every action.
+	CommandPalette *KeyBinding `kdl:"command_palette"`
 }
 
 type DialogKeys struct {
@@ -62,42 +80,67 @@ type NetworksKeys struct {
 	OpenCaptivePortal *KeyBinding `kdl:"open_network_login"`
 	QuickHotspot      *KeyBinding `kdl:"quick_hotspot"`
 	CreateHotspot     *KeyBinding `kdl:"create_hotspot"`
+	ShareHotspot      *KeyBinding `kdl:"share_hotspot"`
+	ExportProfiles    *KeyBinding `kdl:"export_profiles"`
+	ImportProfiles    *KeyBinding `kdl:"import_profiles"`
 }
 
 type AvailableNetworksKeys struct {
 	Connect    *KeyBinding `kdl:"connect"`
 	Activate   *KeyBinding `kdl:"activate"`
 	Deactivate *KeyBinding `kdl:"deactivate"`
+	// Details opens the signal history of the selected access point.
+	Details *KeyBinding `kdl:"details"`
 }
 
 type NetworkProfilesKeys struct {
-	Edit       *KeyBinding `kdl:"edit"`
-	Activate   *KeyBinding `kdl:"activate"`
-	Deactivate *KeyBinding `kdl:"deactivate"`
-	Delete     *KeyBinding `kdl:"delete"`
+	Edit         *KeyBinding `kdl:"edit"`
+	Activate     *KeyBinding `kdl:"activate"`
+	Deactivate   *KeyBinding `kdl:"deactivate"`
+	Delete       *KeyBinding `kdl:"delete"`
+	Share        *KeyBinding `kdl:"share"`
+	Mark         *KeyBinding `kdl:"mark"`
+	MarkMatching *KeyBinding `kdl:"mark_matching"`
+	InvertMarks  *KeyBinding `kdl:"invert_marks"`
+	ClearMarks   *KeyBinding `kdl:"clear_marks"`
+	BulkActions  *KeyBinding `kdl:"bulk_actions"`
+}
+
+type DiagnosticsKeys struct {
+	RerunStep  *KeyBinding `kdl:"rerun_step"`
+	CopyReport *KeyBinding `kdl:"copy_report"`
 }
 
 func DefaultKeys() *KeyConfig {
 	return &KeyConfig{
-		Toggle:    &KeyBinding{"space"},
-		Rescan:    &KeyBinding{"r"},
-		FocusNext: &KeyBinding{"tab"},
-		FocusPrev: &KeyBinding{"shift+tab"},
-		Focus1:    &KeyBinding{"1"},
-		Focus2:    &KeyBinding{"2"},
-		Focus3:    &KeyBinding{"3"},
-		Focus4:    &KeyBinding{"4"},
-		Focus5:    &KeyBinding{"5"},
-		Focus6:    &KeyBinding{"6"},
-		Focus7:    &KeyBinding{"7"},
-		Focus8:    &KeyBinding{"8"},
-		Focus9:    &KeyBinding{"9"},
-		Focus10:   &KeyBinding{"0"},
+		Toggle:      &KeyBinding{"space"},
+		Rescan:      &KeyBinding{"r"},
+		Filter:      &KeyBinding{"/"},
+		Sort:        &KeyBinding{"o"},
+		ReverseSort: &KeyBinding{"O"},
+		FocusNext:   &KeyBinding{"tab"},
+		FocusPrev:   &KeyBinding{"shift+tab"},
+		Focus1:      &KeyBinding{"1"},
+		Focus2:      &KeyBinding{"2"},
+		Focus3:      &KeyBinding{"3"},
+		Focus4:      &KeyBinding{"4"},
+		Focus5:      &KeyBinding{"5"},
+		Focus6:      &KeyBinding{"6"},
+		Focus7:      &KeyBinding{"7"},
+		Focus8:      &KeyBinding{"8"},
+		Focus9:      &KeyBinding{"9"},
+		Focus10:     &KeyBinding{"0"},
+
+		Leader:          new("\\"),
+		SequenceTimeout: new(1000),
+
 		Main: &MainKeys{
 			Help:    &KeyBinding{"?"},
 			TabNext: &KeyBinding{"]"},
 			TabPrev: &KeyBinding{"["},
 			Quit:    &KeyBinding{"esc", "ctrl+c", "q", "ctrl+q"},
+
+			CommandPalette: &KeyBinding{":", "ctrl+p"},
 		},
 		Dialog: &DialogKeys{
 			TogglePWVisibility: &KeyBinding{"ctrl+p"},
@@ -109,17 +152,31 @@ func DefaultKeys() *KeyConfig {
 			OpenCaptivePortal: &KeyBinding{"l"},
 			QuickHotspot:      &KeyBinding{"ctrl+h"},
 			CreateHotspot:     &KeyBinding{"h"},
+			ShareHotspot:      &KeyBinding{"ctrl+s"},
+			ExportProfiles:    &KeyBinding{"ctrl+e"},
+			ImportProfiles:    &KeyBinding{"ctrl+o"},
 		},
 		AvailableNetworks: &AvailableNetworksKeys{
 			Connect:    &KeyBinding{"enter"},
 			Activate:   &KeyBinding{"space"},
 			Deactivate: &KeyBinding{"ctrl+space"},
+			Details:    &KeyBinding{"i"},
 		},
 		NetworkProfiles: &NetworkProfilesKeys{
-			Edit:       &KeyBinding{"enter"},
-			Activate:   &KeyBinding{"space"},
-			Deactivate: &KeyBinding{"ctrl+space"},
-			Delete:     &KeyBinding{"d", "delete"},
+			Edit:         &KeyBinding{"enter"},
+			Activate:     &KeyBinding{"space"},
+			Deactivate:   &KeyBinding{"ctrl+space"},
+			Delete:       &KeyBinding{"d", "delete"},
+			Share:        &KeyBinding{"s"},
+			Mark:         &KeyBinding{"m"},
+			MarkMatching: &KeyBinding{"*"},
+			InvertMarks:  &KeyBinding{"i"},
+			ClearMarks:   &KeyBinding{"u"},
+			BulkActions:  &KeyBinding{"b"},
+		},
+		Diagnostics: &DiagnosticsKeys{
+			RerunStep:  &KeyBinding{"enter"},
+			CopyReport: &KeyBinding{"y"},
 		},
 	}
 }
@@ -132,6 +189,24 @@ func (k *KeyConfig) Merge(src *KeyConfig) []error {
 	var errs []error
 	errs = append(errs, MergeKeyList(&k.Toggle, src.Toggle, "toggle")...)
 	errs = append(errs, MergeKeyList(&k.Rescan, src.Rescan, "rescan")...)
+	errs = append(errs, MergeKeyList(&k.Filter, src.Filter, "filter")...)
+	errs = append(errs, MergeKeyList(&k.Sort, src.Sort, "sort")...)
+
+	if src.Leader != nil {
+		if validKeyName(*src.Leader) {
+			k.Leader = src.Leader
+		} else {
+			errs = append(errs, fmt.Errorf("invalid key leader: %q", *src.Leader))
+		}
+	}
+	if src.SequenceTimeout != nil {
+		if err := validatePositiveTime(*src.SequenceTimeout); err != nil {
+			errs = append(errs, fmt.Errorf("sequence_timeout value: %w", err))
+		} else {
+			k.SequenceTimeout = src.SequenceTimeout
+		}
+	}
+	errs = append(errs, MergeKeyList(&k.ReverseSort, src.ReverseSort, "reverse_sort")...)
 	errs = append(errs, MergeKeyList(&k.FocusNext, src.FocusNext, "focus_next")...)
 	errs = append(errs, MergeKeyList(&k.FocusPrev, src.FocusPrev, "focus_prev")...)
 	errs = append(errs, MergeKeyList(&k.Focus1, src.Focus1, "focus_1")...)
@@ -150,6 +225,7 @@ func (k *KeyConfig) Merge(src *KeyConfig) []error {
 	errs = append(errs, k.Networks.Merge(src.Networks)...)
 	errs = append(errs, k.AvailableNetworks.Merge(src.AvailableNetworks)...)
 	errs = append(errs, k.NetworkProfiles.Merge(src.NetworkProfiles)...)
+	errs = append(errs, k.Diagnostics.Merge(src.Diagnostics)...)
 	return errs
 }
 
@@ -163,6 +239,7 @@ func (m *MainKeys) Merge(src *MainKeys) []error {
 	errs = append(errs, MergeKeyList(&m.TabNext, src.TabNext, "main.next_tab")...)
 	errs = append(errs, MergeKeyList(&m.TabPrev, src.TabPrev, "main.prev_tab")...)
 	errs = append(errs, MergeKeyList(&m.Quit, src.Quit, "main.quit")...)
+	errs = append(errs, MergeKeyList(&m.CommandPalette, src.CommandPalette, "main.command_palette")...)
 	return errs
 }
 
@@ -172,9 +249,9 @@ func (d *DialogKeys) Merge(src *DialogKeys) []error {
 	}
 
 	var errs []error
-	errs = append(errs, MergeKeyList(&d.TogglePWVisibility, src.TogglePWVisibility, "dialog.toggle_pw_visibility")...)
-	errs = append(errs, MergeKeyList(&d.Accept, src.Accept, "dialog.accept")...)
-	errs = append(errs, MergeKeyList(&d.Close, src.Close, "dialog.close")...)
+	errs = append(errs, mergeSingleKeyList(&d.TogglePWVisibility, src.TogglePWVisibility, "dialog.toggle_pw_visibility")...)
+	errs = append(errs, mergeSingleKeyList(&d.Accept, src.Accept, "dialog.accept")...)
+	errs = append(errs, mergeSingleKeyList(&d.Close, src.Close, "dialog.close")...)
 	return errs
 }
 
@@ -188,6 +265,9 @@ func (w *NetworksKeys) Merge(src *NetworksKeys) []error {
 	errs = append(errs, MergeKeyList(&w.OpenCaptivePortal, src.OpenCaptivePortal, "networks.open_network_login")...)
 	errs = append(errs, MergeKeyList(&w.QuickHotspot, src.QuickHotspot, "networks.quick_hotspot")...)
 	errs = append(errs, MergeKeyList(&w.CreateHotspot, src.CreateHotspot, "networks.create_hotspot")...)
+	errs = append(errs, MergeKeyList(&w.ShareHotspot, src.ShareHotspot, "networks.share_hotspot")...)
+	errs = append(errs, MergeKeyList(&w.ExportProfiles, src.ExportProfiles, "networks.export_profiles")...)
+	errs = append(errs, MergeKeyList(&w.ImportProfiles, src.ImportProfiles, "networks.import_profiles")...)
 	return errs
 }
 
@@ -200,6 +280,7 @@ func (a *AvailableNetworksKeys) Merge(src *AvailableNetworksKeys) []error {
 	errs = append(errs, MergeKeyList(&a.Connect, src.Connect, "available_networks.connect")...)
 	errs = append(errs, MergeKeyList(&a.Activate, src.Activate, "available_networks.connect")...)
 	errs = append(errs, MergeKeyList(&a.Deactivate, src.Deactivate, "available_networks.connect")...)
+	errs = append(errs, MergeKeyList(&a.Details, src.Details, "available_networks.details")...)
 	return errs
 }
 
@@ -213,6 +294,23 @@ func (s *NetworkProfilesKeys) Merge(src *NetworkProfilesKeys) []error {
 	errs = append(errs, MergeKeyList(&s.Activate, src.Activate, "network_profiles.activate")...)
 	errs = append(errs, MergeKeyList(&s.Deactivate, src.Deactivate, "network_profiles.deactivate")...)
 	errs = append(errs, MergeKeyList(&s.Delete, src.Delete, "network_profiles.delete")...)
+	errs = append(errs, MergeKeyList(&s.Share, src.Share, "network_profiles.share")...)
+	errs = append(errs, MergeKeyList(&s.Mark, src.Mark, "network_profiles.mark")...)
+	errs = append(errs, MergeKeyList(&s.MarkMatching, src.MarkMatching, "network_profiles.mark_matching")...)
+	errs = append(errs, MergeKeyList(&s.InvertMarks, src.InvertMarks, "network_profiles.invert_marks")...)
+	errs = append(errs, MergeKeyList(&s.ClearMarks, src.ClearMarks, "network_profiles.clear_marks")...)
+	errs = append(errs, MergeKeyList(&s.BulkActions, src.BulkActions, "network_profiles.bulk_actions")...)
+	return errs
+}
+
+func (d *DiagnosticsKeys) Merge(src *DiagnosticsKeys) []error {
+	if src == nil {
+		return nil
+	}
+
+	var errs []error
+	errs = append(errs, MergeKeyList(&d.RerunStep, src.RerunStep, "diagnostics.rerun_step")...)
+	errs = append(errs, MergeKeyList(&d.CopyReport, src.CopyReport, "diagnostics.copy_report")...)
 	return errs
 }
 
@@ -223,7 +321,7 @@ func MergeKeyList(dst **KeyBinding, src *KeyBinding, tag string) []error {
 
 	var errs []error
 	for _, v := range *src {
-		if !validKeyName(v) {
+		if !validKeySequence(v) {
 			errs = append(errs, fmt.Errorf("invalid key %s: %q", tag, v))
 		}
 	}
@@ -235,6 +333,45 @@ func MergeKeyList(dst **KeyBinding, src *KeyBinding, tag string) []error {
 	return nil
 }
 
+// mergeSingleKeyList is MergeKeyList for bindings of dialogs, where typing
+// can't be told from a key sequence.
+func mergeSingleKeyList(dst **KeyBinding, src *KeyBinding, tag string) []error {
+	if src == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, v := range *src {
+		if len(strings.Fields(v)) > 1 {
+			errs = append(errs, fmt.Errorf("key %s: sequence %q, only single keys work in dialogs", tag, v))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return MergeKeyList(dst, src, tag)
+}
+
+// Expand returns the keys of b as they are pressed: the steps of sequences
+// are separated by single spaces and the leader placeholder is replaced.
+func (k *KeyConfig) Expand(b *KeyBinding) []string {
+	keys := make([]string, len(*b))
+	for i, key := range *b {
+		steps := strings.Fields(key)
+		if len(steps) < 2 {
+			keys[i] = key
+			continue
+		}
+		for j, step := range steps {
+			if step == LeaderPlaceholder {
+				steps[j] = *k.Leader
+			}
+		}
+		keys[i] = strings.Join(steps, " ")
+	}
+	return keys
+}
+
 var validModifier = map[string]bool{
 	"ctrl": true, "alt": true, "shift": true,
 	"meta": true, "hyper": true, "super": true,
@@ -278,6 +415,21 @@ func buildValidKey() map[string]bool {
 
 var validKey = buildValidKey()
 
+// validKeySequence reports whether s is a key or a sequence of keys, which
+// may start with or contain the leader placeholder.
+func validKeySequence(s string) bool {
+	steps := strings.Fields(s)
+	if len(steps) < 2 {
+		return validKeyName(s)
+	}
+	for _, step := range steps {
+		if step != LeaderPlaceholder && !validKeyName(step) {
+			return false
+		}
+	}
+	return true
+}
+
 func validKeyName(s string) bool {
 	if s == "" {
 		return false
@@ -1,6 +1,7 @@
 package config_test
 
 import (
+	"slices"
 	"strings"
 	"testing"
 
@@ -68,6 +69,73 @@ func TestKeyConfigMerge(t *testing.T) {
 	})
 }
 
+func TestKeyConfigMergeSequences(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		src     config.KeyConfig
+		wantErr string
+	}{
+		{name: "sequence", src: config.KeyConfig{Rescan: &config.KeyBinding{"g r", "<leader> r"}}},
+		{name: "leader", src: config.KeyConfig{Leader: new("space")}},
+		{name: "timeout", src: config.KeyConfig{SequenceTimeout: new(500)}},
+		{
+			name:    "invalid step",
+			src:     config.KeyConfig{Rescan: &config.KeyBinding{"g notakey"}},
+			wantErr: `invalid key rescan: "g notakey"`,
+		},
+		{
+			name:    "leader alone",
+			src:     config.KeyConfig{Rescan: &config.KeyBinding{"<leader>"}},
+			wantErr: "invalid key rescan",
+		},
+		{
+			name:    "sequence as leader",
+			src:     config.KeyConfig{Leader: new("g g")},
+			wantErr: "invalid key leader",
+		},
+		{
+			name:    "sequence in dialog",
+			src:     config.KeyConfig{Dialog: &config.DialogKeys{Accept: &config.KeyBinding{"enter", "g g"}}},
+			wantErr: "only single keys work in dialogs",
+		},
+		{
+			name:    "zero timeout",
+			src:     config.KeyConfig{SequenceTimeout: new(0)},
+			wantErr: "sequence_timeout",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			errs := config.DefaultKeys().Merge(&tt.src)
+			if tt.wantErr == "" {
+				if len(errs) != 0 {
+					t.Errorf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.wantErr) {
+				t.Errorf("Merge() errors = %v, want one containing %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeyConfigExpand(t *testing.T) {
+	t.Parallel()
+
+	keys := config.DefaultKeys()
+	keys.Leader = new("space")
+	got := keys.Expand(&config.KeyBinding{"d", "g  g", "<leader> h", "g <leader>"})
+	want := []string{"d", "g g", "space h", "g space"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expand() = %q, want %q", got, want)
+	}
+}
+
 func TestMainKeysMerge(t *testing.T) {
 	t.Parallel()
 
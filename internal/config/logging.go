@@ -13,12 +13,7 @@ type LogConfig struct {
 }
 
 func DefaultLogConfig() *LogConfig {
-	stateDir := os.Getenv("XDG_STATE_HOME")
-	if stateDir == "" {
-		home, _ := os.UserHomeDir()
-		stateDir = filepath.Join(home, ".local", "state")
-	}
-	logPath := filepath.Join(stateDir, AppName, "log")
+	logPath := filepath.Join(StateDir(), "log")
 	level := LogError
 	return &LogConfig{
 		Level:    &level,
@@ -67,7 +62,30 @@ func validLogLevel(level string) bool {
 	return false
 }
 
+// StateDir returns the nm-tui directory under $XDG_STATE_HOME, which defaults
+// to ~/.local/state.
+func StateDir() string {
+	stateDir := os.Getenv("XDG_STATE_HOME")
+	if stateDir == "" {
+		home, _ := os.UserHomeDir()
+		stateDir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(stateDir, AppName)
+}
+
+// configPath replaces the default config location when set.
+var configPath string
+
+// SetConfigPath makes [ResolveConfigPath] return path instead of the file
+// under $XDG_CONFIG_HOME. Themes are then looked up next to it as well.
+func SetConfigPath(path string) {
+	configPath = ExpandPath(path)
+}
+
 func ResolveConfigPath() (string, error) {
+	if configPath != "" {
+		return configPath, nil
+	}
 	configDir, err := os.UserConfigDir()
 	if err != nil {
 		return "", err
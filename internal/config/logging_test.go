@@ -182,6 +182,21 @@ func TestResolveConfigPath(t *testing.T) {
 		}
 	})
 
+	t.Run("set path wins", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		t.Setenv("NM_TUI_TEST_DIR", "/tmp/nm-tui")
+		config.SetConfigPath("$NM_TUI_TEST_DIR/config.kdl")
+		t.Cleanup(func() { config.SetConfigPath("") })
+
+		got, err := config.ResolveConfigPath()
+		if err != nil {
+			t.Fatalf("resolveConfigPath() error: %v", err)
+		}
+		if want := "/tmp/nm-tui/config.kdl"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
 	t.Run("error when no config dir", func(t *testing.T) {
 		t.Setenv("XDG_CONFIG_HOME", "")
 		t.Setenv("HOME", "")
@@ -0,0 +1,42 @@
+package config
+
+import "fmt"
+
+// MonitorConfig sets up the sampling of the Monitor tab. Times are in
+// seconds.
+type MonitorConfig struct {
+	SampleInterval *int `kdl:"sample_interval"`
+	// History is how far back the graphs go.
+	History *int `kdl:"history"`
+}
+
+func DefaultMonitorConfig() *MonitorConfig {
+	return &MonitorConfig{
+		SampleInterval: new(1),
+		History:        new(300),
+	}
+}
+
+func (c *MonitorConfig) Merge(src *MonitorConfig) []error {
+	if src == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if src.SampleInterval != nil {
+		if err := validatePositiveTime(*src.SampleInterval); err != nil {
+			errs = append(errs, fmt.Errorf("monitor sample_interval value: %w", err))
+		} else {
+			c.SampleInterval = src.SampleInterval
+		}
+	}
+	if src.History != nil {
+		if err := validatePositiveTime(*src.History); err != nil {
+			errs = append(errs, fmt.Errorf("monitor history value: %w", err))
+		} else {
+			c.History = src.History
+		}
+	}
+	return errs
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// PortalConfig sets up how captive portals are found and opened.
+type PortalConfig struct {
+	CheckURL        *string `kdl:"check_url"`
+	CheckResponse   *string `kdl:"check_response"`
+	Browser         *string `kdl:"browser"`
+	TerminalBrowser *string `kdl:"terminal_browser"`
+	AutoOpen        *bool   `kdl:"auto_open"`
+}
+
+func DefaultPortalConfig() *PortalConfig {
+	return &PortalConfig{
+		CheckURL:        new("http://nmcheck.gnome.org/check_network_status.txt"),
+		CheckResponse:   new("NetworkManager is online"),
+		Browser:         new(DefaultKeyword),
+		TerminalBrowser: new("w3m"),
+		AutoOpen:        new(false),
+	}
+}
+
+func (c *PortalConfig) Merge(src *PortalConfig) []error {
+	if src == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if src.CheckURL != nil && *src.CheckURL != DefaultKeyword {
+		if err := validateCheckURL(*src.CheckURL); err != nil {
+			errs = append(errs, fmt.Errorf("portal check_url %q: %w", *src.CheckURL, err))
+		} else {
+			c.CheckURL = src.CheckURL
+		}
+	}
+
+	if src.CheckResponse != nil && *src.CheckResponse != DefaultKeyword {
+		c.CheckResponse = src.CheckResponse
+	}
+
+	if src.Browser != nil {
+		if *src.Browser == "" {
+			errs = append(errs, fmt.Errorf("empty portal browser"))
+		} else {
+			c.Browser = src.Browser
+		}
+	}
+
+	if src.TerminalBrowser != nil && *src.TerminalBrowser != DefaultKeyword {
+		c.TerminalBrowser = src.TerminalBrowser
+	}
+
+	if src.AutoOpen != nil {
+		c.AutoOpen = src.AutoOpen
+	}
+	return errs
+}
+
+// validateCheckURL accepts absolute http and https URLs.
+func validateCheckURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("scheme %q is not http or https", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("no host")
+	}
+	return nil
+}
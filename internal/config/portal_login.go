@@ -0,0 +1,146 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/calico32/kdl-go"
+)
+
+// PortalLoginsDirName is the directory next to the config holding the login
+// recipes of captive portals, one <profile>.kdl file per profile.
+const PortalLoginsDirName = "portals"
+
+// PortalLogin is a recipe logging in to the captive portal of a profile.
+type PortalLogin struct {
+	// URLMatch is a regular expression the URL of the portal must match. It
+	// is required, so that an access point spoofing the SSID doesn't receive
+	// the fields.
+	URLMatch *string `kdl:"url_match"`
+	// Action is the URL the form is sent to, relative to the portal.
+	Action *string      `kdl:"action"`
+	Method *string      `kdl:"method"`
+	Fields []LoginField `kdl:"field,multiple"`
+}
+
+// LoginField is a form field, with its value given in place, or taken from
+// an environment variable or a file.
+type LoginField struct {
+	Name  string  `kdl:",arg"`
+	Value *string `kdl:"value,prop"`
+	Env   *string `kdl:"env,prop"`
+	File  *string `kdl:"file,prop"`
+}
+
+func PortalLoginsDir() (string, error) {
+	path, err := ResolveConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), PortalLoginsDirName), nil
+}
+
+// LoadPortalLogin reads and validates the login recipe of profile. A missing
+// recipe is reported as [fs.ErrNotExist].
+func LoadPortalLogin(profile string) (*PortalLogin, error) {
+	if profile == "" || strings.ContainsRune(profile, filepath.Separator) {
+		return nil, fmt.Errorf("portal login %q: %w", profile, os.ErrNotExist)
+	}
+	dir, err := PortalLoginsDir()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filepath.Join(dir, profile+".kdl"))
+	if err != nil {
+		return nil, fmt.Errorf("portal login %q: %w", profile, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var login PortalLogin
+	if err := kdl.Decode(f, &login); err != nil {
+		return nil, fmt.Errorf("portal login %q: %w", profile, err)
+	}
+	if err := login.validate(); err != nil {
+		return nil, fmt.Errorf("portal login %q: %w", profile, err)
+	}
+	return &login, nil
+}
+
+func (l *PortalLogin) validate() error {
+	if l.URLMatch == nil || *l.URLMatch == "" {
+		return errors.New("url_match is required")
+	}
+	if _, err := regexp.Compile(*l.URLMatch); err != nil {
+		return fmt.Errorf("url_match: %w", err)
+	}
+	if l.Action != nil {
+		if _, err := url.Parse(*l.Action); err != nil {
+			return fmt.Errorf("action: %w", err)
+		}
+	}
+	if l.Method != nil {
+		switch strings.ToUpper(*l.Method) {
+		case http.MethodGet, http.MethodPost:
+		default:
+			return fmt.Errorf("method %q is not GET or POST", *l.Method)
+		}
+	}
+	for _, f := range l.Fields {
+		if f.Name == "" {
+			return errors.New("field with an empty name")
+		}
+		sources := 0
+		for _, s := range []*string{f.Value, f.Env, f.File} {
+			if s != nil {
+				sources++
+			}
+		}
+		if sources != 1 {
+			return fmt.Errorf("field %q: set exactly one of value, env and file", f.Name)
+		}
+	}
+	return nil
+}
+
+// Matches tells whether the recipe is meant for the portal at portalURL. A
+// recipe without url_match matches nothing.
+func (l *PortalLogin) Matches(portalURL string) bool {
+	if l == nil || l.URLMatch == nil || *l.URLMatch == "" {
+		return false
+	}
+	// Checked on load.
+	re := regexp.MustCompile(*l.URLMatch)
+	return re.MatchString(portalURL)
+}
+
+// Form returns the fields filled in, reading the environment and secret
+// files.
+func (l *PortalLogin) Form() (url.Values, error) {
+	form := url.Values{}
+	for _, f := range l.Fields {
+		switch {
+		case f.Value != nil:
+			form.Add(f.Name, *f.Value)
+		case f.Env != nil:
+			value, ok := os.LookupEnv(*f.Env)
+			if !ok {
+				return nil, fmt.Errorf("field %q: $%s is not set", f.Name, *f.Env)
+			}
+			form.Add(f.Name, value)
+		case f.File != nil:
+			value, err := os.ReadFile(ExpandPath(*f.File))
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", f.Name, err)
+			}
+			// Editors end files with a newline, which is no part of a secret.
+			form.Add(f.Name, strings.TrimRight(string(value), "\r\n"))
+		}
+	}
+	return form, nil
+}
@@ -0,0 +1,105 @@
+package config_test
+
+import (
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/config"
+)
+
+func TestLoadPortalLogin(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("GYM_EMAIL", "me@example.com")
+	loginsDir := filepath.Join(dir, config.AppName, config.PortalLoginsDirName)
+	if err := os.MkdirAll(loginsDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	secret := filepath.Join(dir, "gym-code")
+	if err := os.WriteFile(secret, []byte("1234\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"Gym.kdl": `url_match #"^https://wifi\.gym\.example/"#
+action "/login"
+method "post"
+field "accept" value="on"
+field "email" env="GYM_EMAIL"
+field "code" file="` + secret + `"
+`,
+		"Train.kdl":    "url_match \"^http://train/\"\nfield \"token\" env=\"TRAIN_TOKEN_UNSET\"\n",
+		"Anywhere.kdl": "field \"token\" file=\"~/token\"\n",
+		"Method.kdl":   "url_match \"^https://\"\nmethod \"PUT\"\n",
+		"Twice.kdl":    "url_match \"^https://\"\nfield \"a\" value=\"1\" env=\"A\"\n",
+		"Regexp.kdl":   "url_match \"(\"\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(loginsDir, name), []byte(src), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	login, err := config.LoadPortalLogin("Gym")
+	if err != nil {
+		t.Fatalf("LoadPortalLogin(Gym) error: %v", err)
+	}
+	form, err := login.Form()
+	if err != nil {
+		t.Fatalf("Form() error: %v", err)
+	}
+	want := url.Values{"accept": {"on"}, "email": {"me@example.com"}, "code": {"1234"}}
+	if !reflect.DeepEqual(form, want) {
+		t.Errorf("Form() = %v, want %v", form, want)
+	}
+	if !login.Matches("https://wifi.gym.example/portal?mac=1") {
+		t.Errorf("Matches() rejects the portal of url_match %q", *login.URLMatch)
+	}
+	for _, spoofed := range []string{
+		"https://other.example/",
+		"https://wifi.gym.example.attacker.example/",
+		"http://attacker.example/?next=https://wifi.gym.example/",
+	} {
+		if login.Matches(spoofed) {
+			t.Errorf("Matches(%q) = true for url_match %q", spoofed, *login.URLMatch)
+		}
+	}
+	var none *config.PortalLogin
+	if none.Matches("https://wifi.gym.example/") || (&config.PortalLogin{}).Matches("https://wifi.gym.example/") {
+		t.Error("Matches() = true without url_match")
+	}
+
+	train, err := config.LoadPortalLogin("Train")
+	if err != nil {
+		t.Fatalf("LoadPortalLogin(Train) error: %v", err)
+	}
+	if _, err := train.Form(); err == nil || !strings.Contains(err.Error(), "TRAIN_TOKEN_UNSET") {
+		t.Errorf("Form() error = %v, want the unset variable", err)
+	}
+
+	tests := []struct {
+		profile string
+		wantErr string
+	}{
+		{profile: "Method", wantErr: "not GET or POST"},
+		{profile: "Twice", wantErr: "exactly one of"},
+		{profile: "Regexp", wantErr: "url_match"},
+		{profile: "Anywhere", wantErr: "url_match is required"},
+	}
+	for _, tt := range tests {
+		if _, err := config.LoadPortalLogin(tt.profile); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("LoadPortalLogin(%q) error = %v, want %q", tt.profile, err, tt.wantErr)
+		}
+	}
+	for _, profile := range []string{"Missing", filepath.Join("..", "Gym"), ""} {
+		if _, err := config.LoadPortalLogin(profile); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("LoadPortalLogin(%q) error = %v, want fs.ErrNotExist", profile, err)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package config
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/calico32/kdl-go"
+)
+
+const (
+	ThemeVariantAuto  = "auto"
+	ThemeVariantLight = "light"
+	ThemeVariantDark  = "dark"
+
+	DefaultThemeName = "default"
+	ThemesDirName    = "themes"
+	themeFileExt     = ".kdl"
+)
+
+//go:embed themes/*.kdl
+var builtinThemes embed.FS
+
+type ThemeConfig struct {
+	Name    *string `kdl:"name"`
+	Variant *string `kdl:"variant"`
+}
+
+func DefaultThemeConfig() *ThemeConfig {
+	return &ThemeConfig{
+		Name:    new(DefaultThemeName),
+		Variant: new(ThemeVariantAuto),
+	}
+}
+
+func (c *ThemeConfig) Merge(src *ThemeConfig) []error {
+	var errs []error
+
+	if src.Name != nil {
+		if *src.Name == "" {
+			errs = append(errs, errors.New("theme name: empty"))
+		} else {
+			c.Name = src.Name
+		}
+	}
+
+	if src.Variant != nil {
+		switch *src.Variant {
+		case ThemeVariantAuto, ThemeVariantLight, ThemeVariantDark:
+			c.Variant = src.Variant
+		default:
+			errs = append(errs, fmt.Errorf("theme variant: unknown %q", *src.Variant))
+		}
+	}
+
+	return errs
+}
+
+// Theme is a color theme with a variant for light and for dark terminal
+// backgrounds. A theme file may define only one of them, which is then used
+// for both.
+type Theme struct {
+	Light *ThemeVariant `kdl:"light"`
+	Dark  *ThemeVariant `kdl:"dark"`
+}
+
+// ThemeVariant holds the palette and the styles of single elements. Anything
+// left out falls back to the look built from the palette.
+type ThemeVariant struct {
+	Colors *ColorConfig `kdl:"colors"`
+
+	TableHeader  *ElementStyle `kdl:"table_header"`
+	SelectedRow  *ElementStyle `kdl:"selected_row"`
+	TabActive    *ElementStyle `kdl:"tab_active"`
+	TabInactive  *ElementStyle `kdl:"tab_inactive"`
+	PopupBorder  *ElementStyle `kdl:"popup_border"`
+	NotifInfo    *ElementStyle `kdl:"notification_info"`
+	NotifWarning *ElementStyle `kdl:"notification_warning"`
+	NotifError   *ElementStyle `kdl:"notification_error"`
+
+	// SignalGradient colors signal strength from the weakest to the strongest.
+	SignalGradient []string `kdl:"signal_gradient"`
+}
+
+type ElementStyle struct {
+	Fg   *string `kdl:"fg,prop"`
+	Bg   *string `kdl:"bg,prop"`
+	Bold *bool   `kdl:"bold,prop"`
+}
+
+// Variant returns the light or dark variant of the theme.
+func (t *Theme) Variant(dark bool) *ThemeVariant {
+	if dark && t.Dark != nil || t.Light == nil {
+		return t.Dark
+	}
+	return t.Light
+}
+
+func (t *Theme) validate() error {
+	if t.Light == nil && t.Dark == nil {
+		return errors.New("neither light nor dark variant defined")
+	}
+
+	var errs []error
+	if t.Light != nil {
+		if err := t.Light.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", ThemeVariantLight, err))
+		}
+	}
+	if t.Dark != nil {
+		if err := t.Dark.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", ThemeVariantDark, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (v *ThemeVariant) validate() error {
+	var errs []error
+	collect := func(tag string, color *string) {
+		if color == nil {
+			return
+		}
+		if err := ValidateColor(*color); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", tag, err))
+		}
+	}
+
+	if c := v.Colors; c != nil {
+		collect("colors text", c.Text)
+		collect("colors accent", c.Accent)
+		collect("colors muted", c.Muted)
+		collect("colors error", c.Error)
+		collect("colors notification", c.Notif)
+	}
+
+	elements := []struct {
+		tag   string
+		style *ElementStyle
+	}{
+		{"table_header", v.TableHeader},
+		{"selected_row", v.SelectedRow},
+		{"tab_active", v.TabActive},
+		{"tab_inactive", v.TabInactive},
+		{"popup_border", v.PopupBorder},
+		{"notification_info", v.NotifInfo},
+		{"notification_warning", v.NotifWarning},
+		{"notification_error", v.NotifError},
+	}
+	for _, e := range elements {
+		if e.style != nil {
+			collect(e.tag+" fg", e.style.Fg)
+			collect(e.tag+" bg", e.style.Bg)
+		}
+	}
+
+	for i := range v.SignalGradient {
+		collect(fmt.Sprintf("signal_gradient stop %d", i), &v.SignalGradient[i])
+	}
+
+	return errors.Join(errs...)
+}
+
+// ThemesDir returns the directory of user theme files.
+func ThemesDir() (string, error) {
+	path, err := ResolveConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), ThemesDirName), nil
+}
+
+// LoadTheme reads the theme called name from the user themes directory,
+// falling back to the built-in theme of the same name.
+func LoadTheme(name string) (*Theme, error) {
+	if strings.ContainsRune(name, filepath.Separator) {
+		return nil, fmt.Errorf("theme name %q: contains a path separator", name)
+	}
+	fileName := name + themeFileExt
+
+	if dir, err := ThemesDir(); err == nil {
+		f, err := os.Open(filepath.Join(dir, fileName))
+		if err == nil {
+			defer func() { _ = f.Close() }()
+			return decodeTheme(f, name)
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("open theme %q: %w", name, err)
+		}
+	}
+
+	f, err := builtinThemes.Open(ThemesDirName + "/" + fileName)
+	if err != nil {
+		return nil, fmt.Errorf("theme %q: not found among user or built-in themes", name)
+	}
+	defer func() { _ = f.Close() }()
+	return decodeTheme(f, name)
+}
+
+// BuiltinThemes returns the names of the themes shipped with nm-tui.
+func BuiltinThemes() []string {
+	entries, _ := builtinThemes.ReadDir(ThemesDirName)
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), themeFileExt))
+	}
+	slices.Sort(names)
+	return names
+}
+
+func decodeTheme(r io.Reader, name string) (*Theme, error) {
+	var theme Theme
+	if err := kdl.Decode(r, &theme); err != nil {
+		return nil, fmt.Errorf("decode theme %q: %w", name, err)
+	}
+	if err := theme.validate(); err != nil {
+		return nil, fmt.Errorf("theme %q: %w", name, err)
+	}
+	return &theme, nil
+}
@@ -0,0 +1,135 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/config"
+)
+
+func TestBuiltinThemes(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	names := config.BuiltinThemes()
+	for _, want := range []string{config.DefaultThemeName, "catppuccin", "gruvbox", "nord"} {
+		if !slices.Contains(names, want) {
+			t.Errorf("BuiltinThemes() = %v, missing %q", names, want)
+		}
+	}
+
+	for _, name := range names {
+		theme, err := config.LoadTheme(name)
+		if err != nil {
+			t.Errorf("LoadTheme(%q) error: %v", name, err)
+			continue
+		}
+		for _, dark := range []bool{false, true} {
+			if theme.Variant(dark) == nil {
+				t.Errorf("LoadTheme(%q).Variant(%v) = nil", name, dark)
+			}
+		}
+	}
+}
+
+func TestLoadTheme(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	themesDir := filepath.Join(dir, config.AppName, config.ThemesDirName)
+	if err := os.MkdirAll(themesDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"nord.kdl":   "light {\n    colors {\n        accent \"#123456\"\n    }\n}\n",
+		"broken.kdl": "dark {\n    selected_row fg=\"not-a-color\"\n}\n",
+		"empty.kdl":  "",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(themesDir, name), []byte(src), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		theme      string
+		wantErr    string
+		wantAccent string
+	}{
+		{name: "user theme overrides built-in", theme: "nord", wantAccent: "#123456"},
+		{name: "built-in theme", theme: "gruvbox"},
+		{name: "invalid color", theme: "broken", wantErr: "selected_row fg"},
+		{name: "no variants", theme: "empty", wantErr: "neither light nor dark"},
+		{name: "unknown theme", theme: "missing", wantErr: "not found"},
+		{name: "path separator", theme: filepath.Join("..", "nord"), wantErr: "path separator"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme, err := config.LoadTheme(tt.theme)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("LoadTheme(%q) succeeded, want error", tt.theme)
+				}
+				assertErrsContain(t, []error{err}, tt.wantErr)
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadTheme(%q) error: %v", tt.theme, err)
+			}
+			if tt.wantAccent == "" {
+				return
+			}
+			// A single variant serves both backgrounds.
+			if got := *theme.Variant(true).Colors.Accent; got != tt.wantAccent {
+				t.Errorf("accent = %q, want %q", got, tt.wantAccent)
+			}
+		})
+	}
+}
+
+func TestThemeConfigMerge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		src         *config.ThemeConfig
+		wantErr     int
+		wantName    string
+		wantVariant string
+	}{
+		{
+			name:        "empty source",
+			src:         &config.ThemeConfig{},
+			wantName:    config.DefaultThemeName,
+			wantVariant: config.ThemeVariantAuto,
+		},
+		{
+			name:        "valid",
+			src:         &config.ThemeConfig{Name: new("nord"), Variant: new(config.ThemeVariantLight)},
+			wantName:    "nord",
+			wantVariant: config.ThemeVariantLight,
+		},
+		{
+			name:        "invalid values keep defaults",
+			src:         &config.ThemeConfig{Name: new(""), Variant: new("dim")},
+			wantErr:     2,
+			wantName:    config.DefaultThemeName,
+			wantVariant: config.ThemeVariantAuto,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := config.DefaultThemeConfig()
+			if errs := cfg.Merge(tt.src); len(errs) != tt.wantErr {
+				t.Errorf("Merge() errors = %v, want %d", errs, tt.wantErr)
+			}
+			if *cfg.Name != tt.wantName || *cfg.Variant != tt.wantVariant {
+				t.Errorf("Merge() = %q/%q, want %q/%q", *cfg.Name, *cfg.Variant, tt.wantName, tt.wantVariant)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// watchDebounce gathers the bursts of events editors produce on save into a
+// single change.
+const watchDebounce = 100 * time.Millisecond
+
+// Watcher reports changes of a file. It watches the parent directory rather
+// than the file itself, so files replaced by a rename, as many editors save
+// them, keep being watched.
+type Watcher struct {
+	file    *os.File
+	changes chan struct{}
+}
+
+// NewWatcher watches the config file at [ResolveConfigPath].
+func NewWatcher() (*Watcher, error) {
+	path, err := ResolveConfigPath()
+	if err != nil {
+		return nil, fmt.Errorf("resolve config path: %w", err)
+	}
+	return WatchFile(path)
+}
+
+// Changes receives a value after the file is written, replaced or removed.
+// Changes made while the previous one is not received yet are merged into it.
+// The channel is closed when the watcher is closed.
+func (w *Watcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+func (w *Watcher) Close() error {
+	return w.file.Close()
+}
+
+// run turns the raw events into debounced changes until events is closed.
+func (w *Watcher) run(events <-chan struct{}) {
+	defer close(w.changes)
+
+	var pending <-chan time.Time
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+			pending = time.After(watchDebounce)
+		case <-pending:
+			pending = nil
+			select {
+			case w.changes <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
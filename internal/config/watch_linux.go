@@ -0,0 +1,74 @@
+package config
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+const watchMask = syscall.IN_CLOSE_WRITE | syscall.IN_MOVED_TO | syscall.IN_DELETE
+
+// WatchFile watches the file at path with inotify. The directory of the file
+// must exist, the file itself may be created later.
+func WatchFile(path string) (*Watcher, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("inotify init: %w", err)
+	}
+	// A non-blocking descriptor is served by the runtime poller, so closing
+	// the file interrupts a pending read.
+	file := os.NewFile(uintptr(fd), "inotify")
+
+	dir, name := filepath.Split(filepath.Clean(path))
+	if _, err = syscall.InotifyAddWatch(fd, dir, watchMask); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("watch %s: %w", dir, err)
+	}
+
+	w := &Watcher{
+		file:    file,
+		changes: make(chan struct{}, 1),
+	}
+	events := make(chan struct{})
+	go w.read(name, events)
+	go w.run(events)
+	return w, nil
+}
+
+// read sends to events whenever inotify reports an event on the file name,
+// until the watcher is closed.
+func (w *Watcher) read(name string, events chan<- struct{}) {
+	defer close(events)
+
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		n, err := w.file.Read(buf)
+		if err != nil {
+			return
+		}
+		if namesFile(buf[:n], name) {
+			events <- struct{}{}
+		}
+	}
+}
+
+// namesFile reports whether any of the inotify events in buf is about the
+// file name.
+func namesFile(buf []byte, name string) bool {
+	for len(buf) >= syscall.SizeofInotifyEvent {
+		// Fields preceding the name: wd, mask, cookie, len.
+		nameLen := int(binary.NativeEndian.Uint32(buf[12:16]))
+		end := syscall.SizeofInotifyEvent + nameLen
+		if end > len(buf) {
+			return false
+		}
+		if string(bytes.TrimRight(buf[syscall.SizeofInotifyEvent:end], "\x00")) == name {
+			return true
+		}
+		buf = buf[end:]
+	}
+	return false
+}
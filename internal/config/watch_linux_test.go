@@ -0,0 +1,81 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alphameo/nm-tui/internal/config"
+)
+
+func TestWatchFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, config.ConfigFileName)
+	w, err := config.WatchFile(path)
+	if err != nil {
+		t.Fatalf("WatchFile() error: %v", err)
+	}
+
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expect := func(what string, want bool) {
+		t.Helper()
+		select {
+		case <-w.Changes():
+			if !want {
+				t.Errorf("%s: unexpected change", what)
+			}
+		case <-time.After(500 * time.Millisecond):
+			if want {
+				t.Errorf("%s: no change reported", what)
+			}
+		}
+	}
+
+	write("other.kdl", "mouse false")
+	expect("other file written", false)
+
+	write(config.ConfigFileName, "mouse false")
+	write(config.ConfigFileName, "mouse true")
+	expect("config created and rewritten", true)
+	expect("burst of writes", false)
+
+	write("config.kdl.tmp", "mouse false")
+	if err := os.Rename(filepath.Join(dir, "config.kdl.tmp"), path); err != nil {
+		t.Fatal(err)
+	}
+	expect("config replaced", true)
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	expect("config removed", true)
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+	select {
+	case _, ok := <-w.Changes():
+		if ok {
+			t.Error("change reported after Close()")
+		}
+	case <-time.After(time.Second):
+		t.Error("Changes() not closed after Close()")
+	}
+}
+
+func TestWatchFileMissingDir(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "missing", config.ConfigFileName)
+	if _, err := config.WatchFile(path); err == nil {
+		t.Error("WatchFile() in a missing directory succeeded")
+	}
+}
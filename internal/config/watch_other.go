@@ -0,0 +1,10 @@
+//go:build !linux
+
+package config
+
+import "errors"
+
+// WatchFile is only implemented with inotify.
+func WatchFile(string) (*Watcher, error) {
+	return nil, errors.ErrUnsupported
+}
@@ -0,0 +1,57 @@
+package config
+
+import "fmt"
+
+// WatchdogConfig sets up the auto-reconnect watchdog. All times are in
+// seconds. A max_backoff shorter than backoff keeps the backoff constant.
+type WatchdogConfig struct {
+	Enabled       *bool `kdl:"enabled"`
+	GracePeriod   *int  `kdl:"grace_period"`
+	CheckInterval *int  `kdl:"check_interval"`
+	Backoff       *int  `kdl:"backoff"`
+	MaxBackoff    *int  `kdl:"max_backoff"`
+}
+
+func DefaultWatchdogConfig() *WatchdogConfig {
+	return &WatchdogConfig{
+		Enabled:       new(false),
+		GracePeriod:   new(30),
+		CheckInterval: new(5),
+		Backoff:       new(60),
+		MaxBackoff:    new(600),
+	}
+}
+
+func (c *WatchdogConfig) Merge(src *WatchdogConfig) []error {
+	if src == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if src.Enabled != nil {
+		c.Enabled = src.Enabled
+	}
+
+	times := []struct {
+		name string
+		dst  **int
+		src  *int
+	}{
+		{"grace_period", &c.GracePeriod, src.GracePeriod},
+		{"check_interval", &c.CheckInterval, src.CheckInterval},
+		{"backoff", &c.Backoff, src.Backoff},
+		{"max_backoff", &c.MaxBackoff, src.MaxBackoff},
+	}
+	for _, t := range times {
+		if t.src == nil {
+			continue
+		}
+		if err := validatePositiveTime(*t.src); err != nil {
+			errs = append(errs, fmt.Errorf("watchdog %s value: %w", t.name, err))
+			continue
+		}
+		*t.dst = t.src
+	}
+	return errs
+}
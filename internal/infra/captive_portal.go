@@ -3,15 +3,56 @@ package infra
 import (
 	"context"
 	"errors"
+	"net/url"
+	"os/exec"
 )
 
 var (
 	ErrOpenCaptivePortal   = errors.New("failed to open captive portal")
-	ErrGetGatewayIP        = errors.New("failed to get gateway ip")
+	ErrNoCaptivePortal     = errors.New("no captive portal found")
+	ErrNoBrowser           = errors.New("no browser to open the captive portal")
+	ErrPortalLogin         = errors.New("failed to log in to captive portal")
 	ErrUnsupportedPlarform = errors.New("unsupported platform")
 )
 
-// CaptivePortalOpener opens the captive portal login page in the default browser.
+// PortalSettings tell how captive portals are found and which browser opens
+// them.
+type PortalSettings struct {
+	// CheckURL is fetched without following redirects. A portal answers it
+	// with a redirect or a page of its own.
+	CheckURL string
+	// CheckResponse is the start of the body CheckURL serves when there is
+	// no portal. When empty, "204 No Content" is expected instead.
+	CheckResponse string
+	// Browser is the command opening the portal, "default" for the default
+	// browser of the desktop. "{url}" in it is replaced by the URL of the
+	// portal, which is appended otherwise.
+	Browser string
+	// TerminalBrowser replaces the default browser outside of a graphical
+	// session. It runs in the terminal. Empty disables the fallback.
+	TerminalBrowser string
+}
+
+// PortalForm is the login form of a captive portal, filled in.
+type PortalForm struct {
+	// Action is the URL the form is sent to, relative to the portal. Empty
+	// sends it to the portal itself.
+	Action string
+	// Method is GET or POST.
+	Method string
+	Fields url.Values
+}
+
+// CaptivePortalOpener finds captive portal login pages, the browser to open
+// them in, and logs in to them.
 type CaptivePortalOpener interface {
-	OpenCaptivePortal(ctx context.Context) error
+	// FindCaptivePortal returns the URL of the login page, or
+	// [ErrNoCaptivePortal] when the check URL is reached.
+	FindCaptivePortal(ctx context.Context, settings PortalSettings) (string, error)
+	// BrowserCommand returns the command opening url. A terminal browser
+	// needs the terminal, so it must be run in the foreground.
+	BrowserCommand(url string, settings PortalSettings) (cmd *exec.Cmd, terminal bool, err error)
+	// LoginCaptivePortal loads the portal at portalURL, for the cookies of
+	// the session, and sends form.
+	LoginCaptivePortal(ctx context.Context, portalURL string, form PortalForm) error
 }
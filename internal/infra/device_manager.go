@@ -40,9 +40,21 @@ type NetworkDevice struct {
 	Connection string
 }
 
+// IPConfig is the IP configuration NetworkManager applied to a device.
+type IPConfig struct {
+	// Addresses are in CIDR notation, IPv4 first.
+	Addresses []string
+	Gateways  []string
+	DNS       []string
+}
+
 var (
 	ErrListNetworkDevices = errors.New("failed to list network devices")
 
+	ErrListActiveVPNs = errors.New("failed to list active vpn connections")
+
+	ErrGetIPConfig = errors.New("failed to get ip configuration")
+
 	ErrGetConnectivityStatus = errors.New("failed to get connectivity status")
 	ErrParseConnectivity     = errors.New("failed to parse connectivity status")
 
@@ -65,6 +77,12 @@ type DeviceManager interface {
 	// ListNetworkDevices returns info about network devices
 	ListNetworkDevices(ctx context.Context) ([]NetworkDevice, error)
 
+	// ListActiveVPNs returns names of active VPN and WireGuard connections
+	ListActiveVPNs(ctx context.Context) ([]string, error)
+
+	// GetIPConfig returns addresses, gateways and DNS servers of device
+	GetIPConfig(ctx context.Context, device string) (IPConfig, error)
+
 	// GetConnectivityStatus returns connectivity status of device
 	GetConnectivityStatus(ctx context.Context) (ConnectivityStatus, error)
 
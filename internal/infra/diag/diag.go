@@ -0,0 +1,177 @@
+// Package diag probes the link, gateway, DNS servers and web access for the
+// diagnostics.
+package diag
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alphameo/nm-tui/internal/infra"
+)
+
+// arpTablePath is where Linux lists its IPv4 neighbours.
+const arpTablePath = "/proc/net/arp"
+
+// sysNetPath is where Linux lists its network interfaces.
+const sysNetPath = "/sys/class/net"
+
+// arpComplete is the flag of ARP entries with a known hardware address.
+const arpComplete = 0x2
+
+// Prober runs the checks with ping, the ARP table of the kernel and plain
+// DNS and HTTP requests, and reads the counters of interfaces from sysfs.
+type Prober struct {
+	client  *http.Client
+	timeout time.Duration
+	arpPath string
+	sysPath string
+}
+
+func New() *Prober {
+	return &Prober{
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+			// Any answer tells the web is reachable, a redirect included.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		timeout: 3 * time.Second,
+		arpPath: arpTablePath,
+		sysPath: sysNetPath,
+	}
+}
+
+func (p *Prober) Ping(ctx context.Context, host string) (time.Duration, error) {
+	wait := strconv.Itoa(int(p.timeout.Seconds()))
+	out, err := exec.CommandContext(ctx, "ping", "-n", "-c", "1", "-W", wait, host).Output()
+	if err != nil {
+		return 0, fmt.Errorf("%w from %s: %w: %s", infra.ErrPing, host, err, infra.ExtractStderr(err))
+	}
+	rtt, ok := parsePing(string(out))
+	if !ok {
+		return 0, fmt.Errorf("%w from %s: no round trip time in the output", infra.ErrPing, host)
+	}
+	return rtt, nil
+}
+
+var pingTime = regexp.MustCompile(`time[=<]([0-9.]+) ?ms`)
+
+// parsePing returns the round trip time of the first reply in the output of
+// ping.
+func parsePing(out string) (time.Duration, bool) {
+	m := pingTime.FindStringSubmatch(out)
+	if m == nil {
+		return 0, false
+	}
+	ms, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(ms * float64(time.Millisecond)), true
+}
+
+func (p *Prober) CheckARP(_ context.Context, ip string) error {
+	f, err := os.Open(p.arpPath)
+	if err != nil {
+		return fmt.Errorf("%w: %w", infra.ErrNotInARPTable, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	if !inARPTable(f, ip) {
+		return fmt.Errorf("%w: %s", infra.ErrNotInARPTable, ip)
+	}
+	return nil
+}
+
+// inARPTable tells whether the table, formatted as /proc/net/arp, has a
+// complete entry for ip.
+func inARPTable(table io.Reader, ip string) bool {
+	sc := bufio.NewScanner(table)
+	// The first line holds the column titles.
+	sc.Scan()
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 4 || fields[0] != ip {
+			continue
+		}
+		flags, err := strconv.ParseUint(fields[2], 0, 32)
+		if err == nil && flags&arpComplete != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Prober) Resolve(ctx context.Context, server, host string) ([]string, error) {
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			d := net.Dialer{Timeout: p.timeout}
+			return d.DialContext(ctx, network, net.JoinHostPort(server, "53"))
+		},
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	addrs, err := resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s with %s: %w", infra.ErrResolve, host, server, err)
+	}
+	return addrs, nil
+}
+
+func (p *Prober) FetchHTTP(ctx context.Context, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", infra.ErrHTTPCheck, err)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", infra.ErrHTTPCheck, err)
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func (p *Prober) ReadStats(_ context.Context, device string) (infra.InterfaceStats, error) {
+	// Interface names never hold a slash, but VLANs have dots, like eth0.100.
+	if device == "" || device == "." || device == ".." || strings.ContainsRune(device, '/') {
+		return infra.InterfaceStats{}, fmt.Errorf("%w: invalid device %q", infra.ErrReadStats, device)
+	}
+	var stats infra.InterfaceStats
+	counters := []struct {
+		name string
+		dst  *uint64
+	}{
+		{"rx_bytes", &stats.RxBytes},
+		{"tx_bytes", &stats.TxBytes},
+		{"rx_errors", &stats.RxErrors},
+		{"tx_errors", &stats.TxErrors},
+		{"rx_dropped", &stats.RxDropped},
+		{"tx_dropped", &stats.TxDropped},
+	}
+	dir := filepath.Join(p.sysPath, device, "statistics")
+	for _, c := range counters {
+		raw, err := os.ReadFile(filepath.Join(dir, c.name))
+		if err != nil {
+			return infra.InterfaceStats{}, fmt.Errorf("%w: %w", infra.ErrReadStats, err)
+		}
+		*c.dst, err = strconv.ParseUint(strings.TrimSpace(string(raw)), 10, 64)
+		if err != nil {
+			return infra.InterfaceStats{}, fmt.Errorf("%w: %s: %w", infra.ErrReadStats, c.name, err)
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,131 @@
+package diag
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alphameo/nm-tui/internal/infra"
+)
+
+func TestParsePing(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		out    string
+		want   time.Duration
+		wantOK bool
+	}{
+		{
+			name:   "iputils",
+			out:    "64 bytes from 192.168.1.1: icmp_seq=1 ttl=64 time=1.52 ms\n",
+			want:   1520 * time.Microsecond,
+			wantOK: true,
+		},
+		{
+			name:   "busybox",
+			out:    "64 bytes from 192.168.1.1: seq=0 ttl=64 time=12.000 ms\n",
+			want:   12 * time.Millisecond,
+			wantOK: true,
+		},
+		{
+			name:   "under-a-millisecond",
+			out:    "64 bytes from ::1: icmp_seq=1 ttl=64 time<1 ms\n",
+			want:   time.Millisecond,
+			wantOK: true,
+		},
+		{
+			name: "no-reply",
+			out:  "1 packets transmitted, 0 received, 100% packet loss, time 0ms\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := parsePing(tt.out)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parsePing() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestInARPTable(t *testing.T) {
+	t.Parallel()
+
+	table := "IP address       HW type     Flags       HW address            Mask     Device\n" +
+		"192.168.1.1      0x1         0x2         aa:bb:cc:dd:ee:01     *        wlan0\n" +
+		"192.168.1.7      0x1         0x0         00:00:00:00:00:00     *        wlan0\n"
+
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"192.168.1.1", true},
+		{"192.168.1.7", false},
+		{"192.168.1.9", false},
+		{"IP", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			t.Parallel()
+
+			if got := inARPTable(strings.NewReader(table), tt.ip); got != tt.want {
+				t.Errorf("inARPTable(%q) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadStats(t *testing.T) {
+	t.Parallel()
+
+	sys := t.TempDir()
+	counters := map[string]string{
+		"rx_bytes":   "1024\n",
+		"tx_bytes":   "512\n",
+		"rx_errors":  "3\n",
+		"tx_errors":  "0\n",
+		"rx_dropped": "7\n",
+		"tx_dropped": "1\n",
+	}
+	devices := []string{"wlan0", "eth0.100"}
+	for _, device := range devices {
+		dir := filepath.Join(sys, device, "statistics")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		for name, value := range counters {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(value), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	p := New()
+	p.sysPath = sys
+
+	want := infra.InterfaceStats{RxBytes: 1024, TxBytes: 512, RxErrors: 3, RxDropped: 7, TxDropped: 1}
+	for _, device := range devices {
+		got, err := p.ReadStats(context.Background(), device)
+		if err != nil {
+			t.Fatalf("ReadStats(%q) error: %v", device, err)
+		}
+		if got != want {
+			t.Errorf("ReadStats(%q) = %+v, want %+v", device, got, want)
+		}
+	}
+
+	for _, device := range []string{"eth0", "../wlan0", "", ".", ".."} {
+		if _, err := p.ReadStats(context.Background(), device); !errors.Is(err, infra.ErrReadStats) {
+			t.Errorf("ReadStats(%q) error = %v, want %v", device, err, infra.ErrReadStats)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package diag_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/alphameo/nm-tui/internal/infra/diag"
+)
+
+func TestFetchHTTP(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/portal" {
+			http.Redirect(w, r, "https://portal.example/login", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{"answer", "/generate_204", http.StatusNoContent},
+		{"redirect-not-followed", "/portal", http.StatusFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := diag.New().FetchHTTP(context.Background(), srv.URL+tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("FetchHTTP() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchHTTPUnreachable(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	_, err := diag.New().FetchHTTP(context.Background(), srv.URL)
+	if !errors.Is(err, infra.ErrHTTPCheck) {
+		t.Errorf("FetchHTTP() error = %v, want %v", err, infra.ErrHTTPCheck)
+	}
+}
@@ -0,0 +1,44 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrPing          = errors.New("no reply to ping")
+	ErrNotInARPTable = errors.New("not in the arp table")
+	ErrResolve       = errors.New("failed to resolve")
+	ErrHTTPCheck     = errors.New("http check failed")
+	ErrReadStats     = errors.New("failed to read interface statistics")
+)
+
+// InterfaceStats are the counters of a network interface since it came up.
+type InterfaceStats struct {
+	RxBytes   uint64
+	TxBytes   uint64
+	RxErrors  uint64
+	TxErrors  uint64
+	RxDropped uint64
+	TxDropped uint64
+}
+
+// Prober runs the network checks of the diagnostics and samples the
+// interfaces for the monitor.
+type Prober interface {
+	// Ping sends one ICMP echo request to host and returns the round trip
+	// time.
+	Ping(ctx context.Context, host string) (time.Duration, error)
+	// CheckARP fails with [ErrNotInARPTable] unless the neighbour ip answered
+	// ARP and is in the table of the kernel. Only IPv4 neighbours are there.
+	CheckARP(ctx context.Context, ip string) error
+	// Resolve looks host up through the DNS server only, not the system
+	// resolver.
+	Resolve(ctx context.Context, server, host string) ([]string, error)
+	// FetchHTTP requests url without following redirects and returns the
+	// status code.
+	FetchHTTP(ctx context.Context, url string) (int, error)
+	// ReadStats returns the counters of the interface device.
+	ReadStats(ctx context.Context, device string) (InterfaceStats, error)
+}
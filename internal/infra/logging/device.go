@@ -31,6 +31,18 @@ func (m *DeviceMiddleware) ListNetworkDevices(ctx context.Context) ([]infra.Netw
 	})
 }
 
+func (m *DeviceMiddleware) ListActiveVPNs(ctx context.Context) ([]string, error) {
+	return callResult(m.middleware, "list_active_vpns", func() ([]string, error) {
+		return m.device.ListActiveVPNs(ctx)
+	})
+}
+
+func (m *DeviceMiddleware) GetIPConfig(ctx context.Context, device string) (infra.IPConfig, error) {
+	return callResult(m.middleware, "get_ip_config", func() (infra.IPConfig, error) {
+		return m.device.GetIPConfig(ctx, device)
+	})
+}
+
 func (m *DeviceMiddleware) GetConnectivityStatus(ctx context.Context) (infra.ConnectivityStatus, error) {
 	return callResult(m.middleware, "get_connectivity_status", func() (infra.ConnectivityStatus, error) {
 		return m.device.GetConnectivityStatus(ctx)
@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/alphameo/nm-tui/internal/infra"
+)
+
+// ProberMiddleware implements infra.Prober by delegating to the wrapped
+// implementation. Successes are logged at Debug level, failures at Error
+// level along with the exit code of the failed command when the error is an
+// [*exec.ExitError].
+type ProberMiddleware struct {
+	middleware
+
+	prober infra.Prober
+}
+
+// NewProber returns a *ProberMiddleware wrapping the given prober.
+func NewProber(logger *slog.Logger, prober infra.Prober) *ProberMiddleware {
+	return &ProberMiddleware{
+		middleware: middleware{logger: logger, prefix: "diag"},
+		prober:     prober,
+	}
+}
+
+func (m *ProberMiddleware) Ping(ctx context.Context, host string) (time.Duration, error) {
+	return callResult(m.middleware, "ping", func() (time.Duration, error) {
+		return m.prober.Ping(ctx, host)
+	})
+}
+
+func (m *ProberMiddleware) CheckARP(ctx context.Context, ip string) error {
+	return m.call("check_arp", func() error {
+		return m.prober.CheckARP(ctx, ip)
+	})
+}
+
+func (m *ProberMiddleware) Resolve(ctx context.Context, server, host string) ([]string, error) {
+	return callResult(m.middleware, "resolve", func() ([]string, error) {
+		return m.prober.Resolve(ctx, server, host)
+	})
+}
+
+func (m *ProberMiddleware) FetchHTTP(ctx context.Context, url string) (int, error) {
+	return callResult(m.middleware, "fetch_http", func() (int, error) {
+		return m.prober.FetchHTTP(ctx, url)
+	})
+}
+
+func (m *ProberMiddleware) ReadStats(ctx context.Context, device string) (infra.InterfaceStats, error) {
+	return callResult(m.middleware, "read_stats", func() (infra.InterfaceStats, error) {
+		return m.prober.ReadStats(ctx, device)
+	})
+}
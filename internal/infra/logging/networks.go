@@ -116,3 +116,9 @@ func (m *NetworksMiddleware) UpdateProfile(ctx context.Context, name string, inf
 		return m.networks.UpdateProfile(ctx, name, info)
 	})
 }
+
+func (m *NetworksMiddleware) UpdateAutoconnect(ctx context.Context, name string, update infra.UpdateAutoconnect) error {
+	return m.call("update_autoconnect", func() error {
+		return m.networks.UpdateAutoconnect(ctx, name, update)
+	})
+}
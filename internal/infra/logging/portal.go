@@ -3,6 +3,7 @@ package logging
 import (
 	"context"
 	"log/slog"
+	"os/exec"
 
 	"github.com/alphameo/nm-tui/internal/infra"
 )
@@ -25,8 +26,19 @@ func NewPortal(logger *slog.Logger, portal infra.CaptivePortalOpener) *PortalMid
 	}
 }
 
-func (m *PortalMiddleware) OpenCaptivePortal(ctx context.Context) error {
-	return m.call("open_captive_portal", func() error {
-		return m.portal.OpenCaptivePortal(ctx)
+func (m *PortalMiddleware) FindCaptivePortal(ctx context.Context, settings infra.PortalSettings) (string, error) {
+	return callResult(m.middleware, "find_captive_portal", func() (string, error) {
+		return m.portal.FindCaptivePortal(ctx, settings)
+	})
+}
+
+// BrowserCommand only builds a command, so it is not logged.
+func (m *PortalMiddleware) BrowserCommand(url string, settings infra.PortalSettings) (*exec.Cmd, bool, error) {
+	return m.portal.BrowserCommand(url, settings)
+}
+
+func (m *PortalMiddleware) LoginCaptivePortal(ctx context.Context, portalURL string, form infra.PortalForm) error {
+	return m.call("login_captive_portal", func() error {
+		return m.portal.LoginCaptivePortal(ctx, portalURL, form)
 	})
 }
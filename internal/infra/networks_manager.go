@@ -3,6 +3,7 @@ package infra
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 type AvailableNetwork struct {
@@ -10,13 +11,18 @@ type AvailableNetwork struct {
 	Active   bool
 	Security string
 	Signal   int
+	BSSID    string
 }
 
 type NetworkProfileShort struct {
-	Name   string
-	SSID   string
-	Active bool
-	Mode   NetworkMode
+	Name                string
+	UUID                string
+	SSID                string
+	Active              bool
+	Mode                NetworkMode
+	AutoconnectPriority int
+	// LastUsed is the zero time for profiles that never connected.
+	LastUsed time.Time
 }
 
 type NetworkMode int
@@ -52,6 +58,11 @@ type NetworkProfile struct {
 	Autoconnect         bool
 	AutoconnectPriority int
 	Mode                NetworkMode
+	// KeyMgmt is the key management of the profile as NetworkManager names
+	// it, like "wpa-psk" or "sae", empty for open networks.
+	KeyMgmt string
+	// Hidden is set for networks that don't broadcast their SSID.
+	Hidden bool
 }
 
 type UpdateProfile struct {
@@ -61,6 +72,13 @@ type UpdateProfile struct {
 	AutoconnectPriority int
 }
 
+// UpdateAutoconnect changes the autoconnect settings of a profile, nil fields
+// are left as they are.
+type UpdateAutoconnect struct {
+	Autoconnect         *bool
+	AutoconnectPriority *int
+}
+
 type RadioStatus struct {
 	EnabledWifi bool
 	EnabledWWAN bool
@@ -85,11 +103,14 @@ var (
 	ErrGetWifiAutoconnect         = errors.New("failed retrieving wifi network autoconnect state")
 	ErrGetWifiAutoconnectPriority = errors.New("failed retrieving wifi network autoconnect priority")
 	ErrGetWifiActivity            = errors.New("failed retrieving wifi network activity state")
+	ErrGetWifiKeyMgmt             = errors.New("failed retrieving wifi network key management")
+	ErrGetWifiHidden              = errors.New("failed retrieving wifi network hidden state")
 	ErrGetProfile                 = errors.New("failed retrieving wifi network information")
 	ErrGetNetMode                 = errors.New("failed retrieving network mode")
 	ErrParseNetMode               = errors.New("failed to parse network mode")
 
-	ErrUpdateProfile = errors.New("failed modifying wifi network information")
+	ErrUpdateProfile     = errors.New("failed modifying wifi network information")
+	ErrUpdateAutoconnect = errors.New("failed modifying autoconnect settings")
 
 	ErrDeleteProfile = errors.New("failed deleting wifi connection")
 
@@ -143,4 +164,9 @@ type NetworksManager interface {
 
 	// UpdateProfile updates information about wifi-network with given name.
 	UpdateProfile(ctx context.Context, name string, info UpdateProfile) error
+
+	// UpdateAutoconnect changes only the autoconnect settings of the profile
+	// with given name, leaving its security alone. Works for any kind of
+	// profile.
+	UpdateAutoconnect(ctx context.Context, name string, update UpdateAutoconnect) error
 }
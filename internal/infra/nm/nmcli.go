@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/alphameo/nm-tui/internal/infra"
 )
@@ -50,7 +51,7 @@ func (n *CLI) ListNetworkDevices(ctx context.Context) ([]infra.NetworkDevice, er
 			continue
 		}
 
-		parts := strings.Split(line, ":")
+		parts := splitTerse(line)
 		if len(parts) < 4 {
 			continue
 		}
@@ -65,9 +66,72 @@ func (n *CLI) ListNetworkDevices(ctx context.Context) ([]infra.NetworkDevice, er
 	return res, nil
 }
 
+func (n *CLI) ListActiveVPNs(ctx context.Context) ([]string, error) {
+	args := []string{"-t", "-f", "NAME,TYPE", "connection", "show", "--active"}
+	out, err := n.run(ctx, infra.ErrListActiveVPNs, args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseActiveVPNs(string(out)), nil
+}
+
+// parseActiveVPNs picks the VPN and WireGuard connections from terse
+// NAME,TYPE lines.
+func parseActiveVPNs(connections string) []string {
+	var res []string
+	for line := range strings.SplitSeq(connections, "\n") {
+		parts := splitTerse(line)
+		if len(parts) < 2 {
+			continue
+		}
+		switch parts[1] {
+		case "vpn", "wireguard":
+			res = append(res, parts[0])
+		}
+	}
+	return res
+}
+
+func (n *CLI) GetIPConfig(ctx context.Context, device string) (infra.IPConfig, error) {
+	args := []string{"-t", "-f", "IP4,IP6", "device", "show", device}
+	out, err := n.run(ctx, infra.ErrGetIPConfig, args...)
+	if err != nil {
+		return infra.IPConfig{}, err
+	}
+	return parseIPConfig(string(out)), nil
+}
+
+// parseIPConfig reads terse "IP4.ADDRESS[1]:192.168.1.5/24" lines. Only the
+// first colon separates the value, IPv6 ones are left unescaped by some
+// versions of nmcli.
+func parseIPConfig(out string) infra.IPConfig {
+	var cfg infra.IPConfig
+	for line := range strings.SplitSeq(out, "\n") {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.Join(splitTerse(value), ":")
+		if value == "" || value == "--" {
+			continue
+		}
+		// Indexed fields, like IP4.DNS[2], repeat.
+		name, _, _ = strings.Cut(name, "[")
+		switch name {
+		case "IP4.ADDRESS", "IP6.ADDRESS":
+			cfg.Addresses = append(cfg.Addresses, value)
+		case "IP4.GATEWAY", "IP6.GATEWAY":
+			cfg.Gateways = append(cfg.Gateways, value)
+		case "IP4.DNS", "IP6.DNS":
+			cfg.DNS = append(cfg.DNS, value)
+		}
+	}
+	return cfg
+}
+
 func (n *CLI) ListNetworksWithRescan(ctx context.Context) ([]infra.AvailableNetwork, error) {
 	args := []string{
-		"-t", "-f", "SSID,IN-USE,SECURITY,SIGNAL",
+		"-t", "-f", "SSID,IN-USE,SECURITY,SIGNAL,BSSID",
 		"device", "wifi", "list", "--rescan", "yes",
 	}
 	out, err := n.run(ctx, infra.ErrScanNetworks, args...)
@@ -80,7 +144,7 @@ func (n *CLI) ListNetworksWithRescan(ctx context.Context) ([]infra.AvailableNetw
 
 func (n *CLI) ListNetworks(ctx context.Context) ([]infra.AvailableNetwork, error) {
 	args := []string{
-		"-t", "-f", "SSID,IN-USE,SECURITY,SIGNAL",
+		"-t", "-f", "SSID,IN-USE,SECURITY,SIGNAL,BSSID",
 		"device", "wifi", "list",
 	}
 	out, err := n.run(ctx, infra.ErrListNetworks, args...)
@@ -99,8 +163,8 @@ func parseNetworks(networks string) ([]infra.AvailableNetwork, error) {
 			continue
 		}
 
-		parts := strings.Split(line, ":")
-		if len(parts) < 4 {
+		parts := splitTerse(line)
+		if len(parts) < 5 {
 			continue
 		}
 
@@ -118,13 +182,37 @@ func parseNetworks(networks string) ([]infra.AvailableNetwork, error) {
 			Active:   parts[1] == "*",
 			Security: parts[2],
 			Signal:   signal,
+			BSSID:    parts[4],
 		})
 	}
 	return res, nil
 }
 
+// splitTerse splits a line of nmcli terse output into fields. Colons and
+// backslashes inside values are escaped with a backslash.
+func splitTerse(line string) []string {
+	var parts []string
+	var field strings.Builder
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			field.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ':':
+			parts = append(parts, field.String())
+			field.Reset()
+		default:
+			field.WriteRune(r)
+		}
+	}
+	return append(parts, field.String())
+}
+
 func (n *CLI) ListProfiles(ctx context.Context) ([]infra.NetworkProfileShort, error) {
-	args := []string{"-t", "-f", "NAME,STATE", "connection", "show"}
+	args := []string{"-t", "-f", "NAME,UUID,STATE,AUTOCONNECT-PRIORITY,TIMESTAMP", "connection", "show"}
 	out, err := n.run(ctx, infra.ErrListProfiles, args...)
 	if err != nil {
 		return nil, err
@@ -139,13 +227,18 @@ func (n *CLI) ListProfiles(ctx context.Context) ([]infra.NetworkProfileShort, er
 			continue
 		}
 
-		parts := strings.Split(line, ":")
-		if len(parts) < 2 {
+		parts := splitTerse(line)
+		if len(parts) < 5 {
 			continue
 		}
 		if parts[0] == "lo" {
 			continue
 		}
+		priority, _ := strconv.Atoi(parts[3])
+		var lastUsed time.Time
+		if ts, err := strconv.ParseInt(parts[4], 10, 64); err == nil && ts > 0 {
+			lastUsed = time.Unix(ts, 0)
+		}
 
 		name := parts[0]
 		ssid, err := n.getWifiSSID(ctx, name)
@@ -154,10 +247,13 @@ func (n *CLI) ListProfiles(ctx context.Context) ([]infra.NetworkProfileShort, er
 		}
 		wg.Add(1)
 		wifi := infra.NetworkProfileShort{
-			Name:   name,
-			SSID:   ssid,
-			Active: parts[1] == "activated",
-			Mode:   infra.NetworkNil,
+			Name:                name,
+			UUID:                parts[1],
+			SSID:                ssid,
+			Active:              parts[2] == "activated",
+			Mode:                infra.NetworkNil,
+			AutoconnectPriority: priority,
+			LastUsed:            lastUsed,
 		}
 		res = append(res, wifi)
 		go func(idx int) {
@@ -308,6 +404,32 @@ func (n *CLI) getWifiActive(ctx context.Context, id string) (bool, error) {
 	return strings.TrimSpace(string(out)) == "activated", nil
 }
 
+func (n *CLI) getWifiKeyMgmt(ctx context.Context, id string) (string, error) {
+	args := []string{
+		"-s", "-m", "tabular",
+		"-t", "-f", "802-11-wireless-security.key-mgmt",
+		"connection", "show", id,
+	}
+	out, err := n.run(ctx, infra.ErrGetWifiKeyMgmt, args...)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func (n *CLI) getWifiHidden(ctx context.Context, id string) (bool, error) {
+	args := []string{
+		"-s", "-m", "tabular",
+		"-t", "-f", "802-11-wireless.hidden",
+		"connection", "show", id,
+	}
+	out, err := n.run(ctx, infra.ErrGetWifiHidden, args...)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(out)) == "yes", nil
+}
+
 func (n *CLI) getNetMode(ctx context.Context, id string) (infra.NetworkMode, error) {
 	args := []string{
 		"-s", "-m", "tabular",
@@ -354,7 +476,7 @@ func (n *CLI) GetProfile(ctx context.Context, id string) (infra.NetworkProfile,
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 
-	wg.Add(6)
+	wg.Add(8)
 
 	go func() {
 		defer wg.Done()
@@ -392,6 +514,18 @@ func (n *CLI) GetProfile(ctx context.Context, id string) (infra.NetworkProfile,
 		setFetchResult(&mu, &errs, &info.Mode, mode, err)
 	}()
 
+	go func() {
+		defer wg.Done()
+		keyMgmt, err := n.getWifiKeyMgmt(ctx, id)
+		setFetchResult(&mu, &errs, &info.KeyMgmt, keyMgmt, err)
+	}()
+
+	go func() {
+		defer wg.Done()
+		hidden, err := n.getWifiHidden(ctx, id)
+		setFetchResult(&mu, &errs, &info.Hidden, hidden, err)
+	}()
+
 	wg.Wait()
 
 	if len(errs) != 0 {
@@ -434,6 +568,25 @@ func (n *CLI) UpdateProfile(ctx context.Context, id string, info infra.UpdatePro
 	return err
 }
 
+func (n *CLI) UpdateAutoconnect(ctx context.Context, id string, update infra.UpdateAutoconnect) error {
+	args := []string{"connection", "modify", id}
+	if update.Autoconnect != nil {
+		autoconnect := "no"
+		if *update.Autoconnect {
+			autoconnect = "yes"
+		}
+		args = append(args, "connection.autoconnect", autoconnect)
+	}
+	if update.AutoconnectPriority != nil {
+		args = append(args, "connection.autoconnect-priority", strconv.Itoa(*update.AutoconnectPriority))
+	}
+	if len(args) == 3 {
+		return nil
+	}
+	_, err := n.run(ctx, infra.ErrUpdateAutoconnect, args...)
+	return err
+}
+
 func (n *CLI) DeleteProfile(ctx context.Context, id string) error {
 	args := []string{"connection", "delete", id}
 	_, err := n.run(ctx, infra.ErrDeleteProfile, args...)
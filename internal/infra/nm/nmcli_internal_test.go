@@ -0,0 +1,89 @@
+package nm
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/infra"
+)
+
+func TestSplitTerse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		line string
+		want []string
+	}{
+		{"plain", "Home:*:WPA2:70", []string{"Home", "*", "WPA2", "70"}},
+		{"escaped-colon", `AA\:BB\:CC:x`, []string{"AA:BB:CC", "x"}},
+		{"escaped-backslash", `a\\:b`, []string{`a\`, "b"}},
+		{"empty-fields", "::", []string{"", "", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := splitTerse(tt.line); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitTerse(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNetworks(t *testing.T) {
+	t.Parallel()
+
+	out := "Cafe\\:2:*:WPA2:70:AA\\:BB\\:CC\\:DD\\:EE\\:01\n" +
+		":::40:AA\\:BB\\:CC\\:DD\\:EE\\:02\n" +
+		"Open::--:15:AA\\:BB\\:CC\\:DD\\:EE\\:03\n"
+
+	got, err := parseNetworks(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []infra.AvailableNetwork{
+		{SSID: "Cafe:2", Active: true, Security: "WPA2", Signal: 70, BSSID: "AA:BB:CC:DD:EE:01"},
+		{SSID: "Open", Security: "--", Signal: 15, BSSID: "AA:BB:CC:DD:EE:03"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseNetworks() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseActiveVPNs(t *testing.T) {
+	t.Parallel()
+
+	out := "Home:802-11-wireless\n" +
+		"Office VPN:vpn\n" +
+		"wg\\:home:wireguard\n" +
+		"lo:loopback\n"
+
+	want := []string{"Office VPN", "wg:home"}
+	if got := parseActiveVPNs(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseActiveVPNs() = %q, want %q", got, want)
+	}
+}
+
+func TestParseIPConfig(t *testing.T) {
+	t.Parallel()
+
+	out := "IP4.ADDRESS[1]:192.168.1.5/24\n" +
+		"IP4.GATEWAY:192.168.1.1\n" +
+		"IP4.ROUTE[1]:dst = 0.0.0.0/0, nh = 192.168.1.1, mt = 600\n" +
+		"IP4.DNS[1]:192.168.1.1\n" +
+		"IP4.DNS[2]:1.1.1.1\n" +
+		"IP6.ADDRESS[1]:fe80\\:\\:1/64\n" +
+		"IP6.GATEWAY:\n" +
+		"IP6.DNS[1]:2606:4700:4700::1111\n"
+
+	want := infra.IPConfig{
+		Addresses: []string{"192.168.1.5/24", "fe80::1/64"},
+		Gateways:  []string{"192.168.1.1"},
+		DNS:       []string{"192.168.1.1", "1.1.1.1", "2606:4700:4700::1111"},
+	}
+	if got := parseIPConfig(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseIPConfig() = %+v, want %+v", got, want)
+	}
+}
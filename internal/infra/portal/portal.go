@@ -1,53 +1,183 @@
-// Package portal opens the captive portal login page in the default browser.
+// Package portal finds captive portal login pages, opens them in a browser
+// and submits their login forms.
 package portal
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net"
+	"html"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
 	"os/exec"
+	"regexp"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/alphameo/nm-tui/internal/infra"
 )
 
-// Opener opens captive portal login pages using the default browser.
-type Opener struct{}
+const (
+	// DefaultBrowser stands for the default browser of the desktop.
+	DefaultBrowser = "default"
+	// urlPlaceholder is replaced by the URL of the portal in browser commands.
+	urlPlaceholder = "{url}"
+)
+
+// maxBodySize bounds the part of a page searched for a meta refresh.
+const maxBodySize = 64 << 10
+
+// Opener finds captive portals by probing a check URL.
+type Opener struct {
+	client *http.Client
+}
 
 func New() *Opener {
-	return &Opener{}
+	return &Opener{
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+			// The redirect is the answer, following it would load the portal.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
 }
 
-// OpenCaptivePortal opens the captive portal web-page in the default browser.
-func (p *Opener) OpenCaptivePortal(ctx context.Context) error {
-	ip, err := p.getGatewayIP(ctx)
+// FindCaptivePortal fetches the check URL and takes the portal from the
+// redirect or the meta refresh of the answer. A page without either is the
+// portal itself, served in place of the check URL.
+func (p *Opener) FindCaptivePortal(ctx context.Context, settings infra.PortalSettings) (string, error) {
+	checkURL, err := url.Parse(settings.CheckURL)
 	if err != nil {
-		return err
+		return "", fmt.Errorf("%w: check url: %w", infra.ErrOpenCaptivePortal, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, checkURL.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", infra.ErrOpenCaptivePortal, err)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", infra.ErrOpenCaptivePortal, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+		loc, err := resp.Location()
+		if err != nil {
+			return "", fmt.Errorf("%w: redirect without location", infra.ErrOpenCaptivePortal)
+		}
+		return loc.String(), nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", infra.ErrOpenCaptivePortal, err)
 	}
+	if online(resp.StatusCode, string(body), settings.CheckResponse) {
+		return "", infra.ErrNoCaptivePortal
+	}
+	if refresh, ok := metaRefresh(string(body)); ok {
+		loc, err := checkURL.Parse(refresh)
+		if err != nil {
+			return "", fmt.Errorf("%w: meta refresh: %w", infra.ErrOpenCaptivePortal, err)
+		}
+		return loc.String(), nil
+	}
+	return checkURL.String(), nil
+}
+
+// online tells whether the check URL was answered as it is without a portal.
+func online(status int, body, want string) bool {
+	if want == "" {
+		return status == http.StatusNoContent
+	}
+	return status == http.StatusOK && strings.HasPrefix(strings.TrimSpace(body), want)
+}
 
-	url := fmt.Sprintf("http://%s", ip.String())
+var (
+	metaTag     = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	httpEquiv   = regexp.MustCompile(`(?is)http-equiv\s*=\s*["']?refresh\b`)
+	contentAttr = regexp.MustCompile(`(?is)content\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	refreshURL  = regexp.MustCompile(`(?is)url\s*=\s*['"]?([^'"]+)`)
+)
 
-	return p.openURL(ctx, url)
+// metaRefresh returns the URL of the first
+// <meta http-equiv="refresh" content="0; url=..."> in page.
+func metaRefresh(page string) (string, bool) {
+	for _, tag := range metaTag.FindAllString(page, -1) {
+		if !httpEquiv.MatchString(tag) {
+			continue
+		}
+		content := contentAttr.FindStringSubmatch(tag)
+		if content == nil {
+			continue
+		}
+		value := html.UnescapeString(content[1] + content[2] + content[3])
+		if m := refreshURL.FindStringSubmatch(value); m != nil {
+			return strings.TrimSpace(m[1]), true
+		}
+	}
+	return "", false
 }
 
-// getGatewayIP is equivalent to
-// xdg-open "http://$(ip --oneline route get 1.1.1.1 | awk '{print $3}')".
-func (p *Opener) getGatewayIP(ctx context.Context) (net.IP, error) {
-	ipargs := []string{"--oneline", "route", "get", "1.1.1.1"}
-	route, err := exec.CommandContext(ctx, "ip", ipargs...).Output()
-	if err != nil {
-		return nil, fmt.Errorf("%w: %w", infra.ErrGetGatewayIP, err)
+// BrowserCommand returns the configured browser opening portalURL. The
+// default browser is replaced by the terminal browser outside of a graphical
+// session or when it is missing.
+func (p *Opener) BrowserCommand(portalURL string, settings infra.PortalSettings) (*exec.Cmd, bool, error) {
+	if settings.Browser != "" && settings.Browser != DefaultBrowser {
+		cmd, err := command(settings.Browser, portalURL)
+		return cmd, false, err
+	}
+
+	if graphicalSession() {
+		cmd, err := defaultBrowser(portalURL)
+		if err == nil || errors.Is(err, infra.ErrUnsupportedPlarform) {
+			return cmd, false, err
+		}
 	}
-	out := strings.Split(string(route), " ")
-	if len(out) < 3 {
-		return nil, fmt.Errorf("%w: unexpected format", infra.ErrGetGatewayIP)
+	if settings.TerminalBrowser == "" {
+		return nil, false, fmt.Errorf("%w: no default browser and no terminal browser set", infra.ErrNoBrowser)
 	}
-	return net.ParseIP(out[2]), nil
+	cmd, err := command(settings.TerminalBrowser, portalURL)
+	return cmd, true, err
 }
 
-// openURL opens the URL in the default browser (cross-platform).
-func (p *Opener) openURL(ctx context.Context, url string) error {
+// command splits browser on spaces and puts portalURL in place of "{url}", or
+// after the last argument.
+func command(browser, portalURL string) (*exec.Cmd, error) {
+	args := strings.Fields(browser)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%w: empty browser command", infra.ErrNoBrowser)
+	}
+	replaced := false
+	for i, arg := range args {
+		if strings.Contains(arg, urlPlaceholder) {
+			args[i] = strings.ReplaceAll(arg, urlPlaceholder, portalURL)
+			replaced = true
+		}
+	}
+	if !replaced {
+		args = append(args, portalURL)
+	}
+	return exec.Command(args[0], args[1:]...), nil
+}
+
+func graphicalSession() bool {
+	if runtime.GOOS != "linux" {
+		return true
+	}
+	return os.Getenv("WAYLAND_DISPLAY") != "" || os.Getenv("DISPLAY") != ""
+}
+
+// defaultBrowser returns the command opening portalURL in the default browser.
+func defaultBrowser(portalURL string) (*exec.Cmd, error) {
 	var (
 		cmd  string
 		args []string
@@ -62,9 +192,78 @@ func (p *Opener) openURL(ctx context.Context, url string) error {
 		cmd = "cmd"
 		args = []string{"/c", "start"}
 	default:
-		return fmt.Errorf("%w: %s", infra.ErrUnsupportedPlarform, runtime.GOOS)
+		return nil, fmt.Errorf("%w: %s", infra.ErrUnsupportedPlarform, runtime.GOOS)
+	}
+
+	if _, err := exec.LookPath(cmd); err != nil {
+		return nil, fmt.Errorf("%w: %w", infra.ErrNoBrowser, err)
+	}
+	args = append(args, portalURL)
+	return exec.Command(cmd, args...), nil
+}
+
+// LoginCaptivePortal loads the portal page, keeping the cookies it sets,
+// then sends the form the way a browser submits it.
+func (p *Opener) LoginCaptivePortal(ctx context.Context, portalURL string, form infra.PortalForm) error {
+	page, err := url.Parse(portalURL)
+	if err != nil {
+		return fmt.Errorf("%w: portal url: %w", infra.ErrPortalLogin, err)
+	}
+	action, err := page.Parse(form.Action)
+	if err != nil {
+		return fmt.Errorf("%w: action: %w", infra.ErrPortalLogin, err)
+	}
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return fmt.Errorf("%w: %w", infra.ErrPortalLogin, err)
 	}
+	client := &http.Client{Timeout: p.client.Timeout, Jar: jar}
 
-	args = append(args, url)
-	return exec.CommandContext(ctx, cmd, args...).Start()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, page.String(), nil)
+	if err != nil {
+		return fmt.Errorf("%w: %w", infra.ErrPortalLogin, err)
+	}
+	if err := do(client, req); err != nil {
+		return err
+	}
+
+	switch strings.ToUpper(form.Method) {
+	case http.MethodGet:
+		query := action.Query()
+		for name, values := range form.Fields {
+			query[name] = append(query[name], values...)
+		}
+		action.RawQuery = query.Encode()
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, action.String(), nil)
+	case http.MethodPost, "":
+		body := strings.NewReader(form.Fields.Encode())
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, action.String(), body)
+		if err == nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	default:
+		return fmt.Errorf("%w: unsupported method %q", infra.ErrPortalLogin, form.Method)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", infra.ErrPortalLogin, err)
+	}
+	req.Header.Set("Referer", page.String())
+	return do(client, req)
+}
+
+// do sends req, following redirects, and fails on error statuses.
+func do(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", infra.ErrPortalLogin, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxBodySize))
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%w: %s %s: %s", infra.ErrPortalLogin, req.Method, req.URL.Redacted(), resp.Status)
+	}
+	return nil
 }
@@ -0,0 +1,275 @@
+package portal_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/alphameo/nm-tui/internal/infra/portal"
+)
+
+const checkResponse = "NetworkManager is online"
+
+// newPortal starts a hotel-like portal intercepting every request, handled
+// by answer, and returns the check URL behind it.
+func newPortal(t *testing.T, answer http.HandlerFunc) string {
+	t.Helper()
+	srv := httptest.NewServer(answer)
+	t.Cleanup(srv.Close)
+	return srv.URL + "/check_network_status.txt"
+}
+
+func TestFindCaptivePortal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		answer  http.HandlerFunc
+		noBody  bool
+		want    string
+		wantErr error
+	}{
+		{
+			name: "redirect",
+			answer: func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "https://login.hotel.example/?orig=check", http.StatusFound)
+			},
+			want: "https://login.hotel.example/?orig=check",
+		},
+		{
+			name: "relative redirect",
+			answer: func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "/login", http.StatusTemporaryRedirect)
+			},
+			want: "/login",
+		},
+		{
+			name: "meta refresh",
+			answer: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`<html><head>` +
+					`<meta charset="utf-8">` +
+					`<meta content="0; URL='https://login.hotel.example/start?a=1&amp;b=2'" http-equiv="Refresh">` +
+					`</head></html>`))
+			},
+			want: "https://login.hotel.example/start?a=1&b=2",
+		},
+		{
+			name: "relative meta refresh",
+			answer: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`<meta http-equiv=refresh content="5;url=/portal/login">`))
+			},
+			want: "/portal/login",
+		},
+		{
+			name: "page in place of the check url",
+			answer: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("<html><body>Accept the terms</body></html>"))
+			},
+			want: "/check_network_status.txt",
+		},
+		{
+			name: "online",
+			answer: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(checkResponse + "\n"))
+			},
+			wantErr: infra.ErrNoCaptivePortal,
+		},
+		{
+			name: "online without body",
+			answer: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+			noBody:  true,
+			wantErr: infra.ErrNoCaptivePortal,
+		},
+		{
+			name: "intercepted without body",
+			answer: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("Please log in"))
+			},
+			noBody: true,
+			want:   "/check_network_status.txt",
+		},
+		{
+			name: "redirect without location",
+			answer: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusFound)
+			},
+			wantErr: infra.ErrOpenCaptivePortal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checkURL := newPortal(t, tt.answer)
+			settings := infra.PortalSettings{CheckURL: checkURL, CheckResponse: checkResponse}
+			if tt.noBody {
+				settings.CheckResponse = ""
+			}
+
+			got, err := portal.New().FindCaptivePortal(context.Background(), settings)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("FindCaptivePortal() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindCaptivePortal() error: %v", err)
+			}
+			want := tt.want
+			if strings.HasPrefix(want, "/") {
+				want = strings.TrimSuffix(checkURL, "/check_network_status.txt") + want
+			}
+			if got != want {
+				t.Errorf("FindCaptivePortal() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestFindCaptivePortalUnreachable(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.NotFoundHandler())
+	checkURL := srv.URL + "/check_network_status.txt"
+	srv.Close()
+
+	_, err := portal.New().FindCaptivePortal(context.Background(), infra.PortalSettings{CheckURL: checkURL})
+	if !errors.Is(err, infra.ErrOpenCaptivePortal) {
+		t.Errorf("FindCaptivePortal() error = %v, want %v", err, infra.ErrOpenCaptivePortal)
+	}
+}
+
+func TestBrowserCommand(t *testing.T) {
+	// Without a graphical session the terminal browser is used.
+	t.Setenv("DISPLAY", "")
+	t.Setenv("WAYLAND_DISPLAY", "")
+
+	const portalURL = "http://login.example/?a=1"
+	tests := []struct {
+		name         string
+		settings     infra.PortalSettings
+		wantArgs     []string
+		wantTerminal bool
+		wantErr      error
+	}{
+		{
+			name:     "url appended",
+			settings: infra.PortalSettings{Browser: "firefox --new-window"},
+			wantArgs: []string{"firefox", "--new-window", portalURL},
+		},
+		{
+			name:     "url placeholder",
+			settings: infra.PortalSettings{Browser: "chromium --app={url} --incognito"},
+			wantArgs: []string{"chromium", "--app=" + portalURL, "--incognito"},
+		},
+		{
+			name:         "terminal fallback",
+			settings:     infra.PortalSettings{Browser: portal.DefaultBrowser, TerminalBrowser: "w3m"},
+			wantArgs:     []string{"w3m", portalURL},
+			wantTerminal: true,
+		},
+		{
+			name:     "no fallback",
+			settings: infra.PortalSettings{Browser: portal.DefaultBrowser},
+			wantErr:  infra.ErrNoBrowser,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, terminal, err := portal.New().BrowserCommand(portalURL, tt.settings)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("BrowserCommand() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BrowserCommand() error: %v", err)
+			}
+			if !slices.Equal(cmd.Args, tt.wantArgs) || terminal != tt.wantTerminal {
+				t.Errorf("BrowserCommand() = %q, terminal %v, want %q, terminal %v",
+					cmd.Args, terminal, tt.wantArgs, tt.wantTerminal)
+			}
+		})
+	}
+}
+
+// guestPortal is a portal handing out a session cookie on its page and
+// accepting the terms at /login within the session.
+type guestPortal struct {
+	mu       sync.Mutex
+	accepted []string
+}
+
+func (g *guestPortal) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/portal":
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s3cr3t"})
+		_, _ = w.Write([]byte(`<form action="/login" method="post">`))
+	case "/login":
+		c, err := r.Cookie("session")
+		if err != nil || c.Value != "s3cr3t" || r.FormValue("accept") != "on" {
+			http.Error(w, "no session", http.StatusForbidden)
+			return
+		}
+		g.mu.Lock()
+		g.accepted = append(g.accepted, r.Method+" "+r.FormValue("email"))
+		g.mu.Unlock()
+		http.Redirect(w, r, "/welcome", http.StatusSeeOther)
+	case "/welcome":
+		_, _ = w.Write([]byte("You are online"))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestLoginCaptivePortal(t *testing.T) {
+	t.Parallel()
+
+	fields := url.Values{"accept": {"on"}, "email": {"me@example.com"}}
+	tests := []struct {
+		name    string
+		form    infra.PortalForm
+		want    string
+		wantErr bool
+	}{
+		{name: "post", form: infra.PortalForm{Action: "/login", Method: "POST", Fields: fields}, want: "POST me@example.com"},
+		{name: "get", form: infra.PortalForm{Action: "login", Method: "get", Fields: fields}, want: "GET me@example.com"},
+		{name: "default method", form: infra.PortalForm{Action: "/login", Fields: fields}, want: "POST me@example.com"},
+		{name: "rejected", form: infra.PortalForm{Action: "/login", Fields: url.Values{"email": {"x"}}}, wantErr: true},
+		{name: "wrong action", form: infra.PortalForm{Action: "/signin", Fields: fields}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			guest := &guestPortal{}
+			srv := httptest.NewServer(guest)
+			t.Cleanup(srv.Close)
+
+			err := portal.New().LoginCaptivePortal(context.Background(), srv.URL+"/portal", tt.form)
+			if tt.wantErr {
+				if !errors.Is(err, infra.ErrPortalLogin) {
+					t.Errorf("LoginCaptivePortal() error = %v, want %v", err, infra.ErrPortalLogin)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoginCaptivePortal() error: %v", err)
+			}
+			if !slices.Equal(guest.accepted, []string{tt.want}) {
+				t.Errorf("portal accepted %q, want %q", guest.accepted, tt.want)
+			}
+		})
+	}
+}
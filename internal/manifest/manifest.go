@@ -0,0 +1,178 @@
+// Package manifest reconciles saved Wi-Fi profiles with a declarative KDL
+// description of the profiles a machine should have.
+//
+// A manifest lists one profile node per connection:
+//
+//	profile "office" {
+//	    ssid "Office WiFi"
+//	    security "wpa-psk"
+//	    password_env "OFFICE_WIFI_PSK"
+//	    autoconnect #true
+//	    priority 10
+//	}
+//
+// Secrets are never stored in the manifest itself, they are read from the
+// environment variables it names.
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/calico32/kdl-go"
+)
+
+const (
+	SecurityOpen   = "open"
+	SecurityWPAPSK = "wpa-psk"
+)
+
+const (
+	minPSKLen = 8
+	maxPSKLen = 63
+)
+
+var (
+	ErrInvalidManifest = errors.New("invalid manifest")
+	ErrMissingSecret   = errors.New("missing secret")
+)
+
+// Manifest is the decoded manifest file.
+type Manifest struct {
+	Profiles []Profile `kdl:"profile,multiple"`
+}
+
+// Profile is a profile node as written in the manifest. Optional settings
+// are pointers, so that omitted ones can fall back to NetworkManager defaults.
+type Profile struct {
+	Name        string  `kdl:",arg"`
+	SSID        *string `kdl:"ssid"`
+	Security    *string `kdl:"security"`
+	PasswordEnv *string `kdl:"password_env"`
+	Autoconnect *bool   `kdl:"autoconnect"`
+	Priority    *int    `kdl:"priority"`
+	Hidden      *bool   `kdl:"hidden"`
+}
+
+// Desired is a profile with defaults applied and its secret resolved.
+type Desired struct {
+	Name        string
+	SSID        string
+	Password    string
+	Autoconnect bool
+	Priority    int
+	Hidden      bool
+}
+
+// Load decodes and validates a manifest.
+func Load(r io.Reader) (Manifest, error) {
+	var m Manifest
+	if err := kdl.Decode(r, &m); err != nil {
+		return Manifest{}, fmt.Errorf("decode manifest: %w", err)
+	}
+	if errs := m.Validate(); len(errs) > 0 {
+		return Manifest{}, fmt.Errorf("%w: %w", ErrInvalidManifest, errors.Join(errs...))
+	}
+	return m, nil
+}
+
+// Validate reports every problem of the manifest that can be found without
+// looking up secrets.
+func (m *Manifest) Validate() []error {
+	var errs []error
+	seen := make(map[string]bool, len(m.Profiles))
+	for i, p := range m.Profiles {
+		if p.Name == "" {
+			errs = append(errs, fmt.Errorf("profile #%d: empty name", i+1))
+			continue
+		}
+		if seen[p.Name] {
+			errs = append(errs, fmt.Errorf("profile %q: declared more than once", p.Name))
+		}
+		seen[p.Name] = true
+
+		if p.SSID != nil && *p.SSID == "" {
+			errs = append(errs, fmt.Errorf("profile %q: empty ssid", p.Name))
+		}
+		if p.Priority != nil && *p.Priority < 0 {
+			errs = append(errs, fmt.Errorf("profile %q: negative priority %d", p.Name, *p.Priority))
+		}
+		switch p.security() {
+		case SecurityOpen:
+			if p.PasswordEnv != nil {
+				errs = append(errs, fmt.Errorf("profile %q: password_env set for an open network", p.Name))
+			}
+		case SecurityWPAPSK:
+			if p.PasswordEnv == nil || *p.PasswordEnv == "" {
+				errs = append(errs, fmt.Errorf("profile %q: %s requires password_env", p.Name, SecurityWPAPSK))
+			}
+		default:
+			errs = append(errs, fmt.Errorf(
+				"profile %q: unknown security %q, want %s or %s",
+				p.Name, p.security(), SecurityOpen, SecurityWPAPSK,
+			))
+		}
+	}
+	return errs
+}
+
+// Resolve applies defaults and reads secrets with lookup, which usually is
+// [os.LookupEnv]. All missing or malformed secrets are reported at once.
+func (m *Manifest) Resolve(lookup func(key string) (string, bool)) ([]Desired, error) {
+	var errs []error
+	desired := make([]Desired, 0, len(m.Profiles))
+	for _, p := range m.Profiles {
+		d := Desired{
+			Name:        p.Name,
+			SSID:        p.Name,
+			Autoconnect: true,
+		}
+		if p.SSID != nil {
+			d.SSID = *p.SSID
+		}
+		if p.Autoconnect != nil {
+			d.Autoconnect = *p.Autoconnect
+		}
+		if p.Priority != nil {
+			d.Priority = *p.Priority
+		}
+		if p.Hidden != nil {
+			d.Hidden = *p.Hidden
+		}
+
+		if p.security() == SecurityWPAPSK && p.PasswordEnv != nil {
+			password, ok := lookup(*p.PasswordEnv)
+			if !ok || password == "" {
+				errs = append(errs, fmt.Errorf("%w: profile %q: $%s is not set", ErrMissingSecret, p.Name, *p.PasswordEnv))
+				continue
+			}
+			if n := utf8.RuneCountInString(password); n < minPSKLen || n > maxPSKLen {
+				errs = append(errs, fmt.Errorf(
+					"profile %q: $%s must be %d to %d characters long",
+					p.Name, *p.PasswordEnv, minPSKLen, maxPSKLen,
+				))
+				continue
+			}
+			d.Password = password
+		}
+		desired = append(desired, d)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return desired, nil
+}
+
+// security defaults to WPA-PSK when a secret is referenced, to open otherwise.
+func (p *Profile) security() string {
+	switch {
+	case p.Security != nil:
+		return *p.Security
+	case p.PasswordEnv != nil:
+		return SecurityWPAPSK
+	default:
+		return SecurityOpen
+	}
+}
@@ -0,0 +1,138 @@
+package manifest_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/manifest"
+)
+
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	}
+}
+
+func TestLoadExampleManifest(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open(filepath.Join("..", "..", "manifest.example.kdl"))
+	if err != nil {
+		t.Fatalf("open manifest.example.kdl: %v", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	m, err := manifest.Load(f)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	got, err := m.Resolve(env(map[string]string{
+		"OFFICE_WIFI_PSK": "office-secret",
+		"LAB_WIFI_PSK":    "lab-secret",
+	}))
+	if err != nil {
+		t.Fatalf("Resolve() error: %v", err)
+	}
+
+	want := []manifest.Desired{
+		{Name: "office", SSID: "Office WiFi", Password: "office-secret", Autoconnect: true, Priority: 10},
+		{Name: "lab", SSID: "Lab", Password: "lab-secret", Autoconnect: true, Priority: 5, Hidden: true},
+		{Name: "Guest", SSID: "Guest"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Resolve() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "duplicate name",
+			src:  `profile "a"; profile "a"`,
+			want: "declared more than once",
+		},
+		{
+			name: "empty name",
+			src:  `profile ""`,
+			want: "empty name",
+		},
+		{
+			name: "unknown security",
+			src:  `profile "a" { security "wep"; }`,
+			want: `unknown security "wep"`,
+		},
+		{
+			name: "wpa without secret",
+			src:  `profile "a" { security "wpa-psk"; }`,
+			want: "requires password_env",
+		},
+		{
+			name: "open with secret",
+			src:  `profile "a" { security "open"; password_env "X"; }`,
+			want: "password_env set for an open network",
+		},
+		{
+			name: "negative priority",
+			src:  `profile "a" { priority -1; }`,
+			want: "negative priority",
+		},
+		{
+			name: "empty ssid",
+			src:  `profile "a" { ssid ""; }`,
+			want: "empty ssid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := manifest.Load(strings.NewReader(tt.src))
+			if !errors.Is(err, manifest.ErrInvalidManifest) {
+				t.Fatalf("Load() error = %v, want %v", err, manifest.ErrInvalidManifest)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() error = %q, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveSecrets(t *testing.T) {
+	t.Parallel()
+
+	m, err := manifest.Load(strings.NewReader(`
+		profile "a" { password_env "A_PSK"; }
+		profile "b" { password_env "B_PSK"; }
+		profile "c" { password_env "C_PSK"; }
+	`))
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	_, err = m.Resolve(env(map[string]string{
+		"A_PSK": "long enough",
+		"B_PSK": "short",
+	}))
+	if !errors.Is(err, manifest.ErrMissingSecret) {
+		t.Fatalf("Resolve() error = %v, want %v", err, manifest.ErrMissingSecret)
+	}
+	for _, want := range []string{"$C_PSK is not set", "$B_PSK must be 8 to 63"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Resolve() error = %q, want it to contain %q", err, want)
+		}
+	}
+}
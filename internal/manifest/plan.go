@@ -0,0 +1,296 @@
+package manifest
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/alphameo/nm-tui/internal/infra"
+)
+
+// ChangeKind is what applying does with a single profile.
+type ChangeKind int
+
+const (
+	// ChangeCreate adds a profile the machine does not have yet.
+	ChangeCreate ChangeKind = iota
+	// ChangeUpdate modifies settings of a saved profile in place.
+	ChangeUpdate
+	// ChangeReplace recreates a saved profile, needed when its SSID or
+	// hidden flag differ, since those cannot be modified in place.
+	ChangeReplace
+	// ChangeDelete removes a saved profile missing from the manifest.
+	ChangeDelete
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeCreate:
+		return "create"
+	case ChangeUpdate:
+		return "update"
+	case ChangeReplace:
+		return "replace"
+	case ChangeDelete:
+		return "delete"
+	default:
+		return "undefined"
+	}
+}
+
+// Diff is a single differing setting. Secrets are never included in Old and
+// New.
+type Diff struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// ErrNameClash tells that a manifest entry is named like a saved hotspot or
+// non Wi-Fi profile, which is left alone.
+var ErrNameClash = errors.New("name taken by a profile that is not a Wi-Fi client")
+
+// replacingSuffix marks the name a replacement is created under, while the
+// profile it replaces still holds the name.
+const replacingSuffix = " (replacing)"
+
+// Clash is a manifest entry named like a saved hotspot or non Wi-Fi profile.
+type Clash struct {
+	Name string
+	Mode infra.NetworkMode
+}
+
+// Change is one step of a plan.
+type Change struct {
+	Kind ChangeKind
+	Name string
+	// Desired is the target state, empty for [ChangeDelete].
+	Desired Desired
+	Diffs   []Diff
+}
+
+// Plan is the difference between the manifest and the saved profiles.
+type Plan struct {
+	Changes []Change
+	// Unchanged lists managed profiles already matching the manifest.
+	Unchanged []string
+	// Clashes lists entries that cannot be applied without deleting a saved
+	// profile of another kind.
+	Clashes []Clash
+}
+
+// Empty reports whether applying the plan would do nothing.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Count returns the number of changes of the given kind.
+func (p *Plan) Count(kind ChangeKind) int {
+	n := 0
+	for _, c := range p.Changes {
+		if c.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// MakePlan compares desired profiles with the saved ones. With prune, saved
+// Wi-Fi client profiles absent from the manifest are deleted; hotspots and
+// non Wi-Fi profiles are never pruned, nor replaced: entries named like them
+// are reported as clashes.
+func MakePlan(ctx context.Context, networks infra.NetworksManager, desired []Desired, prune bool) (Plan, error) {
+	saved, err := networks.ListProfiles(ctx)
+	if err != nil {
+		return Plan{}, err
+	}
+	savedModes := make(map[string]infra.NetworkMode, len(saved))
+	for _, s := range saved {
+		savedModes[s.Name] = s.Mode
+	}
+
+	var plan Plan
+	managed := make(map[string]bool, len(desired))
+	for _, d := range desired {
+		managed[d.Name] = true
+		mode, ok := savedModes[d.Name]
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{Kind: ChangeCreate, Name: d.Name, Desired: d})
+			continue
+		}
+		if mode != infra.NetworkInfra {
+			plan.Clashes = append(plan.Clashes, Clash{Name: d.Name, Mode: mode})
+			continue
+		}
+
+		current, err := networks.GetProfile(ctx, d.Name)
+		if err != nil {
+			return Plan{}, err
+		}
+		change := compare(current, d)
+		if len(change.Diffs) == 0 {
+			plan.Unchanged = append(plan.Unchanged, d.Name)
+			continue
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	if prune {
+		deleted := make(map[string]bool)
+		for _, s := range saved {
+			if managed[s.Name] || deleted[s.Name] || s.Mode != infra.NetworkInfra {
+				continue
+			}
+			deleted[s.Name] = true
+			plan.Changes = append(plan.Changes, Change{Kind: ChangeDelete, Name: s.Name})
+		}
+	}
+	return plan, nil
+}
+
+func compare(current infra.NetworkProfile, d Desired) Change {
+	change := Change{Kind: ChangeUpdate, Name: d.Name, Desired: d}
+	if current.SSID != d.SSID {
+		change.Kind = ChangeReplace
+		change.Diffs = append(change.Diffs, Diff{"ssid", strconv.Quote(current.SSID), strconv.Quote(d.SSID)})
+	}
+	if current.Hidden != d.Hidden {
+		change.Kind = ChangeReplace
+		change.Diffs = append(change.Diffs, Diff{
+			"hidden",
+			strconv.FormatBool(current.Hidden),
+			strconv.FormatBool(d.Hidden),
+		})
+	}
+	if current.Password != d.Password {
+		change.Diffs = append(change.Diffs, Diff{"password", "(hidden)", "(hidden)"})
+	}
+	if security, want := cmp.Or(current.KeyMgmt, keyMgmtNone), keyMgmt(d.Password); security != want {
+		change.Diffs = append(change.Diffs, Diff{"security", security, want})
+	}
+	if current.Autoconnect != d.Autoconnect {
+		change.Diffs = append(change.Diffs, Diff{
+			"autoconnect",
+			strconv.FormatBool(current.Autoconnect),
+			strconv.FormatBool(d.Autoconnect),
+		})
+	}
+	if current.AutoconnectPriority != d.Priority {
+		change.Diffs = append(change.Diffs, Diff{
+			"priority",
+			strconv.Itoa(current.AutoconnectPriority),
+			strconv.Itoa(d.Priority),
+		})
+	}
+	return change
+}
+
+// Key managements of the profiles nm-tui saves, as NetworkManager names them.
+const (
+	keyMgmtNone   = "none"
+	keyMgmtWpaPsk = "wpa-psk"
+)
+
+// keyMgmt returns the key management a profile with password is saved with:
+// WPA-PSK, or none for open networks.
+func keyMgmt(password string) string {
+	if password == "" {
+		return keyMgmtNone
+	}
+	return keyMgmtWpaPsk
+}
+
+// Result summarizes an apply.
+type Result struct {
+	Created  int
+	Updated  int
+	Replaced int
+	Deleted  int
+	Errors   []error
+}
+
+func (r Result) String() string {
+	s := fmt.Sprintf(
+		"%d created, %d updated, %d replaced, %d deleted",
+		r.Created, r.Updated, r.Replaced, r.Deleted,
+	)
+	if len(r.Errors) > 0 {
+		s += fmt.Sprintf(", %d failed", len(r.Errors))
+	}
+	return s
+}
+
+// Err joins the errors of failed changes.
+func (r Result) Err() error {
+	return errors.Join(r.Errors...)
+}
+
+// Apply executes the plan. Deletions run first to free names for the
+// profiles being created. A failing change does not stop the others, and
+// applying a freshly made plan again changes nothing. Clashes are reported as
+// errors.
+func Apply(ctx context.Context, networks infra.NetworksManager, plan Plan) Result {
+	var res Result
+	for _, c := range plan.Clashes {
+		res.Errors = append(res.Errors, fmt.Errorf("%q (%s): %w", c.Name, c.Mode, ErrNameClash))
+	}
+	order := []ChangeKind{ChangeDelete, ChangeReplace, ChangeCreate, ChangeUpdate}
+	for _, kind := range order {
+		for _, c := range plan.Changes {
+			if c.Kind != kind {
+				continue
+			}
+			if err := applyChange(ctx, networks, c); err != nil {
+				res.Errors = append(res.Errors, fmt.Errorf("%s %q: %w", c.Kind, c.Name, err))
+				continue
+			}
+			switch kind {
+			case ChangeCreate:
+				res.Created++
+			case ChangeUpdate:
+				res.Updated++
+			case ChangeReplace:
+				res.Replaced++
+			case ChangeDelete:
+				res.Deleted++
+			}
+		}
+	}
+	return res
+}
+
+// applyChange runs a single change. A replacement is created under a
+// temporary name before the saved profile is deleted, so a failing create
+// leaves it alone, and renamed last.
+func applyChange(ctx context.Context, networks infra.NetworksManager, c Change) error {
+	d := c.Desired
+	createName := d.Name
+	switch c.Kind {
+	case ChangeDelete:
+		return networks.DeleteProfile(ctx, c.Name)
+	case ChangeReplace:
+		createName = d.Name + replacingSuffix
+		if err := networks.CreateConnectionProfile(ctx, createName, d.SSID, d.Password, d.Hidden); err != nil {
+			return err
+		}
+		if err := networks.DeleteProfile(ctx, c.Name); err != nil {
+			return fmt.Errorf("%w, new profile saved as %q", err, createName)
+		}
+	case ChangeCreate:
+		if err := networks.CreateConnectionProfile(ctx, d.Name, d.SSID, d.Password, d.Hidden); err != nil {
+			return err
+		}
+	}
+	err := networks.UpdateProfile(ctx, createName, infra.UpdateProfile{
+		Name:                d.Name,
+		Password:            d.Password,
+		Autoconnect:         d.Autoconnect,
+		AutoconnectPriority: d.Priority,
+	})
+	if err != nil && createName != d.Name {
+		return fmt.Errorf("%w, new profile saved as %q", err, createName)
+	}
+	return err
+}
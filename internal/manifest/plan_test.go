@@ -0,0 +1,358 @@
+package manifest_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/alphameo/nm-tui/internal/manifest"
+)
+
+// fakeNetworks keeps saved profiles in memory, so that plans can be applied
+// and planned again. Methods that are not overridden panic through the nil
+// embedded interface.
+type fakeNetworks struct {
+	infra.NetworksManager
+
+	profiles []infra.NetworkProfile
+	calls    []string
+	failOn   string
+}
+
+func (f *fakeNetworks) find(name string) int {
+	return slices.IndexFunc(f.profiles, func(p infra.NetworkProfile) bool { return p.Name == name })
+}
+
+func (f *fakeNetworks) ListProfiles(context.Context) ([]infra.NetworkProfileShort, error) {
+	res := make([]infra.NetworkProfileShort, len(f.profiles))
+	for i, p := range f.profiles {
+		res[i] = infra.NetworkProfileShort{Name: p.Name, SSID: p.SSID, Mode: p.Mode}
+	}
+	return res, nil
+}
+
+func (f *fakeNetworks) GetProfile(_ context.Context, name string) (infra.NetworkProfile, error) {
+	i := f.find(name)
+	if i < 0 {
+		return infra.NetworkProfile{}, infra.ErrGetProfile
+	}
+	return f.profiles[i], nil
+}
+
+func (f *fakeNetworks) DeleteProfile(_ context.Context, name string) error {
+	f.calls = append(f.calls, "delete "+name)
+	i := f.find(name)
+	if i < 0 {
+		return infra.ErrDeleteProfile
+	}
+	f.profiles = slices.Delete(f.profiles, i, i+1)
+	return nil
+}
+
+// keyMgmt is the key management nmcli saves a profile with.
+func keyMgmt(password string) string {
+	if password == "" {
+		return "none"
+	}
+	return "wpa-psk"
+}
+
+func (f *fakeNetworks) CreateConnectionProfile(_ context.Context, name, ssid, password string, hidden bool) error {
+	f.calls = append(f.calls, "create "+name)
+	if name == f.failOn {
+		return errors.New("nmcli failed")
+	}
+	f.profiles = append(f.profiles, infra.NetworkProfile{
+		Name:        name,
+		SSID:        ssid,
+		Password:    password,
+		Autoconnect: true,
+		Mode:        infra.NetworkInfra,
+		KeyMgmt:     keyMgmt(password),
+		Hidden:      hidden,
+	})
+	return nil
+}
+
+func (f *fakeNetworks) UpdateProfile(_ context.Context, name string, info infra.UpdateProfile) error {
+	f.calls = append(f.calls, "update "+name)
+	i := f.find(name)
+	if i < 0 {
+		return infra.ErrUpdateProfile
+	}
+	p := &f.profiles[i]
+	p.Name = info.Name
+	p.Password = info.Password
+	p.KeyMgmt = keyMgmt(info.Password)
+	p.Autoconnect = info.Autoconnect
+	p.AutoconnectPriority = info.AutoconnectPriority
+	return nil
+}
+
+func savedProfiles() []infra.NetworkProfile {
+	return []infra.NetworkProfile{
+		{Name: "office", SSID: "Office", Password: "office-pw", Autoconnect: true, Mode: infra.NetworkInfra, KeyMgmt: "wpa-psk"},
+		{Name: "lab", SSID: "Lab", Password: "old-lab-pw", Autoconnect: true, Mode: infra.NetworkInfra, KeyMgmt: "wpa-psk"},
+		{Name: "guest", SSID: "Guest-Old", Autoconnect: true, Mode: infra.NetworkInfra},
+		{Name: "cafe", SSID: "Cafe", Autoconnect: true, Mode: infra.NetworkInfra, KeyMgmt: "none"},
+		{Name: "laptop-ap", SSID: "laptop", Password: "hotspot!", Mode: infra.NetworkAccessPoint, KeyMgmt: "wpa-psk"},
+		{Name: "Wired connection 1", Mode: infra.NetworkNil},
+	}
+}
+
+func desiredProfiles() []manifest.Desired {
+	return []manifest.Desired{
+		{Name: "office", SSID: "Office", Password: "office-pw", Autoconnect: true},
+		{Name: "lab", SSID: "Lab", Password: "new-lab-pw", Autoconnect: true, Priority: 5},
+		{Name: "guest", SSID: "Guest", Autoconnect: true},
+		{Name: "home", SSID: "Home", Password: "home-pw", Autoconnect: false, Priority: 1},
+	}
+}
+
+func kinds(plan manifest.Plan) map[string]manifest.ChangeKind {
+	res := make(map[string]manifest.ChangeKind, len(plan.Changes))
+	for _, c := range plan.Changes {
+		res[c.Name] = c.Kind
+	}
+	return res
+}
+
+func TestMakePlan(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		prune         bool
+		wantChanges   map[string]manifest.ChangeKind
+		wantUnchanged []string
+	}{
+		{
+			name: "without prune",
+			wantChanges: map[string]manifest.ChangeKind{
+				"lab":   manifest.ChangeUpdate,
+				"guest": manifest.ChangeReplace,
+				"home":  manifest.ChangeCreate,
+			},
+			wantUnchanged: []string{"office"},
+		},
+		{
+			name:  "with prune keeps hotspots and wired profiles",
+			prune: true,
+			wantChanges: map[string]manifest.ChangeKind{
+				"lab":   manifest.ChangeUpdate,
+				"guest": manifest.ChangeReplace,
+				"home":  manifest.ChangeCreate,
+				"cafe":  manifest.ChangeDelete,
+			},
+			wantUnchanged: []string{"office"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			networks := &fakeNetworks{profiles: savedProfiles()}
+			plan, err := manifest.MakePlan(context.Background(), networks, desiredProfiles(), tt.prune)
+			if err != nil {
+				t.Fatalf("MakePlan() error: %v", err)
+			}
+			if got := kinds(plan); !reflect.DeepEqual(got, tt.wantChanges) {
+				t.Errorf("MakePlan() changes = %v, want %v", got, tt.wantChanges)
+			}
+			if !reflect.DeepEqual(plan.Unchanged, tt.wantUnchanged) {
+				t.Errorf("MakePlan() unchanged = %v, want %v", plan.Unchanged, tt.wantUnchanged)
+			}
+			if len(networks.calls) != 0 {
+				t.Errorf("MakePlan() modified profiles: %v", networks.calls)
+			}
+		})
+	}
+}
+
+func TestMakePlanDiffs(t *testing.T) {
+	t.Parallel()
+
+	networks := &fakeNetworks{profiles: savedProfiles()}
+	plan, err := manifest.MakePlan(context.Background(), networks, desiredProfiles(), false)
+	if err != nil {
+		t.Fatalf("MakePlan() error: %v", err)
+	}
+
+	want := map[string][]manifest.Diff{
+		"lab": {
+			{Field: "password", Old: "(hidden)", New: "(hidden)"},
+			{Field: "priority", Old: "0", New: "5"},
+		},
+		"guest": {
+			{Field: "ssid", Old: `"Guest-Old"`, New: `"Guest"`},
+		},
+		"home": nil,
+	}
+	for _, c := range plan.Changes {
+		if !reflect.DeepEqual(c.Diffs, want[c.Name]) {
+			t.Errorf("%s diffs = %+v, want %+v", c.Name, c.Diffs, want[c.Name])
+		}
+	}
+}
+
+func TestMakePlanHiddenAndSecurity(t *testing.T) {
+	t.Parallel()
+
+	saved := []infra.NetworkProfile{
+		{Name: "vault", SSID: "Vault", Password: "pw", Autoconnect: true, Mode: infra.NetworkInfra, KeyMgmt: "sae"},
+		{Name: "attic", SSID: "Attic", Password: "pw", Autoconnect: true, Mode: infra.NetworkInfra, KeyMgmt: "wpa-psk"},
+		{Name: "porch", SSID: "Porch", Autoconnect: true, Mode: infra.NetworkInfra, Hidden: true},
+	}
+	desired := []manifest.Desired{
+		{Name: "vault", SSID: "Vault", Password: "pw", Autoconnect: true},
+		{Name: "attic", SSID: "Attic", Password: "pw", Autoconnect: true, Hidden: true},
+		{Name: "porch", SSID: "Porch", Autoconnect: true, Hidden: true},
+	}
+
+	ctx := context.Background()
+	networks := &fakeNetworks{profiles: saved}
+	plan, err := manifest.MakePlan(ctx, networks, desired, false)
+	if err != nil {
+		t.Fatalf("MakePlan() error: %v", err)
+	}
+	wantKinds := map[string]manifest.ChangeKind{"vault": manifest.ChangeUpdate, "attic": manifest.ChangeReplace}
+	if got := kinds(plan); !reflect.DeepEqual(got, wantKinds) {
+		t.Errorf("MakePlan() changes = %v, want %v", got, wantKinds)
+	}
+	wantDiffs := map[string][]manifest.Diff{
+		"vault": {{Field: "security", Old: "sae", New: "wpa-psk"}},
+		"attic": {{Field: "hidden", Old: "false", New: "true"}},
+	}
+	for _, c := range plan.Changes {
+		if !reflect.DeepEqual(c.Diffs, wantDiffs[c.Name]) {
+			t.Errorf("%s diffs = %+v, want %+v", c.Name, c.Diffs, wantDiffs[c.Name])
+		}
+	}
+	if want := []string{"porch"}; !reflect.DeepEqual(plan.Unchanged, want) {
+		t.Errorf("MakePlan() unchanged = %v, want %v", plan.Unchanged, want)
+	}
+
+	if err := manifest.Apply(ctx, networks, plan).Err(); err != nil {
+		t.Fatalf("Apply() error: %v", err)
+	}
+	again, err := manifest.MakePlan(ctx, networks, desired, false)
+	if err != nil {
+		t.Fatalf("MakePlan() after Apply() error: %v", err)
+	}
+	if !again.Empty() {
+		t.Errorf("MakePlan() after Apply() = %+v, want no changes", again.Changes)
+	}
+}
+
+func TestApplyIsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	networks := &fakeNetworks{profiles: savedProfiles()}
+	plan, err := manifest.MakePlan(ctx, networks, desiredProfiles(), true)
+	if err != nil {
+		t.Fatalf("MakePlan() error: %v", err)
+	}
+
+	res := manifest.Apply(ctx, networks, plan)
+	if err := res.Err(); err != nil {
+		t.Fatalf("Apply() error: %v", err)
+	}
+	if got, want := res.String(), "1 created, 1 updated, 1 replaced, 1 deleted"; got != want {
+		t.Errorf("Apply() = %q, want %q", got, want)
+	}
+	wantCalls := []string{
+		"delete cafe",
+		"create guest (replacing)", "delete guest", "update guest (replacing)",
+		"create home", "update home",
+		"update lab",
+	}
+	if !reflect.DeepEqual(networks.calls, wantCalls) {
+		t.Errorf("Apply() calls = %v, want %v", networks.calls, wantCalls)
+	}
+
+	again, err := manifest.MakePlan(ctx, networks, desiredProfiles(), true)
+	if err != nil {
+		t.Fatalf("MakePlan() after Apply() error: %v", err)
+	}
+	if !again.Empty() {
+		t.Errorf("MakePlan() after Apply() = %+v, want no changes", again.Changes)
+	}
+}
+
+func TestApplyContinuesAfterFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	networks := &fakeNetworks{profiles: savedProfiles(), failOn: "home"}
+	plan, err := manifest.MakePlan(ctx, networks, desiredProfiles(), false)
+	if err != nil {
+		t.Fatalf("MakePlan() error: %v", err)
+	}
+
+	res := manifest.Apply(ctx, networks, plan)
+	if len(res.Errors) != 1 {
+		t.Fatalf("Apply() errors = %v, want 1", res.Errors)
+	}
+	if got, want := res.String(), "0 created, 1 updated, 1 replaced, 0 deleted, 1 failed"; got != want {
+		t.Errorf("Apply() = %q, want %q", got, want)
+	}
+}
+
+func TestMakePlanReportsClashes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	networks := &fakeNetworks{profiles: savedProfiles()}
+	desired := []manifest.Desired{
+		{Name: "laptop-ap", SSID: "laptop", Password: "hotspot!"},
+		{Name: "Wired connection 1", SSID: "wired"},
+	}
+	plan, err := manifest.MakePlan(ctx, networks, desired, true)
+	if err != nil {
+		t.Fatalf("MakePlan() error: %v", err)
+	}
+	wantClashes := []manifest.Clash{
+		{Name: "laptop-ap", Mode: infra.NetworkAccessPoint},
+		{Name: "Wired connection 1", Mode: infra.NetworkNil},
+	}
+	if !reflect.DeepEqual(plan.Clashes, wantClashes) {
+		t.Errorf("MakePlan() clashes = %+v, want %+v", plan.Clashes, wantClashes)
+	}
+
+	res := manifest.Apply(ctx, networks, plan)
+	if len(res.Errors) != 2 || !errors.Is(res.Err(), manifest.ErrNameClash) {
+		t.Errorf("Apply() errors = %v, want both clashes", res.Errors)
+	}
+	for _, call := range networks.calls {
+		if call == "delete laptop-ap" || call == "delete Wired connection 1" {
+			t.Errorf("Apply() deleted a clashing profile: %v", networks.calls)
+		}
+	}
+}
+
+func TestApplyReplaceKeepsProfileOnFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	networks := &fakeNetworks{profiles: savedProfiles(), failOn: "guest (replacing)"}
+	plan, err := manifest.MakePlan(ctx, networks, desiredProfiles()[2:3], false)
+	if err != nil {
+		t.Fatalf("MakePlan() error: %v", err)
+	}
+
+	res := manifest.Apply(ctx, networks, plan)
+	if len(res.Errors) != 1 {
+		t.Fatalf("Apply() errors = %v, want 1", res.Errors)
+	}
+	if want := []string{"create guest (replacing)"}; !reflect.DeepEqual(networks.calls, want) {
+		t.Errorf("Apply() calls = %v, want %v", networks.calls, want)
+	}
+	if i := networks.find("guest"); i < 0 || networks.profiles[i].SSID != "Guest-Old" {
+		t.Error("Apply() lost the saved profile after a failed replace")
+	}
+}
@@ -0,0 +1,111 @@
+// Package state keeps UI preferences that should survive restarts, such as
+// the sort order of tables, in a JSON file under the state directory
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/alphameo/nm-tui/internal/config"
+)
+
+const FileName = "state.json"
+
+// Sort is the sort preference of a table. An empty Column keeps the order
+// reported by NetworkManager.
+type Sort struct {
+	Column     string `json:"column,omitempty"`
+	Descending bool   `json:"descending,omitempty"`
+}
+
+type State struct {
+	AvailableSort Sort `json:"available_sort"`
+	ProfilesSort  Sort `json:"profiles_sort"`
+}
+
+// Store reads the state file once and rewrites it on every update. It is safe
+// for concurrent use.
+type Store struct {
+	path string
+
+	mu    sync.Mutex
+	state State
+}
+
+// DefaultPath returns the state file location inside [config.StateDir].
+func DefaultPath() string {
+	return filepath.Join(config.StateDir(), FileName)
+}
+
+// Open loads the state file at path. A missing file yields an empty state. A
+// malformed file is reported but still yields a usable store, so the next
+// update overwrites it.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("read state: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.state); err != nil {
+		s.state = State{}
+		return s, fmt.Errorf("parse state %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (s *Store) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// Update applies fn to the state and writes the result. The file is replaced
+// atomically, so a crash never leaves it half written.
+func (s *Store) Update(fn func(*State)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := s.state
+	fn(&next)
+	data, err := json.MarshalIndent(next, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode state: %w", err)
+	}
+	if err := writeFile(s.path, data); err != nil {
+		return err
+	}
+	s.state = next
+	return nil
+}
+
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create state directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), FileName+".*")
+	if err != nil {
+		return fmt.Errorf("write state: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package state_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/state"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "nested", state.FileName)
+	store, err := state.Open(path)
+	if err != nil {
+		t.Fatalf("Open() on missing file: %v", err)
+	}
+	if got := store.State(); got != (state.State{}) {
+		t.Errorf("State() of missing file = %+v, want empty", got)
+	}
+
+	want := state.Sort{Column: "signal", Descending: true}
+	if err := store.Update(func(s *state.State) { s.AvailableSort = want }); err != nil {
+		t.Fatalf("Update(): %v", err)
+	}
+
+	reopened, err := state.Open(path)
+	if err != nil {
+		t.Fatalf("Open() after update: %v", err)
+	}
+	if got := reopened.State().AvailableSort; got != want {
+		t.Errorf("AvailableSort = %+v, want %+v", got, want)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("state directory holds %d files, want only %s", len(entries), state.FileName)
+	}
+}
+
+func TestOpenMalformed(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), state.FileName)
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := state.Open(path)
+	if err == nil {
+		t.Fatal("Open() of malformed file returned no error")
+	}
+	if err := store.Update(func(s *state.State) { s.ProfilesSort.Column = "name" }); err != nil {
+		t.Fatalf("Update() after malformed file: %v", err)
+	}
+	reopened, err := state.Open(path)
+	if err != nil || reopened.State().ProfilesSort.Column != "name" {
+		t.Errorf("malformed file not replaced: state %+v, err %v", reopened.State(), err)
+	}
+}
@@ -26,6 +26,7 @@ func convertAvailableNetwork(record infra.AvailableNetwork) AvailableNetwork {
 		Active:   record.Active,
 		Security: record.Security,
 		Signal:   record.Signal,
+		BSSID:    record.BSSID,
 	}
 }
 
@@ -39,10 +40,14 @@ func convertAvailableNetworks(records []infra.AvailableNetwork) []AvailableNetwo
 
 func convertNetworkProfileShort(record infra.NetworkProfileShort) NetworkProfileShort {
 	return NetworkProfileShort{
-		Name:   record.Name,
-		SSID:   record.SSID,
-		Active: record.Active,
-		Mode:   ConvertNetworkMode(record.Mode),
+		Name:                record.Name,
+		UUID:                record.UUID,
+		SSID:                record.SSID,
+		Active:              record.Active,
+		Mode:                ConvertNetworkMode(record.Mode),
+		Hotspot:             record.Mode == infra.NetworkAccessPoint,
+		AutoconnectPriority: record.AutoconnectPriority,
+		LastUsed:            record.LastUsed,
 	}
 }
 
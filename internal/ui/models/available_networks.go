@@ -1,16 +1,21 @@
 package models
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
+	"time"
 
 	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/table"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/alphameo/nm-tui/internal/state"
 	"github.com/alphameo/nm-tui/internal/ui/styles"
+	"github.com/alphameo/nm-tui/internal/ui/tools/graph"
 	"github.com/alphameo/nm-tui/internal/ui/tools/renderer"
 )
 
@@ -19,22 +24,28 @@ type availableNetworksConfig struct {
 	ssidColIdx              int
 	securityColIdx          int
 	signalColIdx            int
+	historyColIdx           int
 	ssidColTitle            string
 	securityColTitle        string
 	stateColTitle           string
+	historyColTitle         string
 	securityWidthProportion float32
 	minSignalColWidth       int
 }
 
+const availableNetworksZoneID = "available"
+
 var availableNetworksCfg = availableNetworksConfig{
 	stateColIdx:    0,
 	ssidColIdx:     1,
 	securityColIdx: 2,
 	signalColIdx:   3,
+	historyColIdx:  4,
 
 	ssidColTitle:     "SSID",
 	securityColTitle: "Security",
 	stateColTitle:    "State",
+	historyColTitle:  "History",
 
 	securityWidthProportion: 0.3,
 	minSignalColWidth:       3,
@@ -44,9 +55,23 @@ type availableNetworksKeyMap struct {
 	connect    key.Binding
 	activate   key.Binding
 	deactivate key.Binding
+	details    key.Binding
+	filter     tableFilterKeyMap
+	sort       tableSortKeyMap
 }
 
 type AvailableNetworksModel struct {
+	networks []AvailableNetwork
+	// visible holds the networks shown in the table, in row order.
+	visible []AvailableNetwork
+	filter  tableFilter
+	sort    tableSort[AvailableNetwork]
+	cues    rowCues[AvailableNetwork]
+	history signalHistory
+	clicks  clickTracker
+	// store persists the sort preference, nil disables it.
+	store *state.Store
+
 	dataTable          table.Model
 	focusedTableStyles table.Styles
 	bluredTableStyles  table.Styles
@@ -65,7 +90,7 @@ func NewAvailableNetworksModel(
 	keys availableNetworksKeyMap,
 	networksManager infra.NetworksManager,
 ) *AvailableNetworksModel {
-	cols := make([]table.Column, 4)
+	cols := make([]table.Column, 5)
 	cols[availableNetworksCfg.stateColIdx] = table.Column{
 		Title: availableNetworksCfg.stateColTitle,
 		Width: len(availableNetworksCfg.stateColTitle),
@@ -82,6 +107,10 @@ func NewAvailableNetworksModel(
 		Title: styles.SymbolSignal,
 		Width: max(availableNetworksCfg.minSignalColWidth, len(styles.SymbolSignal)),
 	}
+	cols[availableNetworksCfg.historyColIdx] = table.Column{
+		Title: availableNetworksCfg.historyColTitle,
+		Width: max(signalHistoryCfg.sparkWidth, len(availableNetworksCfg.historyColTitle)),
+	}
 
 	t := table.New(
 		table.WithColumns(cols),
@@ -89,6 +118,10 @@ func NewAvailableNetworksModel(
 	)
 
 	model := &AvailableNetworksModel{
+		filter:             newTableFilter(keys.filter),
+		sort:               tableSort[AvailableNetwork]{options: availableNetworksSortOptions()},
+		cues:               newRowCues(networkID, compareSignal),
+		history:            newSignalHistory(),
 		dataTable:          t,
 		focusedTableStyles: table.DefaultStyles(),
 		bluredTableStyles:  table.DefaultStyles(),
@@ -102,6 +135,52 @@ func NewAvailableNetworksModel(
 	return model
 }
 
+// restyle applies the current styles and symbols to the filter and to the
+// rendered rows.
+func (m *AvailableNetworksModel) restyle() {
+	restyleInputs(&m.filter.input)
+	m.updateHeaders()
+	m.updateRows()
+}
+
+func availableNetworksSortOptions() []sortOption[AvailableNetwork] {
+	return []sortOption[AvailableNetwork]{
+		{
+			name:       "signal",
+			colIdx:     availableNetworksCfg.signalColIdx,
+			compare:    func(a, b AvailableNetwork) int { return cmp.Compare(a.Signal, b.Signal) },
+			descending: true,
+		},
+		{
+			name:    "ssid",
+			colIdx:  availableNetworksCfg.ssidColIdx,
+			compare: func(a, b AvailableNetwork) int { return compareFold(a.SSID, b.SSID) },
+		},
+		{
+			name:    "security",
+			colIdx:  availableNetworksCfg.securityColIdx,
+			compare: func(a, b AvailableNetwork) int { return compareFold(a.Security, b.Security) },
+		},
+		{
+			name:   "known",
+			colIdx: availableNetworksCfg.stateColIdx,
+			compare: func(a, b AvailableNetwork) int {
+				rank := func(n AvailableNetwork) int {
+					switch {
+					case n.Active:
+						return 2
+					case n.ProfileExists:
+						return 1
+					}
+					return 0
+				}
+				return cmp.Compare(rank(a), rank(b))
+			},
+			descending: true,
+		},
+	}
+}
+
 func (m *AvailableNetworksModel) Resize(width, height int) {
 	m.focusedStyle = m.focusedStyle.Width(width).Height(height)
 	m.bluredStyle = m.bluredStyle.Width(width).Height(height)
@@ -117,8 +196,9 @@ func (m *AvailableNetworksModel) Resize(width, height int) {
 
 	secColWidth := int(float32(width) * availableNetworksCfg.securityWidthProportion)
 	signalColWidth := m.dataTable.Columns()[availableNetworksCfg.signalColIdx].Width
+	historyColWidth := m.dataTable.Columns()[availableNetworksCfg.historyColIdx].Width
 	conColWidth := m.dataTable.Columns()[availableNetworksCfg.stateColIdx].Width
-	ssidWidth := width - signalColWidth - tableUtilityOffset - conColWidth - secColWidth
+	ssidWidth := width - signalColWidth - historyColWidth - tableUtilityOffset - conColWidth - secColWidth
 
 	m.dataTable.Columns()[availableNetworksCfg.securityColIdx].Width = secColWidth
 	m.dataTable.Columns()[availableNetworksCfg.ssidColIdx].Width = ssidWidth
@@ -173,28 +253,61 @@ func (m *AvailableNetworksModel) Update(msg tea.Msg) (*AvailableNetworksModel, t
 		if !m.focus {
 			return m, nil
 		}
+		if key.Matches(msg, m.keys.sort.cycle) && !m.filter.Editing() {
+			m.sort.cycle()
+			m.updateHeaders()
+			m.updateRows()
+			return m, m.saveSortCmd()
+		}
+		if key.Matches(msg, m.keys.sort.reverse) && !m.filter.Editing() {
+			m.sort.reverse()
+			m.updateHeaders()
+			m.updateRows()
+			return m, m.saveSortCmd()
+		}
+		if m.filter.Editing() || key.Matches(msg, m.keys.filter.open) {
+			cmd, changed := m.filter.Update(msg)
+			if changed {
+				m.updateRows()
+			}
+			return m, cmd
+		}
 		switch {
 		case key.Matches(msg, m.keys.connect):
-			row := m.dataTable.SelectedRow()
-			if row != nil {
-				return m, OpenConnectorCmd(row[availableNetworksCfg.ssidColIdx])
+			if network, ok := m.selected(); ok {
+				return m, OpenConnectorCmd(network.SSID)
 			}
 			return m, nil
 		case key.Matches(msg, m.keys.activate):
-			row := m.dataTable.SelectedRow()
-			if row != nil {
-				return m, m.activateConnToSelectedCmd()
+			if network, ok := m.selected(); ok {
+				return m, m.activateConnCmd(network.SSID)
 			}
 			return m, nil
 		case key.Matches(msg, m.keys.deactivate):
-			row := m.dataTable.SelectedRow()
-			if row != nil {
-				return m, m.deactivateConnToSelectedCmd()
+			if network, ok := m.selected(); ok {
+				return m, m.deactivateConnCmd(network.SSID)
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.details):
+			if network, ok := m.selected(); ok {
+				return m, OpenNetworkDetailsCmd(networkID(network))
 			}
 			return m, nil
 		}
 	case AvailableNetworksStateMsg:
 		return m, SetNetworksStateCmd(networksState(msg))
+	case rowCuesExpiredMsg:
+		m.cues.prune(time.Now())
+		m.updateRows()
+		return m, nil
+	case tea.MouseClickMsg, tea.MouseWheelMsg:
+		if !clickTableRow(&m.dataTable, availableNetworksZoneID, msg) {
+			return m, nil
+		}
+		if network, ok := m.selected(); ok && m.clicks.click(networkID(network), time.Now()) {
+			return m, OpenConnectorCmd(network.SSID)
+		}
+		return m, nil
 	}
 
 	var cmd tea.Cmd
@@ -203,6 +316,11 @@ func (m *AvailableNetworksModel) Update(msg tea.Msg) (*AvailableNetworksModel, t
 	m.dataTable, cmd = m.dataTable.Update(msg)
 	cmds = append(cmds, cmd)
 
+	if m.filter.Editing() {
+		cmd, _ = m.filter.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
 	return m, tea.Batch(cmds...)
 }
 
@@ -212,40 +330,176 @@ func (m *AvailableNetworksModel) View() string {
 	style := m.activeStyle()
 	view = renderer.RenderWithTitleAndKeybind(
 		view,
-		"Available networks",
+		m.filter.Title("Available networks"),
 		"1",
 		*style,
 		styles.AccentColor,
 	)
-	return view
+	return zones.Mark(availableNetworksZoneID, view)
 }
 
 func (m *AvailableNetworksModel) setAvailable(list []AvailableNetwork, err error) tea.Cmd {
+	m.networks = list
+	cuesCmd := m.cues.update(list, time.Now())
+	if err == nil {
+		m.history.record(list)
+	}
+	m.updateRows()
+
+	cmds := []tea.Cmd{SetNetworksStateCmd(NetsDone), cuesCmd}
+	if err != nil {
+		cmds = append(cmds, NotifyErrorCmd("Cannot scan available wifi networks"))
+	}
+	return tea.Batch(cmds...)
+}
+
+// updateRows fills the table with the networks matching the filter query,
+// highlighting the matched runes of SSID and security. Networks gone since
+// recent rescans stay below as struck out rows until their cues expire. The
+// cursor follows the selected network wherever it moved.
+func (m *AvailableNetworksModel) updateRows() {
+	selected, hadSelection := m.selected()
+	now := time.Now()
+
+	m.visible = nil
 	rows := []table.Row{}
-	for _, wifiNet := range list {
+	for _, wifiNet := range m.sort.apply(m.networks) {
+		matches, ok := filterMatch(m.filter.Query(), wifiNet.SSID, wifiNet.Security)
+		if !ok {
+			continue
+		}
 		var connectionFlag string
 		if wifiNet.Active {
 			connectionFlag = styles.SymbolCheck
 		} else if wifiNet.ProfileExists {
 			connectionFlag = styles.SymbolSaved
 		}
+
+		base := lipgloss.NewStyle()
+		signal := strconv.Itoa(wifiNet.Signal)
+		switch m.cues.change(networkID(wifiNet), now) {
+		case rowAppeared:
+			base = styles.CueAppearedStyle
+		case rowStronger:
+			signal += styles.SymbolSignalRising
+		case rowWeaker:
+			signal += styles.SymbolSignalFalling
+		}
+
+		m.visible = append(m.visible, wifiNet)
 		rows = append(rows, table.Row{
-			connectionFlag,
-			wifiNet.SSID,
-			wifiNet.Security,
-			strconv.Itoa(wifiNet.Signal),
+			markRow(availableNetworksZoneID, len(rows), connectionFlag),
+			highlightMatches(wifiNet.SSID, matches[0], base),
+			highlightMatches(wifiNet.Security, matches[1], base),
+			styles.SignalStyle(wifiNet.Signal).Render(signal),
+			styles.SignalStyle(wifiNet.Signal).Render(m.sparkline(networkID(wifiNet))),
+		})
+	}
+	for _, gone := range m.cues.vanished(now) {
+		if _, ok := filterMatch(m.filter.Query(), gone.SSID, gone.Security); !ok {
+			continue
+		}
+		rows = append(rows, table.Row{
+			markRow(availableNetworksZoneID, len(rows), ""),
+			styles.CueVanishedStyle.Render(gone.SSID),
+			styles.CueVanishedStyle.Render(gone.Security),
+			styles.CueVanishedStyle.Render(strconv.Itoa(gone.Signal)),
+			styles.CueVanishedStyle.Render(m.sparkline(networkID(gone))),
 		})
 	}
 
 	m.dataTable.SetRows(rows)
-	m.dataTable.GotoTop()
-	m.dataTable.UpdateViewport()
+	if hadSelection {
+		id := networkID(selected)
+		if idx := slices.IndexFunc(m.visible, func(n AvailableNetwork) bool { return networkID(n) == id }); idx >= 0 {
+			m.dataTable.SetCursor(idx)
+		}
+	}
+}
 
-	cmds := []tea.Cmd{SetNetworksStateCmd(NetsDone)}
-	if err != nil {
-		cmds = append(cmds, NotifyCmd("Cannot scan available wifi networks"))
+// sparkline draws the signal of the latest scans of the access point id.
+func (m *AvailableNetworksModel) sparkline(id string) string {
+	return graph.Sparkline(m.history.values(id), signalHistoryCfg.sparkWidth, 100)
+}
+
+// networkID identifies an access point across rescans.
+func networkID(n AvailableNetwork) string {
+	if n.BSSID != "" {
+		return n.BSSID
 	}
-	return tea.Batch(cmds...)
+	return n.SSID
+}
+
+// updateHeaders shows the sort indicator and fits the fixed width columns to
+// their titles.
+func (m *AvailableNetworksModel) updateHeaders() {
+	cols := m.dataTable.Columns()
+	titles := make([]string, len(cols))
+	titles[availableNetworksCfg.stateColIdx] = availableNetworksCfg.stateColTitle
+	titles[availableNetworksCfg.ssidColIdx] = availableNetworksCfg.ssidColTitle
+	titles[availableNetworksCfg.securityColIdx] = availableNetworksCfg.securityColTitle
+	titles[availableNetworksCfg.signalColIdx] = styles.SymbolSignal
+	titles[availableNetworksCfg.historyColIdx] = availableNetworksCfg.historyColTitle
+	m.sort.setHeaders(cols, titles)
+
+	stateCol := &cols[availableNetworksCfg.stateColIdx]
+	stateCol.Width = lipgloss.Width(stateCol.Title)
+	signalCol := &cols[availableNetworksCfg.signalColIdx]
+	signalCol.Width = max(availableNetworksCfg.minSignalColWidth, lipgloss.Width(signalCol.Title))
+	if m.Width() > 0 {
+		m.Resize(m.Width(), m.Height())
+	}
+}
+
+func (m *AvailableNetworksModel) restoreSort(pref state.Sort) {
+	m.sort.restore(pref)
+	m.updateHeaders()
+	m.updateRows()
+}
+
+func (m *AvailableNetworksModel) saveSortCmd() tea.Cmd {
+	if m.store == nil {
+		return nil
+	}
+	pref := m.sort.pref()
+	return func() tea.Msg {
+		err := m.store.Update(func(s *state.State) { s.AvailableSort = pref })
+		if err != nil {
+			return NotifyErrorCmd(fmt.Sprintf("Cannot save sort preference:\n%v", err))
+		}
+		return NilMsg{}
+	}
+}
+
+func (m *AvailableNetworksModel) selected() (AvailableNetwork, bool) {
+	cursor := m.dataTable.Cursor()
+	if cursor < 0 || cursor >= len(m.visible) {
+		return AvailableNetwork{}, false
+	}
+	return m.visible[cursor], true
+}
+
+// network returns the access point id from the last scan.
+func (m *AvailableNetworksModel) network(id string) (AvailableNetwork, bool) {
+	idx := slices.IndexFunc(m.networks, func(n AvailableNetwork) bool { return networkID(n) == id })
+	if idx < 0 {
+		return AvailableNetwork{}, false
+	}
+	return m.networks[idx], true
+}
+
+// detailsCmd opens the details of the strongest access point of ssid.
+func (m *AvailableNetworksModel) detailsCmd(ssid string) tea.Cmd {
+	var best *AvailableNetwork
+	for i, n := range m.networks {
+		if n.SSID == ssid && (best == nil || n.Signal > best.Signal) {
+			best = &m.networks[i]
+		}
+	}
+	if best == nil {
+		return NotifyErrorCmd(fmt.Sprintf("No network with SSID=%q in range", ssid))
+	}
+	return OpenNetworkDetailsCmd(networkID(*best))
 }
 
 type AvailableNetworksStateMsg networksState
@@ -256,37 +510,36 @@ func SetAvailableNetworksStateCmd(state networksState) tea.Cmd {
 	}
 }
 
-func (m *AvailableNetworksModel) activateConnToSelectedCmd() tea.Cmd {
+func (m *AvailableNetworksModel) activateConnCmd(ssid string) tea.Cmd {
 	return tea.Sequence(
 		SetNetworksStateCmd(NetsActivating),
 		func() tea.Msg {
-			ssid := m.dataTable.SelectedRow()[availableNetworksCfg.ssidColIdx]
 			err := m.netMngr.TryActivateNetwork(context.Background(), ssid)
 			if err != nil {
 				return tea.Batch(
 					SetNetworksStateCmd(NetsDone),
-					NotifyCmd(fmt.Sprintf("Cannot activate connection to network with SSID=%q\n"+
+					NotifyErrorCmd(fmt.Sprintf("Cannot activate connection to network with SSID=%q\n"+
 						"Try connect via profile", ssid)),
 				)
 			}
 			return tea.Batch(
 				SetNetworksStateCmd(NetsDone),
 				RescanNetworksCmd(),
+				networkActivatedCmd(ssid),
 			)
 		},
 	)
 }
 
-func (m *AvailableNetworksModel) deactivateConnToSelectedCmd() tea.Cmd {
+func (m *AvailableNetworksModel) deactivateConnCmd(name string) tea.Cmd {
 	return tea.Sequence(
 		SetNetworksStateCmd(NetsDeactivating),
 		func() tea.Msg {
-			name := m.dataTable.SelectedRow()[availableNetworksCfg.ssidColIdx]
 			err := m.netMngr.DeactivateProfile(context.Background(), name)
 			if err != nil {
 				return tea.Batch(
 					SetNetworksStateCmd(NetsDone),
-					NotifyCmd(
+					NotifyErrorCmd(
 						fmt.Sprintf("Error while deactivating connection to network with SSID=%q\n"+
 							"try disconnect via profile (The profile name and SSID may differ)", name),
 					),
@@ -0,0 +1,205 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/alphameo/nm-tui/internal/backup"
+	"github.com/alphameo/nm-tui/internal/config"
+	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/alphameo/nm-tui/internal/ui/models/focus"
+	"github.com/alphameo/nm-tui/internal/ui/styles"
+	"github.com/alphameo/nm-tui/internal/ui/tools/compositor"
+	"github.com/alphameo/nm-tui/internal/ui/tools/renderer"
+)
+
+type backupConfig struct {
+	exportTitle string
+	importTitle string
+	defaultPath string
+}
+
+const backupExportZoneID = "backup_export"
+
+var backupCfg = backupConfig{
+	exportTitle: "Export Network profiles",
+	importTitle: "Import Network profiles",
+	defaultPath: "~/nm-tui-backup.json",
+}
+
+type backupExportKeyMap struct {
+	togglePWVisibility key.Binding
+	prev               key.Binding
+	next               key.Binding
+	export             key.Binding
+}
+
+// BackupExportModel asks where to save the profiles archive and for an
+// optional passphrase to encrypt it with.
+type BackupExportModel struct {
+	path       textinput.Model
+	passphrase textinput.Model
+
+	focuses focus.Group
+
+	keys backupExportKeyMap
+
+	netMngr infra.NetworksManager
+	Style   lipgloss.Style
+}
+
+func NewBackupExportModel(keys backupExportKeyMap, networksManager infra.NetworksManager) *BackupExportModel {
+	model := &BackupExportModel{
+		path:       newDefaultPathInput(),
+		passphrase: newDefaultPassphraseInput(),
+		keys:       keys,
+		netMngr:    networksManager,
+		Style:      lipgloss.NewStyle(),
+	}
+
+	inp := []focus.Focusable{
+		&model.path,
+		&model.passphrase,
+	}
+	model.focuses = *focus.NewGroup(inp)
+
+	return model
+}
+
+// restyle applies the current styles to the inputs.
+func (m *BackupExportModel) restyle() {
+	restyleInputs(&m.path, &m.passphrase)
+}
+
+func (m *BackupExportModel) Reset() tea.Cmd {
+	m.path.SetValue(backupCfg.defaultPath)
+	m.path.CursorEnd()
+
+	m.passphrase.Reset()
+	m.passphrase.EchoMode = textinput.EchoPassword
+	m.passphrase.Blur()
+
+	return m.focuses.SetFocusIdx(0)
+}
+
+func (m *BackupExportModel) Init() tea.Cmd {
+	return m.focuses.SetFocusIdx(0)
+}
+
+func (m *BackupExportModel) Update(msg tea.Msg) (*BackupExportModel, tea.Cmd) {
+	if msg, ok := msg.(tea.MouseClickMsg); ok {
+		cmd, _ := focusClicked(&m.focuses, backupExportZoneID, msg)
+		return m, cmd
+	}
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.next):
+			return m, m.focuses.FocusCycleNextCmd()
+		case key.Matches(msg, m.keys.prev):
+			return m, m.focuses.FocusCyclePrevCmd()
+		case key.Matches(msg, m.keys.togglePWVisibility):
+			togglePasswordEcho(&m.passphrase)
+			return m, nil
+		case key.Matches(msg, m.keys.export):
+			if m.path.Value() == "" {
+				return m, nil
+			}
+			return m, tea.Sequence(
+				ClosePopupCmd(),
+				m.exportCmd(m.path.Value(), m.passphrase.Value()),
+			)
+		}
+	}
+
+	var cmd tea.Cmd
+	var cmds []tea.Cmd
+
+	m.path, cmd = m.path.Update(msg)
+	cmds = append(cmds, cmd)
+
+	m.passphrase, cmd = m.passphrase.Update(msg)
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *BackupExportModel) UpdateAsPopup(msg tea.Msg) (PopupModel, tea.Cmd) {
+	return m.Update(msg)
+}
+
+func (m *BackupExportModel) View() string {
+	path := styles.ViewBorderedFocusable(&m.path)
+	path = lipgloss.JoinHorizontal(lipgloss.Center, "File       ", path)
+	path = markField(backupExportZoneID, 0, path)
+
+	passphrase := styles.ViewBorderedFocusable(&m.passphrase)
+	passphrase = lipgloss.JoinHorizontal(lipgloss.Center, "Passphrase ", passphrase)
+	passphrase = markField(backupExportZoneID, 1, passphrase)
+
+	view := lipgloss.JoinVertical(
+		lipgloss.Left,
+		path,
+		passphrase,
+		styles.MutedStyle.Render("Leave passphrase empty to store secrets unencrypted"),
+	)
+
+	view = m.Style.Render(view)
+	title := styles.DefaultStyle.Render(renderer.RenderTitle(backupCfg.exportTitle))
+	return compositor.Compose(
+		title,
+		view,
+		compositor.Center,
+		compositor.Begin,
+		0,
+		0,
+	)
+}
+
+func (m *BackupExportModel) exportCmd(path, passphrase string) tea.Cmd {
+	return tea.Sequence(
+		SetNetworksStateCmd(NetsExporting),
+		func() tea.Msg {
+			n, err := exportProfiles(m.netMngr, config.ExpandPath(path), passphrase)
+			if err != nil {
+				return tea.Batch(
+					SetNetworksStateCmd(NetsDone),
+					NotifyErrorCmd(fmt.Sprintf("Cannot export profiles:\n%v", err)),
+				)
+			}
+			return tea.Batch(
+				SetNetworksStateCmd(NetsDone),
+				NotifyCmd(fmt.Sprintf("Exported %d profiles to %s", n, path)),
+			)
+		},
+	)
+}
+
+func exportProfiles(networks infra.NetworksManager, path, passphrase string) (int, error) {
+	archive, err := backup.Collect(context.Background(), networks)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return 0, err
+	}
+	if err := backup.Write(f, archive, passphrase); err != nil {
+		_ = f.Close()
+		return 0, err
+	}
+	return len(archive.Profiles), f.Close()
+}
+
+func togglePasswordEcho(input *textinput.Model) {
+	if input.EchoMode == textinput.EchoPassword {
+		input.EchoMode = textinput.EchoNormal
+	} else {
+		input.EchoMode = textinput.EchoPassword
+	}
+}
@@ -0,0 +1,170 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/alphameo/nm-tui/internal/backup"
+	"github.com/alphameo/nm-tui/internal/config"
+	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/alphameo/nm-tui/internal/ui/models/focus"
+	"github.com/alphameo/nm-tui/internal/ui/styles"
+	"github.com/alphameo/nm-tui/internal/ui/tools/compositor"
+	"github.com/alphameo/nm-tui/internal/ui/tools/renderer"
+)
+
+const backupImportZoneID = "backup_import"
+
+type backupImportKeyMap struct {
+	togglePWVisibility key.Binding
+	prev               key.Binding
+	next               key.Binding
+	load               key.Binding
+}
+
+// BackupImportModel asks for the profiles archive to restore. Once the
+// archive is read it hands the restore plan over to [RestorePreviewModel].
+type BackupImportModel struct {
+	path       textinput.Model
+	passphrase textinput.Model
+
+	focuses focus.Group
+
+	keys backupImportKeyMap
+
+	netMngr infra.NetworksManager
+	Style   lipgloss.Style
+}
+
+func NewBackupImportModel(keys backupImportKeyMap, networksManager infra.NetworksManager) *BackupImportModel {
+	model := &BackupImportModel{
+		path:       newDefaultPathInput(),
+		passphrase: newDefaultPassphraseInput(),
+		keys:       keys,
+		netMngr:    networksManager,
+		Style:      lipgloss.NewStyle(),
+	}
+
+	inp := []focus.Focusable{
+		&model.path,
+		&model.passphrase,
+	}
+	model.focuses = *focus.NewGroup(inp)
+
+	return model
+}
+
+// restyle applies the current styles to the inputs.
+func (m *BackupImportModel) restyle() {
+	restyleInputs(&m.path, &m.passphrase)
+}
+
+func (m *BackupImportModel) Reset() tea.Cmd {
+	m.path.SetValue(backupCfg.defaultPath)
+	m.path.CursorEnd()
+
+	m.passphrase.Reset()
+	m.passphrase.EchoMode = textinput.EchoPassword
+	m.passphrase.Blur()
+
+	return m.focuses.SetFocusIdx(0)
+}
+
+func (m *BackupImportModel) Init() tea.Cmd {
+	return m.focuses.SetFocusIdx(0)
+}
+
+func (m *BackupImportModel) Update(msg tea.Msg) (*BackupImportModel, tea.Cmd) {
+	if msg, ok := msg.(tea.MouseClickMsg); ok {
+		cmd, _ := focusClicked(&m.focuses, backupImportZoneID, msg)
+		return m, cmd
+	}
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.next):
+			return m, m.focuses.FocusCycleNextCmd()
+		case key.Matches(msg, m.keys.prev):
+			return m, m.focuses.FocusCyclePrevCmd()
+		case key.Matches(msg, m.keys.togglePWVisibility):
+			togglePasswordEcho(&m.passphrase)
+			return m, nil
+		case key.Matches(msg, m.keys.load):
+			if m.path.Value() == "" {
+				return m, nil
+			}
+			return m, m.loadCmd(m.path.Value(), m.passphrase.Value())
+		}
+	}
+
+	var cmd tea.Cmd
+	var cmds []tea.Cmd
+
+	m.path, cmd = m.path.Update(msg)
+	cmds = append(cmds, cmd)
+
+	m.passphrase, cmd = m.passphrase.Update(msg)
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *BackupImportModel) UpdateAsPopup(msg tea.Msg) (PopupModel, tea.Cmd) {
+	return m.Update(msg)
+}
+
+func (m *BackupImportModel) View() string {
+	path := styles.ViewBorderedFocusable(&m.path)
+	path = lipgloss.JoinHorizontal(lipgloss.Center, "File       ", path)
+	path = markField(backupImportZoneID, 0, path)
+
+	passphrase := styles.ViewBorderedFocusable(&m.passphrase)
+	passphrase = lipgloss.JoinHorizontal(lipgloss.Center, "Passphrase ", passphrase)
+	passphrase = markField(backupImportZoneID, 1, passphrase)
+
+	view := lipgloss.JoinVertical(
+		lipgloss.Left,
+		path,
+		passphrase,
+		styles.MutedStyle.Render("Passphrase is only needed for encrypted archives"),
+	)
+
+	view = m.Style.Render(view)
+	title := styles.DefaultStyle.Render(renderer.RenderTitle(backupCfg.importTitle))
+	return compositor.Compose(
+		title,
+		view,
+		compositor.Center,
+		compositor.Begin,
+		0,
+		0,
+	)
+}
+
+// loadCmd reads the archive and matches it against the saved profiles. The
+// popup stays open on failure, so a mistyped passphrase can be corrected.
+func (m *BackupImportModel) loadCmd(path, passphrase string) tea.Cmd {
+	return func() tea.Msg {
+		f, err := os.Open(config.ExpandPath(path))
+		if err != nil {
+			return NotifyErrorCmd(fmt.Sprintf("Cannot read %s:\n%v", path, err))
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+
+		archive, err := backup.Read(f, passphrase)
+		if err != nil {
+			return NotifyErrorCmd(fmt.Sprintf("Cannot read %s:\n%v", path, err))
+		}
+		saved, err := m.netMngr.ListProfiles(context.Background())
+		if err != nil {
+			return NotifyErrorCmd(fmt.Sprintf("Cannot get network profiles:\n%v", err))
+		}
+		return OpenRestorePreviewCmd(backup.Plan(archive, saved))
+	}
+}
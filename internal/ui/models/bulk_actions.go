@@ -0,0 +1,324 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/alphameo/nm-tui/internal/ui/models/focus"
+	"github.com/alphameo/nm-tui/internal/ui/styles"
+	"github.com/alphameo/nm-tui/internal/ui/tools/compositor"
+	"github.com/alphameo/nm-tui/internal/ui/tools/renderer"
+)
+
+type bulkActionsConfig struct {
+	title string
+	// maxReportedErrors limits the failures listed in the result notification.
+	maxReportedErrors int
+}
+
+const bulkActionsZoneID = "bulk_actions"
+
+var bulkActionsCfg = bulkActionsConfig{
+	title:             "Bulk actions",
+	maxReportedErrors: 5,
+}
+
+type bulkAction int
+
+const (
+	bulkDelete bulkAction = iota
+	bulkAutoconnectOn
+	bulkAutoconnectOff
+	bulkPriority
+)
+
+func (a bulkAction) String() string {
+	switch a {
+	case bulkDelete:
+		return "Delete"
+	case bulkAutoconnectOn:
+		return "Enable autoconnect"
+	case bulkAutoconnectOff:
+		return "Disable autoconnect"
+	case bulkPriority:
+		return "Set priority"
+	default:
+		return "Undefined"
+	}
+}
+
+// pastTense describes finished work in the result report.
+func (a bulkAction) pastTense() string {
+	switch a {
+	case bulkDelete:
+		return "Deleted"
+	case bulkPriority:
+		return "Changed priority of"
+	default:
+		return "Changed autoconnect of"
+	}
+}
+
+type bulkActionsKeyMap struct {
+	prev  key.Binding
+	next  key.Binding
+	apply key.Binding
+}
+
+// bulkOption is a focusable line of the bulk actions menu.
+type bulkOption struct {
+	action  bulkAction
+	focused bool
+}
+
+func (o *bulkOption) Focused() bool  { return o.focused }
+func (o *bulkOption) Focus() tea.Cmd { o.focused = true; return nil }
+func (o *bulkOption) Blur()          { o.focused = false }
+
+// BulkActionsModel applies one action to every marked profile. The priority
+// option owns the input holding the new priority.
+type BulkActionsModel struct {
+	names []string
+
+	options  []*bulkOption
+	priority textinput.Model
+
+	focuses focus.Group
+
+	keys bulkActionsKeyMap
+
+	netMngr infra.NetworksManager
+	Style   lipgloss.Style
+}
+
+func NewBulkActionsModel(keys bulkActionsKeyMap, networksManager infra.NetworksManager) *BulkActionsModel {
+	model := &BulkActionsModel{
+		options: []*bulkOption{
+			{action: bulkDelete},
+			{action: bulkAutoconnectOn},
+			{action: bulkAutoconnectOff},
+		},
+		priority: newDefaultPriorityInput(),
+		keys:     keys,
+		netMngr:  networksManager,
+		Style:    lipgloss.NewStyle(),
+	}
+
+	inp := make([]focus.Focusable, 0, len(model.options)+1)
+	for _, o := range model.options {
+		inp = append(inp, o)
+	}
+	inp = append(inp, &model.priority)
+	model.focuses = *focus.NewGroup(inp)
+
+	return model
+}
+
+// restyle applies the current styles to the input.
+func (m *BulkActionsModel) restyle() {
+	restyleInputs(&m.priority)
+}
+
+func (m *BulkActionsModel) setTargets(names []string) tea.Cmd {
+	m.names = names
+	m.priority.Reset()
+	return m.focuses.SetFocusIdx(0)
+}
+
+func (m *BulkActionsModel) Init() tea.Cmd {
+	return m.focuses.FocusCurrent()
+}
+
+func (m *BulkActionsModel) Update(msg tea.Msg) (*BulkActionsModel, tea.Cmd) {
+	if msg, ok := msg.(tea.MouseClickMsg); ok {
+		cmd, _ := focusClicked(&m.focuses, bulkActionsZoneID, msg)
+		return m, cmd
+	}
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.next):
+			return m, m.focuses.FocusCycleNextCmd()
+		case key.Matches(msg, m.keys.prev):
+			return m, m.focuses.FocusCyclePrevCmd()
+		case key.Matches(msg, m.keys.apply):
+			return m, m.applyCmd()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.priority, cmd = m.priority.Update(msg)
+	return m, cmd
+}
+
+func (m *BulkActionsModel) UpdateAsPopup(msg tea.Msg) (PopupModel, tea.Cmd) {
+	return m.Update(msg)
+}
+
+func (m *BulkActionsModel) View() string {
+	lines := make([]string, 0, len(m.options)+3)
+	lines = append(lines, fmt.Sprintf("%d profiles: %s", len(m.names), m.targetsSummary()), "")
+	for i, o := range m.options {
+		option := m.optionView(o.action.String(), o.focused)
+		lines = append(lines, markField(bulkActionsZoneID, i, option))
+	}
+
+	priority := styles.ViewBorderedFocusable(&m.priority)
+	priority = lipgloss.JoinHorizontal(
+		lipgloss.Center,
+		m.optionView(bulkPriority.String(), m.priority.Focused())+" ",
+		priority,
+	)
+	lines = append(lines, markField(bulkActionsZoneID, len(m.options), priority))
+
+	view := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	view = m.Style.Render(view)
+	title := styles.DefaultStyle.Render(renderer.RenderTitle(bulkActionsCfg.title))
+	return compositor.Compose(
+		title,
+		view,
+		compositor.Center,
+		compositor.Begin,
+		0,
+		0,
+	)
+}
+
+func (*BulkActionsModel) optionView(label string, focused bool) string {
+	if focused {
+		return styles.AccentStyle.Render("> " + label)
+	}
+	return "  " + label
+}
+
+// targetsSummary lists the first target names, eliding the rest.
+func (m *BulkActionsModel) targetsSummary() string {
+	const shown = 3
+	if len(m.names) <= shown {
+		return strings.Join(m.names, ", ")
+	}
+	return strings.Join(m.names[:shown], ", ") + ", " + styles.SymbolEllipsis
+}
+
+func (m *BulkActionsModel) applyCmd() tea.Cmd {
+	action := bulkPriority
+	if idx := m.focuses.FocusIdx(); idx < len(m.options) {
+		action = m.options[idx].action
+	}
+
+	var priority int
+	if action == bulkPriority {
+		var err error
+		priority, err = strconv.Atoi(strings.TrimSpace(m.priority.Value()))
+		if err != nil {
+			return NotifyWarningCmd("Priority must be a number")
+		}
+	}
+
+	return tea.Sequence(
+		ClosePopupCmd(),
+		clearMarksCmd(),
+		runBulkCmd(m.netMngr, action, priority, m.names),
+	)
+}
+
+// bulkRun is the state of a bulk action running one profile at a time.
+type bulkRun struct {
+	netMngr  infra.NetworksManager
+	action   bulkAction
+	priority int
+	names    []string
+	errs     []error
+}
+
+// runBulkCmd applies the action to the named profiles sequentially, keeping
+// the networks progress indicator up to date, and notifies with a single
+// report at the end.
+func runBulkCmd(networksManager infra.NetworksManager, action bulkAction, priority int, names []string) tea.Cmd {
+	state := NetsUpdating
+	if action == bulkDelete {
+		state = NetsDeleting
+	}
+	run := &bulkRun{
+		netMngr:  networksManager,
+		action:   action,
+		priority: priority,
+		names:    names,
+	}
+	return tea.Sequence(
+		SetNetworksStateCmd(state),
+		SetNetworksProgressCmd(0, len(names)),
+		run.stepCmd(0),
+	)
+}
+
+func (r *bulkRun) stepCmd(i int) tea.Cmd {
+	return func() tea.Msg {
+		name := r.names[i]
+		if err := r.apply(context.Background(), name); err != nil {
+			r.errs = append(r.errs, fmt.Errorf("%q: %w", name, err))
+		}
+		if i+1 < len(r.names) {
+			return tea.Sequence(
+				SetNetworksProgressCmd(i+1, len(r.names)),
+				r.stepCmd(i+1),
+			)
+		}
+		notify := NotifyCmd
+		if len(r.errs) > 0 {
+			notify = NotifyWarningCmd
+		}
+		return tea.Batch(
+			SetNetworksStateCmd(NetsDone),
+			notify(r.report()),
+			RescanNetworksCmd(),
+		)
+	}
+}
+
+func (r *bulkRun) apply(ctx context.Context, name string) error {
+	if r.action == bulkDelete {
+		return r.netMngr.DeleteProfile(ctx, name)
+	}
+
+	var update infra.UpdateAutoconnect
+	switch r.action {
+	case bulkAutoconnectOn:
+		update.Autoconnect = new(true)
+	case bulkAutoconnectOff:
+		update.Autoconnect = new(false)
+	case bulkPriority:
+		update.AutoconnectPriority = new(r.priority)
+	}
+	return r.netMngr.UpdateAutoconnect(ctx, name, update)
+}
+
+func (r *bulkRun) report() string {
+	done := len(r.names) - len(r.errs)
+	text := fmt.Sprintf("%s %d of %d profiles", r.action.pastTense(), done, len(r.names))
+	if len(r.errs) == 0 {
+		return text
+	}
+
+	shown := r.errs[:min(len(r.errs), bulkActionsCfg.maxReportedErrors)]
+	text += fmt.Sprintf(", %d failed:\n%v", len(r.errs), errors.Join(shown...))
+	if hidden := len(r.errs) - len(shown); hidden > 0 {
+		text += fmt.Sprintf("\nand %d more", hidden)
+	}
+	return text
+}
+
+type clearMarksMsg struct{}
+
+func clearMarksCmd() tea.Cmd {
+	return func() tea.Msg {
+		return clearMarksMsg{}
+	}
+}
@@ -0,0 +1,199 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/infra"
+)
+
+// fakeBulkNetworks records profile changes. Methods that are not overridden
+// panic through the nil embedded interface.
+type fakeBulkNetworks struct {
+	infra.NetworksManager
+
+	profiles map[string]infra.NetworkProfile
+	deleted  []string
+	failOn   string
+}
+
+func (f *fakeBulkNetworks) DeleteProfile(_ context.Context, name string) error {
+	if name == f.failOn {
+		return errors.New("nmcli failed")
+	}
+	f.deleted = append(f.deleted, name)
+	return nil
+}
+
+func (f *fakeBulkNetworks) UpdateAutoconnect(_ context.Context, name string, update infra.UpdateAutoconnect) error {
+	if name == f.failOn {
+		return infra.ErrUpdateAutoconnect
+	}
+	p := f.profiles[name]
+	if update.Autoconnect != nil {
+		p.Autoconnect = *update.Autoconnect
+	}
+	if update.AutoconnectPriority != nil {
+		p.AutoconnectPriority = *update.AutoconnectPriority
+	}
+	f.profiles[name] = p
+	return nil
+}
+
+func runAllSteps(r *bulkRun) {
+	for i := range r.names {
+		r.stepCmd(i)()
+	}
+}
+
+func TestBulkRun(t *testing.T) {
+	t.Parallel()
+
+	newNetworks := func() *fakeBulkNetworks {
+		return &fakeBulkNetworks{
+			profiles: map[string]infra.NetworkProfile{
+				"cafe":    {Name: "cafe", SSID: "Cafe", Autoconnect: true, AutoconnectPriority: 1},
+				"airport": {Name: "airport", SSID: "Airport", Password: "pw123456", AutoconnectPriority: 2, KeyMgmt: "sae"},
+				"office":  {Name: "office", SSID: "Corp", AutoconnectPriority: 3, KeyMgmt: "wpa-eap", Hidden: true},
+			},
+			failOn: "broken",
+		}
+	}
+	names := []string{"cafe", "broken", "airport", "office"}
+
+	tests := []struct {
+		name       string
+		action     bulkAction
+		priority   int
+		wantReport string
+		check      func(t *testing.T, f *fakeBulkNetworks)
+	}{
+		{
+			name:       "delete",
+			action:     bulkDelete,
+			wantReport: "Deleted 3 of 4 profiles, 1 failed:",
+			check: func(t *testing.T, f *fakeBulkNetworks) {
+				t.Helper()
+				if want := []string{"cafe", "airport", "office"}; !reflect.DeepEqual(f.deleted, want) {
+					t.Errorf("deleted = %v, want %v", f.deleted, want)
+				}
+			},
+		},
+		{
+			name:       "autoconnect off keeps security",
+			action:     bulkAutoconnectOff,
+			wantReport: "Changed autoconnect of 3 of 4 profiles, 1 failed:",
+			check: func(t *testing.T, f *fakeBulkNetworks) {
+				t.Helper()
+				want := infra.NetworkProfile{Name: "airport", SSID: "Airport", Password: "pw123456", AutoconnectPriority: 2, KeyMgmt: "sae"}
+				if got := f.profiles["airport"]; got != want {
+					t.Errorf("airport = %+v, want %+v", got, want)
+				}
+				want = infra.NetworkProfile{Name: "office", SSID: "Corp", AutoconnectPriority: 3, KeyMgmt: "wpa-eap", Hidden: true}
+				if got := f.profiles["office"]; got != want {
+					t.Errorf("office = %+v, want %+v", got, want)
+				}
+				if f.profiles["cafe"].Autoconnect {
+					t.Error("cafe autoconnect still enabled")
+				}
+			},
+		},
+		{
+			name:       "priority",
+			action:     bulkPriority,
+			priority:   -5,
+			wantReport: "Changed priority of 3 of 4 profiles, 1 failed:",
+			check: func(t *testing.T, f *fakeBulkNetworks) {
+				t.Helper()
+				for _, name := range []string{"cafe", "airport", "office"} {
+					if got := f.profiles[name].AutoconnectPriority; got != -5 {
+						t.Errorf("%s priority = %d, want -5", name, got)
+					}
+				}
+				if got := f.profiles["airport"]; got.KeyMgmt != "sae" || got.Password != "pw123456" {
+					t.Errorf("airport security = %q/%q, want sae/pw123456", got.KeyMgmt, got.Password)
+				}
+				if !f.profiles["cafe"].Autoconnect {
+					t.Error("cafe autoconnect changed by a priority run")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			networks := newNetworks()
+			run := &bulkRun{netMngr: networks, action: tt.action, priority: tt.priority, names: names}
+			runAllSteps(run)
+
+			report := run.report()
+			if !strings.HasPrefix(report, tt.wantReport) || !strings.Contains(report, `"broken"`) {
+				t.Errorf("report() = %q, want prefix %q naming the failed profile", report, tt.wantReport)
+			}
+			tt.check(t, networks)
+		})
+	}
+}
+
+func TestBulkRunReportLimitsErrors(t *testing.T) {
+	t.Parallel()
+
+	run := &bulkRun{action: bulkDelete, names: make([]string, 8)}
+	for range 7 {
+		run.errs = append(run.errs, errors.New("failed"))
+	}
+
+	report := run.report()
+	if got, want := strings.Count(report, "\nfailed"), bulkActionsCfg.maxReportedErrors; got != want {
+		t.Errorf("report() lists %d failures, want %d", got, want)
+	}
+	if !strings.HasSuffix(report, "and 2 more") {
+		t.Errorf("report() = %q, want it to end with %q", report, "and 2 more")
+	}
+}
+
+func TestNetworkProfilesMarks(t *testing.T) {
+	t.Parallel()
+
+	m := NewNetworkProfilesModel(networkProfilesKeyMap{}, nil)
+	m.setProfiles([]NetworkProfileShort{
+		{Name: "Cafe Central", SSID: "central"},
+		{Name: "home", SSID: "HomeNet"},
+		{Name: "cafe-2", SSID: "Bean Cafe"},
+		{Name: "office", SSID: "corp"},
+	}, nil)
+
+	if got, want := m.bulkTargets(), []string{"Cafe Central"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bulkTargets() without marks = %v, want selected row %v", got, want)
+	}
+
+	m.markMatching("CAFE")
+	if got, want := m.bulkTargets(), []string{"Cafe Central", "cafe-2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bulkTargets() after markMatching = %v, want %v", got, want)
+	}
+
+	m.invertMarks()
+	if got, want := m.bulkTargets(), []string{"home", "office"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bulkTargets() after invertMarks = %v, want %v", got, want)
+	}
+
+	m.sort.cycle() // by name
+	m.sort.reverse()
+	m.updateRows()
+	if got, want := m.bulkTargets(), []string{"office", "home"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bulkTargets() sorted by name descending = %v, want table order %v", got, want)
+	}
+
+	m.toggleMark("home")
+	m.setProfiles([]NetworkProfileShort{{Name: "home"}, {Name: "cafe-2"}}, nil)
+	if len(m.marked) != 0 {
+		t.Errorf("marks of vanished profiles kept: %v", slices.Collect(maps.Keys(m.marked)))
+	}
+}
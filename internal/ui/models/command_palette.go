@@ -0,0 +1,516 @@
+package models
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/alphameo/nm-tui/internal/ui/styles"
+	"github.com/alphameo/nm-tui/internal/ui/tools/compositor"
+	"github.com/alphameo/nm-tui/internal/ui/tools/fuzzy"
+	"github.com/alphameo/nm-tui/internal/ui/tools/renderer"
+	"github.com/charmbracelet/x/ansi"
+)
+
+type commandPaletteConfig struct {
+	title   string
+	width   int
+	maxRows int
+}
+
+const commandPaletteZoneID = "command_palette"
+
+var commandPaletteCfg = commandPaletteConfig{
+	title:   "Commands",
+	width:   80,
+	maxRows: 12,
+}
+
+type commandPaletteKeyMap struct {
+	prev key.Binding
+	next key.Binding
+	run  key.Binding
+}
+
+// actionScope is the part of the interface an action belongs to.
+type actionScope int
+
+const (
+	scopeMain actionScope = iota
+	scopeNetworks
+	scopeAvailableNetworks
+	scopeNetworkProfiles
+	scopeDevice
+	scopeDiagnostics
+	scopeMonitor
+)
+
+func (s actionScope) String() string {
+	switch s {
+	case scopeMain:
+		return "Main"
+	case scopeNetworks:
+		return "Networks"
+	case scopeAvailableNetworks:
+		return "Available Networks"
+	case scopeNetworkProfiles:
+		return "Network Profiles"
+	case scopeDevice:
+		return "Device"
+	case scopeDiagnostics:
+		return "Diagnostics"
+	case scopeMonitor:
+		return "Monitor"
+	default:
+		return "Undefined"
+	}
+}
+
+// paletteCommand is an action offered by the command palette.
+type paletteCommand struct {
+	name  string
+	desc  string
+	scope actionScope
+	// binding is the key running the action outside the palette.
+	binding key.Binding
+
+	// param names the argument of the command, empty if it takes none.
+	param string
+	// complete lists the values offered for the argument.
+	complete func() []string
+	run      func(arg string) tea.Cmd
+}
+
+func (c *paletteCommand) label() string {
+	if c.param == "" {
+		return c.name
+	}
+	return fmt.Sprintf("%s <%s>", c.name, c.param)
+}
+
+// paletteRow is a command, or a command with its argument, matching the
+// query.
+type paletteRow struct {
+	command *paletteCommand
+	arg     string
+	// positions holds the matched runes of the label.
+	positions []int
+	// byName tells matches of the label from matches of the description.
+	byName bool
+	score  int
+}
+
+func (r *paletteRow) label() string {
+	if r.arg != "" {
+		return r.command.name + " " + r.arg
+	}
+	return r.command.label()
+}
+
+// CommandPaletteModel searches the actions of every tab by name and
+// description and runs the chosen one. Commands with a parameter complete
+// their argument from the current networks and profiles.
+type CommandPaletteModel struct {
+	input    textinput.Model
+	commands []paletteCommand
+	rows     []paletteRow
+	cursor   int
+	// offset is the first row shown.
+	offset int
+	clicks clickTracker
+
+	keys commandPaletteKeyMap
+
+	Style lipgloss.Style
+}
+
+func NewCommandPaletteModel(keys commandPaletteKeyMap) *CommandPaletteModel {
+	return &CommandPaletteModel{
+		input: newDefaultCommandInput(),
+		keys:  keys,
+		Style: lipgloss.NewStyle(),
+	}
+}
+
+// restyle applies the current styles to the input.
+func (m *CommandPaletteModel) restyle() {
+	restyleInputs(&m.input)
+}
+
+// setCommands empties the query and offers commands.
+func (m *CommandPaletteModel) setCommands(commands []paletteCommand) tea.Cmd {
+	m.commands = commands
+	m.input.Reset()
+	m.matchRows()
+	return m.input.Focus()
+}
+
+func (m *CommandPaletteModel) Init() tea.Cmd {
+	return m.input.Focus()
+}
+
+func (m *CommandPaletteModel) Update(msg tea.Msg) (*CommandPaletteModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.MouseClickMsg:
+		return m, m.click(msg)
+	case tea.MouseWheelMsg:
+		switch msg.Button {
+		case tea.MouseWheelUp:
+			m.moveCursor(-1)
+		case tea.MouseWheelDown:
+			m.moveCursor(1)
+		}
+		return m, nil
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, m.keys.prev):
+			m.moveCursor(-1)
+			return m, nil
+		case key.Matches(msg, m.keys.next):
+			m.moveCursor(1)
+			return m, nil
+		case key.Matches(msg, m.keys.run):
+			return m, m.runSelected()
+		}
+	}
+
+	query := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != query {
+		m.matchRows()
+	}
+	return m, cmd
+}
+
+func (m *CommandPaletteModel) UpdateAsPopup(msg tea.Msg) (PopupModel, tea.Cmd) {
+	return m.Update(msg)
+}
+
+// runSelected runs the selected row. A command missing its argument is
+// completed into the query instead, so its values are listed.
+func (m *CommandPaletteModel) runSelected() tea.Cmd {
+	command, arg, ok := m.argumentMode()
+	if ok {
+		if len(m.rows) > 0 {
+			arg = m.rows[m.cursor].arg
+		}
+		if arg == "" {
+			return nil
+		}
+		return tea.Sequence(ClosePopupCmd(), command.run(arg))
+	}
+
+	if len(m.rows) == 0 {
+		return nil
+	}
+	command = m.rows[m.cursor].command
+	if command.param != "" {
+		m.input.SetValue(command.name + " ")
+		m.input.CursorEnd()
+		m.matchRows()
+		return nil
+	}
+	return tea.Sequence(ClosePopupCmd(), command.run(""))
+}
+
+// argumentMode reports the command whose name, followed by a space, starts
+// the query, and the argument typed after it.
+func (m *CommandPaletteModel) argumentMode() (*paletteCommand, string, bool) {
+	query := strings.ToLower(m.input.Value())
+	var found *paletteCommand
+	for i := range m.commands {
+		c := &m.commands[i]
+		if c.param == "" || !strings.HasPrefix(query, c.name+" ") {
+			continue
+		}
+		if found == nil || len(c.name) > len(found.name) {
+			found = c
+		}
+	}
+	if found == nil {
+		return nil, "", false
+	}
+	arg := strings.TrimLeft(m.input.Value()[len(found.name)+1:], " ")
+	return found, arg, true
+}
+
+// matchRows lists the commands matching the query, or the argument values
+// once a command is chosen, best matches first.
+func (m *CommandPaletteModel) matchRows() {
+	var rows []paletteRow
+	if command, arg, ok := m.argumentMode(); ok {
+		offset := len([]rune(command.name)) + 1
+		for _, value := range command.complete() {
+			positions, score, ok := fuzzy.Match(arg, value)
+			if !ok {
+				continue
+			}
+			for i := range positions {
+				positions[i] += offset
+			}
+			rows = append(rows, paletteRow{
+				command:   command,
+				arg:       value,
+				positions: positions,
+				byName:    true,
+				score:     score,
+			})
+		}
+	} else {
+		query := strings.TrimSpace(m.input.Value())
+		for i := range m.commands {
+			c := &m.commands[i]
+			if positions, score, ok := fuzzy.Match(query, c.label()); ok {
+				rows = append(rows, paletteRow{command: c, positions: positions, byName: true, score: score})
+			} else if _, score, ok := fuzzy.Match(query, c.desc); ok {
+				rows = append(rows, paletteRow{command: c, score: score})
+			}
+		}
+	}
+	slices.SortStableFunc(rows, func(a, b paletteRow) int {
+		if a.byName != b.byName {
+			if a.byName {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(b.score, a.score)
+	})
+
+	m.rows = rows
+	m.cursor = 0
+	m.offset = 0
+}
+
+func (m *CommandPaletteModel) moveCursor(delta int) {
+	if len(m.rows) == 0 {
+		return
+	}
+	m.cursor = max(min(m.cursor+delta, len(m.rows)-1), 0)
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+commandPaletteCfg.maxRows {
+		m.offset = m.cursor - commandPaletteCfg.maxRows + 1
+	}
+}
+
+// click selects the clicked row and runs it on a double click.
+func (m *CommandPaletteModel) click(msg tea.MouseClickMsg) tea.Cmd {
+	if msg.Button != tea.MouseLeft {
+		return nil
+	}
+	for i := m.offset; i < min(m.offset+commandPaletteCfg.maxRows, len(m.rows)); i++ {
+		if !zones.Contains(itemZoneID(commandPaletteZoneID+".row", i), msg.X, msg.Y) {
+			continue
+		}
+		m.cursor = i
+		if m.clicks.click(m.rows[i].label(), time.Now()) {
+			return m.runSelected()
+		}
+		return nil
+	}
+	return nil
+}
+
+func (m *CommandPaletteModel) View() string {
+	input := styles.ViewBorderedFocusable(&m.input)
+	lines := []string{input}
+
+	end := min(m.offset+commandPaletteCfg.maxRows, len(m.rows))
+	visible := m.rows[m.offset:end]
+	var labelWidth, keyWidth, scopeWidth int
+	for _, r := range visible {
+		labelWidth = max(labelWidth, ansi.StringWidth(r.label()))
+		keyWidth = max(keyWidth, ansi.StringWidth(r.command.binding.Help().Key))
+		scopeWidth = max(scopeWidth, ansi.StringWidth(r.command.scope.String()))
+	}
+	for i, r := range visible {
+		idx := m.offset + i
+		selected := idx == m.cursor
+		base := styles.DefaultStyle
+		cursor := "  "
+		if selected {
+			base = styles.BoldStyle
+			cursor = styles.AccentStyle.Render("> ")
+		}
+		label := highlightMatches(r.label(), r.positions, base)
+		label += strings.Repeat(" ", labelWidth-ansi.StringWidth(r.label()))
+		line := cursor + label + "  " +
+			styles.MutedStyle.Width(keyWidth).Render(r.command.binding.Help().Key) + "  " +
+			styles.MutedStyle.Width(scopeWidth).Render(r.command.scope.String()) + "  " +
+			r.command.desc
+		line = ansi.Truncate(line, commandPaletteCfg.width, styles.SymbolEllipsis)
+		lines = append(lines, zones.Mark(itemZoneID(commandPaletteZoneID+".row", idx), line))
+	}
+	if len(m.rows) == 0 {
+		lines = append(lines, styles.MutedStyle.Render("  No matching commands"))
+	}
+
+	view := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	view = m.Style.Width(commandPaletteCfg.width + m.Style.GetHorizontalFrameSize()).Render(view)
+	title := styles.DefaultStyle.Render(renderer.RenderTitle(commandPaletteCfg.title))
+	return compositor.Compose(
+		title,
+		view,
+		compositor.Center,
+		compositor.Begin,
+		0,
+		0,
+	)
+}
+
+// paletteActionMsg runs the action bound to press in scope.
+type paletteActionMsg struct {
+	scope actionScope
+	press tea.KeyPressMsg
+}
+
+// pressCmd runs the action called name by handing its key to the model, once
+// scope is in front.
+func pressCmd(name string, scope actionScope, binding key.Binding) func(string) tea.Cmd {
+	return func(string) tea.Cmd {
+		keys := binding.Keys()
+		if len(keys) == 0 {
+			return NotifyWarningCmd(fmt.Sprintf("%q is not bound to a key", name))
+		}
+		return func() tea.Msg {
+			return paletteActionMsg{scope: scope, press: tea.KeyPressMsg{Text: keys[0]}}
+		}
+	}
+}
+
+// showScope brings the tab and table of scope to the front.
+func (m *MainModel) showScope(scope actionScope) tea.Cmd {
+	switch scope {
+	case scopeNetworks:
+		return m.tabs.SetActiveTab(0)
+	case scopeAvailableNetworks:
+		return tea.Batch(m.tabs.SetActiveTab(0), m.networks.focuses.SetFocusIdx(0))
+	case scopeNetworkProfiles:
+		return tea.Batch(m.tabs.SetActiveTab(0), m.networks.focuses.SetFocusIdx(1))
+	case scopeDevice:
+		return m.tabs.SetActiveTab(1)
+	case scopeDiagnostics:
+		return m.tabs.SetActiveTab(2)
+	case scopeMonitor:
+		return m.tabs.SetActiveTab(3)
+	default:
+		return nil
+	}
+}
+
+// availableSSIDs lists the SSIDs of the available networks to complete
+// arguments with.
+func (m *MainModel) availableSSIDs() []string {
+	ssids := make([]string, 0, len(m.networks.available.networks))
+	for _, n := range m.networks.available.networks {
+		if n.SSID != "" {
+			ssids = append(ssids, n.SSID)
+		}
+	}
+	slices.SortFunc(ssids, compareFold)
+	return slices.Compact(ssids)
+}
+
+func (m *MainModel) profileNames() []string {
+	names := make([]string, 0, len(m.networks.profiles.profiles))
+	for _, p := range m.networks.profiles.profiles {
+		names = append(names, p.Name)
+	}
+	slices.SortFunc(names, compareFold)
+	return names
+}
+
+// paletteCommands lists every action that can run from a tab.
+func (m *MainModel) paletteCommands() []paletteCommand {
+	k := m.help.keyMap
+	available := m.networks.available
+	profiles := m.networks.profiles
+
+	simple := func(name string, scope actionScope, binding key.Binding) paletteCommand {
+		return paletteCommand{
+			name:    name,
+			desc:    m.help.fullDesc(scope, binding),
+			scope:   scope,
+			binding: binding,
+			run:     pressCmd(name, scope, binding),
+		}
+	}
+	withNetwork := func(name string, binding key.Binding, run func(string) tea.Cmd) paletteCommand {
+		return paletteCommand{
+			name:     name,
+			desc:     m.help.fullDesc(scopeAvailableNetworks, binding),
+			scope:    scopeAvailableNetworks,
+			binding:  binding,
+			param:    "ssid",
+			complete: m.availableSSIDs,
+			run:      run,
+		}
+	}
+	withProfile := func(name string, binding key.Binding, run func(string) tea.Cmd) paletteCommand {
+		return paletteCommand{
+			name:     name,
+			desc:     m.help.fullDesc(scopeNetworkProfiles, binding),
+			scope:    scopeNetworkProfiles,
+			binding:  binding,
+			param:    "name",
+			complete: m.profileNames,
+			run:      run,
+		}
+	}
+
+	return []paletteCommand{
+		simple("quit", scopeMain, k.main.quit),
+		simple("help", scopeMain, k.main.help),
+		simple("next tab", scopeMain, k.tabs.Next),
+		simple("previous tab", scopeMain, k.tabs.Prev),
+
+		simple("rescan networks", scopeNetworks, k.networks.rescan),
+		simple("create profile", scopeNetworks, k.networks.createProfile),
+		simple("create hotspot", scopeNetworks, k.networks.createHotspot),
+		simple("quick hotspot", scopeNetworks, k.networks.quickHotspot),
+		simple("open network login", scopeNetworks, k.networks.openCaptivePortal),
+		simple("share hotspot", scopeNetworks, k.networks.shareHotspot),
+		simple("export profiles", scopeNetworks, k.networks.exportProfiles),
+		simple("import profiles", scopeNetworks, k.networks.importProfiles),
+
+		withNetwork("connect", k.availableNetworks.connect, OpenConnectorCmd),
+		withNetwork("activate network", k.availableNetworks.activate, available.activateConnCmd),
+		withNetwork("deactivate network", k.availableNetworks.deactivate, available.deactivateConnCmd),
+		withNetwork("network details", k.availableNetworks.details, available.detailsCmd),
+		simple("filter networks", scopeAvailableNetworks, k.availableNetworks.filter.open),
+		simple("sort networks", scopeAvailableNetworks, k.availableNetworks.sort.cycle),
+		simple("reverse network sort", scopeAvailableNetworks, k.availableNetworks.sort.reverse),
+
+		withProfile("edit profile", k.networkProfiles.edit, OpenProfileEditorCmd),
+		withProfile("activate profile", k.networkProfiles.activate, profiles.activateConnCmd),
+		withProfile("deactivate profile", k.networkProfiles.deactivate, profiles.deactivateConnCmd),
+		withProfile("delete profile", k.networkProfiles.delete, profiles.deleteCmd),
+		withProfile("share profile", k.networkProfiles.share, OpenWifiShareCmd),
+		simple("mark profile", scopeNetworkProfiles, k.networkProfiles.mark),
+		simple("mark matching", scopeNetworkProfiles, k.networkProfiles.markMatching),
+		simple("invert marks", scopeNetworkProfiles, k.networkProfiles.invertMarks),
+		simple("clear marks", scopeNetworkProfiles, k.networkProfiles.clearMarks),
+		simple("bulk actions", scopeNetworkProfiles, k.networkProfiles.bulkActions),
+		simple("filter profiles", scopeNetworkProfiles, k.networkProfiles.filter.open),
+		simple("sort profiles", scopeNetworkProfiles, k.networkProfiles.sort.cycle),
+		simple("reverse profile sort", scopeNetworkProfiles, k.networkProfiles.sort.reverse),
+
+		simple("rescan device", scopeDevice, k.device.rescan),
+		simple("toggle control", scopeDevice, k.toggle.Toggle),
+
+		simple("run diagnostics", scopeDiagnostics, k.diagnostics.rescan),
+		simple("rerun diagnostic step", scopeDiagnostics, k.diagnostics.rerunStep),
+		simple("copy diagnostics report", scopeDiagnostics, k.diagnostics.copyReport),
+		simple("next monitored device", scopeMonitor, k.monitor.next),
+		simple("previous monitored device", scopeMonitor, k.monitor.prev),
+		simple("clear monitor graphs", scopeMonitor, k.monitor.clear),
+	}
+}
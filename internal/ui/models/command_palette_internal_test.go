@@ -0,0 +1,117 @@
+package models
+
+import (
+	"slices"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/alphameo/nm-tui/internal/config"
+)
+
+func testPaletteCommands(ran *[]string) []paletteCommand {
+	run := func(name string) func(string) tea.Cmd {
+		return func(arg string) tea.Cmd {
+			*ran = append(*ran, name+"("+arg+")")
+			return nil
+		}
+	}
+	profiles := func() []string { return []string{"Cafe", "Home", "Home 5G"} }
+	return []paletteCommand{
+		{name: "rescan networks", desc: "Rescan networks", run: run("rescan")},
+		{name: "delete profile", desc: "Delete network profile", param: "name", complete: profiles, run: run("delete")},
+		{name: "connect", desc: "Open Connector for a network", param: "ssid", complete: profiles, run: run("connect")},
+		{name: "quit", desc: "Exit the application", run: run("quit")},
+	}
+}
+
+func paletteLabels(m *CommandPaletteModel) []string {
+	labels := make([]string, len(m.rows))
+	for i := range m.rows {
+		labels[i] = m.rows[i].label()
+	}
+	return labels
+}
+
+func TestCommandPaletteMatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"rescan networks", "delete profile <name>", "connect <ssid>", "quit"}},
+		{"dp", []string{"delete profile <name>"}},
+		{"exit", []string{"quit"}},
+		{"delete profile ", []string{"delete profile Cafe", "delete profile Home", "delete profile Home 5G"}},
+		{"delete profile hm5", []string{"delete profile Home 5G"}},
+		{"Connect ca", []string{"connect Cafe"}},
+		{"nothing", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			t.Parallel()
+
+			var ran []string
+			m := NewCommandPaletteModel(commandPaletteKeyMap{})
+			m.setCommands(testPaletteCommands(&ran))
+			m.input.SetValue(tt.query)
+			m.matchRows()
+			if got := paletteLabels(m); !slices.Equal(got, tt.want) {
+				t.Errorf("rows = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandPaletteRun(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		query     string
+		moves     int
+		wantQuery string
+		wantRan   []string
+	}{
+		{name: "command", query: "resc", wantQuery: "resc", wantRan: []string{"rescan()"}},
+		{name: "completes parameter", query: "del", wantQuery: "delete profile "},
+		{name: "selected value", query: "delete profile ", moves: 1, wantQuery: "delete profile ", wantRan: []string{"delete(Home)"}},
+		{name: "typed value", query: "connect Hidden", wantQuery: "connect Hidden", wantRan: []string{"connect(Hidden)"}},
+		{name: "no value", query: "connect ", wantQuery: "connect ", wantRan: []string{"connect(Cafe)"}},
+		{name: "no match", query: "nothing", wantQuery: "nothing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var ran []string
+			m := NewCommandPaletteModel(commandPaletteKeyMap{})
+			m.setCommands(testPaletteCommands(&ran))
+			m.input.SetValue(tt.query)
+			m.matchRows()
+			m.moveCursor(tt.moves)
+			m.runSelected()
+
+			if got := m.input.Value(); got != tt.wantQuery {
+				t.Errorf("query = %q, want %q", got, tt.wantQuery)
+			}
+			if !slices.Equal(ran, tt.wantRan) {
+				t.Errorf("ran %q, want %q", ran, tt.wantRan)
+			}
+		})
+	}
+}
+
+func TestPaletteCommandsDescribedByHelp(t *testing.T) {
+	t.Parallel()
+
+	m := &MainModel{
+		help:     NewHelpModel(initKeys(*config.DefaultKeys())),
+		networks: &NetworksModel{},
+	}
+	for _, c := range m.paletteCommands() {
+		if c.desc == c.binding.Help().Desc {
+			t.Errorf("%q has the short description %q, want the one Help lists", c.name, c.desc)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/alphameo/nm-tui/internal/config"
+	"github.com/alphameo/nm-tui/internal/ui/styles"
+)
+
+type configChangedMsg struct{}
+
+// WatchConfig makes the model reload the config whenever changes receives.
+// It must be called before the program starts.
+func (m *MainModel) WatchConfig(changes <-chan struct{}) {
+	m.configChanges = changes
+}
+
+func waitConfigChangeCmd(changes <-chan struct{}) tea.Cmd {
+	if changes == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		if _, ok := <-changes; !ok {
+			return nil
+		}
+		return configChangedMsg{}
+	}
+}
+
+// OverrideConfig keeps the settings of overrides, given on the command line,
+// over the ones in the config file when it is reloaded. It must be called
+// before the program starts.
+func (m *MainModel) OverrideConfig(overrides *config.Config) {
+	m.overrides = overrides
+}
+
+// reloadConfig reads the config file again and applies it in place, so the
+// state of every model survives. A config that can't be read is not applied,
+// while merge errors leave the affected settings at their defaults, just like
+// on startup.
+func (m *MainModel) reloadConfig() tea.Cmd {
+	cfg := config.DefaultConfig()
+	userCfg, err := config.Load()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return NotifyErrorCmd(fmt.Sprintf("Config not reloaded: %s", err))
+	}
+
+	var errs []error
+	if userCfg != nil {
+		errs = cfg.Merge(userCfg)
+	}
+	if m.overrides != nil {
+		errs = append(errs, cfg.Merge(m.overrides)...)
+	}
+
+	if err = styles.Init(cfg); err != nil {
+		return NotifyErrorCmd(fmt.Sprintf("Config not reloaded: %s", err))
+	}
+	applyMainConfig(cfg)
+	m.notification.closeTime = mainCfg.notificationCloseTime
+	m.setKeys(initKeys(*cfg.Keys))
+	m.applyStyles()
+	if m.ready {
+		m.Resize(m.Width(), m.Height())
+	}
+
+	for _, c := range m.help.keyMap.conflicts {
+		errs = append(errs, c)
+	}
+	if len(errs) > 0 {
+		return NotifyWarningCmd(fmt.Sprintf("Config reloaded with errors:\n%s", errors.Join(errs...)))
+	}
+	return NotifyCmd("Config reloaded")
+}
+
+// keyConflictsCmd warns about keys shadowing one another, as the bindings
+// losing out would otherwise just seem broken.
+func keyConflictsCmd(conflicts []config.KeyConflict) tea.Cmd {
+	if len(conflicts) == 0 {
+		return nil
+	}
+	errs := make([]error, len(conflicts))
+	for i, c := range conflicts {
+		errs[i] = c
+	}
+	return NotifyWarningCmd(fmt.Sprintf("Conflicting keys, see help:\n%s", errors.Join(errs...)))
+}
+
+// setKeys hands new bindings to every model.
+func (m *MainModel) setKeys(keys keyMaps) {
+	m.keys = &keys.main
+	m.tabs.Keys = keys.tabs
+
+	m.networks.keys = keys.networks
+	m.networks.available.keys = keys.availableNetworks
+	m.networks.available.filter.keys = keys.availableNetworks.filter
+	m.networks.profiles.keys = keys.networkProfiles
+	m.networks.profiles.filter.keys = keys.networkProfiles.filter
+	m.device.keys = keys.device
+	m.diagnostics.keys = keys.diagnostics
+	m.monitor.keys = keys.monitor
+
+	m.connector.keys = keys.connector
+	m.profileCreator.keys = keys.profileCreator
+	m.hotspotCreator.keys = keys.hotspotCreator
+	m.profileEditor.keys = keys.profileEditor
+	m.backupExport.keys = keys.backupExport
+	m.backupImport.keys = keys.backupImport
+	m.restorePreview.keys = keys.restorePreview
+	m.markMatching.keys = keys.markMatching
+	m.bulkActions.keys = keys.bulkActions
+	m.commandPalette.keys = keys.commandPalette
+
+	m.help.keyMap = keys
+	m.help.keys = keys.help
+}
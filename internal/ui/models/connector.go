@@ -21,6 +21,8 @@ type connectorConfig struct {
 	pwIdx   int
 }
 
+const connectorZoneID = "connector"
+
 var connectorCfg = connectorConfig{
 	title:   "Connect to Network",
 	nameIdx: 0,
@@ -67,6 +69,11 @@ func NewConnectorModel(keys connectorKeyMap, networksManager infra.NetworksManag
 	return model
 }
 
+// restyle applies the current styles to the inputs.
+func (m *ConnectorModel) restyle() {
+	restyleInputs(&m.name, &m.password)
+}
+
 func (m *ConnectorModel) setNewNetworkCmd(ssid string) tea.Cmd {
 	m.ssid = ssid
 
@@ -87,6 +94,10 @@ func (m *ConnectorModel) Init() tea.Cmd {
 }
 
 func (m *ConnectorModel) Update(msg tea.Msg) (*ConnectorModel, tea.Cmd) {
+	if msg, ok := msg.(tea.MouseClickMsg); ok {
+		cmd, _ := focusClicked(&m.focuses, connectorZoneID, msg)
+		return m, cmd
+	}
 	if msg, ok := msg.(tea.KeyPressMsg); ok {
 		switch {
 		case key.Matches(msg, m.keys.next):
@@ -133,9 +144,11 @@ func (m *ConnectorModel) View() string {
 
 	name := styles.ViewBorderedFocusable(&m.name)
 	name = lipgloss.JoinHorizontal(lipgloss.Center, "Name     ", name)
+	name = markField(connectorZoneID, connectorCfg.nameIdx, name)
 
 	password := styles.ViewInputWithValidation(&m.password)
 	password = lipgloss.JoinHorizontal(lipgloss.Center, "Password ", password)
+	password = markField(connectorZoneID, connectorCfg.pwIdx, password)
 
 	fields := []string{
 		ssid,
@@ -172,7 +185,7 @@ func (m *ConnectorModel) connectToNetworkCmd() tea.Cmd {
 			if err != nil {
 				return tea.Batch(
 					SetAvailableNetworksStateCmd(NetsDone),
-					NotifyCmd(fmt.Sprintf(
+					NotifyErrorCmd(fmt.Sprintf(
 						"Cannot connect to %s via given password:\n%v",
 						m.ssid, err,
 					)),
@@ -182,6 +195,7 @@ func (m *ConnectorModel) connectToNetworkCmd() tea.Cmd {
 			return tea.Batch(
 				SetAvailableNetworksStateCmd(NetsDone),
 				RescanNetworksCmd(),
+				networkActivatedCmd(m.ssid),
 			)
 		},
 	)
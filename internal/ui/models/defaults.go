@@ -40,6 +40,57 @@ func newDefaultPasswordInput() textinput.Model {
 	return pw
 }
 
+func newDefaultImportInput() textinput.Model {
+	source := newDefaultInput()
+	source.Placeholder = "WIFI: URI or QR .png"
+	return source
+}
+
+func newDefaultPathInput() textinput.Model {
+	path := newDefaultInput()
+	path.Placeholder = "File"
+	path.SetWidth(30)
+	return path
+}
+
+func newDefaultPassphraseInput() textinput.Model {
+	pp := newDefaultInput()
+	pp.EchoMode = textinput.EchoPassword
+	pp.EchoCharacter = styles.SymbolPwHiddenChar
+	pp.Placeholder = "Passphrase (optional)"
+	pp.SetWidth(30)
+	return pp
+}
+
+func newDefaultPatternInput() textinput.Model {
+	pattern := newDefaultInput()
+	pattern.Placeholder = "Pattern"
+	return pattern
+}
+
+func newDefaultFilterInput() textinput.Model {
+	filter := newDefaultInput()
+	filter.Prompt = "/"
+	filter.Placeholder = "Filter"
+	return filter
+}
+
+func newDefaultCommandInput() textinput.Model {
+	command := newDefaultInput()
+	command.Prompt = ":"
+	command.Placeholder = "Command or description"
+	command.SetWidth(40)
+	return command
+}
+
+func newDefaultPriorityInput() textinput.Model {
+	priority := newDefaultInput()
+	priority.SetWidth(4)
+	priority.Placeholder = "0"
+	priority.Validate = autoconnectPriorityValidator
+	return priority
+}
+
 func newDefaultToggle() toggle.Model {
 	t := toggle.New()
 	t.Styles = styles.ToggleStyles
@@ -54,6 +105,26 @@ func newDefaultSpinner() spinner.Model {
 	return s
 }
 
+// restyleInputs applies the current styles to inputs built by newDefaultInput.
+func restyleInputs(inputs ...*textinput.Model) {
+	for _, input := range inputs {
+		input.SetStyles(styles.InputStyles)
+		input.EchoCharacter = styles.SymbolPwHiddenChar
+	}
+}
+
+func restyleToggles(toggles ...*toggle.Model) {
+	for _, t := range toggles {
+		t.Styles = styles.ToggleStyles
+		t.Symbols = styles.SymbolsToggle
+	}
+}
+
+func restyleSpinner(s *spinner.Model) {
+	s.Style = styles.DefaultStyle
+	s.Spinner = styles.Spinner
+}
+
 var ErrPasswordFmt = errors.New("wrong password format")
 
 func passwordValidator(input string) error {
@@ -34,6 +34,11 @@ type deviceConfig struct {
 	stateWidthProportion  float32
 }
 
+const (
+	deviceTableZoneID    = "device.table"
+	deviceControlsZoneID = "device.controls"
+)
+
 var deviceCfg = deviceConfig{
 	controlsStyle: lipgloss.NewStyle().Margin(1, 0),
 
@@ -172,6 +177,13 @@ func NewDeviceModel(keys deviceKeyMap, deviceManager infra.DeviceManager) *Devic
 	return model
 }
 
+// restyle applies the current styles to the table, toggles and indicator.
+func (m *DeviceModel) restyle() {
+	m.devicesTable.SetStyles(styles.DataTableStyles)
+	restyleToggles(&m.wwan, &m.wifi, &m.networking)
+	restyleSpinner(&m.indicatorSpinner)
+}
+
 func (m *DeviceModel) Resize(width, height int) {
 	m.Style = m.Style.Width(width).Height(height)
 
@@ -241,16 +253,17 @@ func (m *DeviceModel) Update(msg tea.Msg) (*DeviceModel, tea.Cmd) {
 			return m, m.RescanCmd()
 		// NOTE: It is supposed that all togglers has the same bindings
 		case key.Matches(msg, m.wwan.Keys.Toggle):
-			if m.wwan.Focused() {
-				return m, m.toggleWWAN()
-			}
-			if m.wifi.Focused() {
-				return m, m.toggleWIFI()
-			}
-			if m.networking.Focused() {
-				return m, m.toggleNetworking()
-			}
+			return m, m.toggleFocused()
+		}
+	case tea.MouseClickMsg:
+		focusCmd, ok := focusClicked(&m.focuses, deviceControlsZoneID, msg)
+		if !ok {
+			return m, nil
 		}
+		return m, tea.Batch(focusCmd, m.toggleFocused())
+	case tea.MouseWheelMsg:
+		clickTableRow(&m.devicesTable, deviceTableZoneID, msg)
+		return m, nil
 	}
 
 	var cmd tea.Cmd
@@ -282,6 +295,7 @@ func (m *DeviceModel) UpdateAsTab(msg tea.Msg) (tabview.TabModel, tea.Cmd) {
 
 func (m *DeviceModel) View() string {
 	table := m.TableStyle.Render(m.devicesTable.View())
+	table = zones.Mark(deviceTableZoneID, table)
 
 	controls := m.controlsView()
 	statusline := m.indicatorView()
@@ -312,12 +326,15 @@ func (m *DeviceModel) indicatorView() string {
 func (m *DeviceModel) controlsView() string {
 	wwan := m.wwan.View()
 	wwan = lipgloss.JoinHorizontal(lipgloss.Center, "WWAN       ", wwan)
+	wwan = markField(deviceControlsZoneID, 0, wwan)
 
 	wifi := m.wifi.View()
 	wifi = lipgloss.JoinHorizontal(lipgloss.Center, "Wi-Fi      ", wifi)
+	wifi = markField(deviceControlsZoneID, 1, wifi)
 
 	networking := m.networking.View()
 	networking = lipgloss.JoinHorizontal(lipgloss.Center, "Networking ", networking)
+	networking = markField(deviceControlsZoneID, 2, networking)
 
 	connectivity := styles.BoldStyle.Render(m.connectivity)
 	connectivity = fmt.Sprintf("Connectivity %s", connectivity)
@@ -340,7 +357,7 @@ func (m *DeviceModel) RescanCmd() tea.Cmd {
 		func() tea.Msg {
 			list, err := m.connMngr.ListNetworkDevices(context.Background())
 			if err != nil {
-				return NotifyCmd("Cannot get network devices")
+				return NotifyErrorCmd("Cannot get network devices")
 			}
 
 			rows := []table.Row{}
@@ -358,20 +375,20 @@ func (m *DeviceModel) RescanCmd() tea.Cmd {
 
 			radioStatus, err := m.connMngr.GetRadioStatus(context.Background())
 			if err != nil {
-				return NotifyCmd("Cannot get radio status")
+				return NotifyErrorCmd("Cannot get radio status")
 			}
 			m.wwan.SetValue(radioStatus.EnabledWWAN)
 			m.wifi.SetValue(radioStatus.EnabledWifi)
 
 			networkingStatus, err := m.connMngr.IsNetworkingEnabled(context.Background())
 			if err != nil {
-				return NotifyCmd("Cannot get networking status")
+				return NotifyErrorCmd("Cannot get networking status")
 			}
 			m.networking.SetValue(networkingStatus)
 
 			conStatus, err := m.connMngr.GetConnectivityStatus(context.Background())
 			if err != nil {
-				return NotifyCmd("Cannot get connection status")
+				return NotifyErrorCmd("Cannot get connection status")
 			}
 			m.connectivity = conStatus.String()
 
@@ -392,6 +409,18 @@ func (m *DeviceModel) setStateCmd(state deviceState) tea.Cmd {
 	return tea.Sequence(updCmd, m.indicatorSpinner.Tick)
 }
 
+func (m *DeviceModel) toggleFocused() tea.Cmd {
+	switch {
+	case m.wwan.Focused():
+		return m.toggleWWAN()
+	case m.wifi.Focused():
+		return m.toggleWIFI()
+	case m.networking.Focused():
+		return m.toggleNetworking()
+	}
+	return nil
+}
+
 func (m *DeviceModel) toggleWWAN() tea.Cmd {
 	if m.indicatorState != DeviceDone {
 		return nil
@@ -406,7 +435,7 @@ func (m *DeviceModel) toggleWWAN() tea.Cmd {
 				err = m.connMngr.EnableWWAN(context.Background())
 			}
 			if err != nil {
-				return NotifyCmd("Failed toggling WWAN")
+				return NotifyErrorCmd("Failed toggling WWAN")
 			}
 
 			return m.RescanCmd()
@@ -428,7 +457,7 @@ func (m *DeviceModel) toggleWIFI() tea.Cmd {
 				err = m.connMngr.EnableWifi(context.Background())
 			}
 			if err != nil {
-				return NotifyCmd("Failed toggling Wi-Fi")
+				return NotifyErrorCmd("Failed toggling Wi-Fi")
 			}
 
 			return m.RescanCmd()
@@ -450,7 +479,7 @@ func (m *DeviceModel) toggleNetworking() tea.Cmd {
 				err = m.connMngr.EnableNetworking(context.Background())
 			}
 			if err != nil {
-				return NotifyCmd("Failed toggling networking")
+				return NotifyErrorCmd("Failed toggling networking")
 			}
 
 			return m.RescanCmd()
@@ -0,0 +1,691 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/spinner"
+	"charm.land/bubbles/v2/table"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/alphameo/nm-tui/internal/ui/models/tabview"
+	"github.com/alphameo/nm-tui/internal/ui/styles"
+)
+
+type diagnosticsConfig struct {
+	stepColIdx    int
+	resultColIdx  int
+	latencyColIdx int
+	detailColIdx  int
+
+	stepColTitle    string
+	resultColTitle  string
+	latencyColTitle string
+	detailColTitle  string
+
+	stepWidthProportion    float32
+	resultWidthProportion  float32
+	latencyWidthProportion float32
+}
+
+const diagnosticsTableZoneID = "diagnostics.table"
+
+var diagnosticsCfg = diagnosticsConfig{
+	stepColIdx:    0,
+	resultColIdx:  1,
+	latencyColIdx: 2,
+	detailColIdx:  3,
+
+	stepColTitle:    "Step",
+	resultColTitle:  "Result",
+	latencyColTitle: "Latency",
+	detailColTitle:  "Detail",
+
+	stepWidthProportion:    0.25,
+	resultWidthProportion:  0.1,
+	latencyWidthProportion: 0.1,
+}
+
+type diagKind int
+
+const (
+	diagLink diagKind = iota
+	diagIP
+	diagGateway
+	diagDNS
+	diagHTTP
+	diagPortal
+)
+
+type diagStatus int
+
+const (
+	diagPending diagStatus = iota
+	diagRunning
+	diagPassed
+	diagFailed
+)
+
+func (s diagStatus) String() string {
+	switch s {
+	case diagRunning:
+		return "running"
+	case diagPassed:
+		return "pass"
+	case diagFailed:
+		return "fail"
+	default:
+		return ""
+	}
+}
+
+// diagStep is a check of the diagnostics and its last result.
+type diagStep struct {
+	kind diagKind
+	// server is the DNS server checked by DNS steps.
+	server string
+
+	status diagStatus
+	// latency is zero when the step has nothing to time.
+	latency time.Duration
+	detail  string
+}
+
+func (s *diagStep) name() string {
+	switch s.kind {
+	case diagLink:
+		return "Link up"
+	case diagIP:
+		return "IP assigned"
+	case diagGateway:
+		return "Gateway reachable"
+	case diagDNS:
+		if s.server == "" {
+			return "DNS"
+		}
+		return "DNS " + s.server
+	case diagHTTP:
+		return "HTTP reachable"
+	case diagPortal:
+		return "Captive portal"
+	default:
+		return "Undefined"
+	}
+}
+
+func (s *diagStep) result() string {
+	switch s.status {
+	case diagPassed:
+		return styles.SymbolCheck + " " + s.status.String()
+	case diagFailed:
+		return styles.SymbolError + " " + s.status.String()
+	default:
+		return s.status.String()
+	}
+}
+
+// diagTarget is what the first steps learn about the connection, the later
+// ones check it.
+type diagTarget struct {
+	device infra.NetworkDevice
+	ip     infra.IPConfig
+}
+
+// defaultDiagSteps returns the steps before the DNS servers are known.
+func defaultDiagSteps() []diagStep {
+	return []diagStep{
+		{kind: diagLink},
+		{kind: diagIP},
+		{kind: diagGateway},
+		{kind: diagDNS},
+		{kind: diagHTTP},
+		{kind: diagPortal},
+	}
+}
+
+// withDNSSteps replaces the DNS steps of steps by one for each server,
+// keeping the results of the servers checked before. Without servers a
+// single step reports their absence.
+func withDNSSteps(steps []diagStep, servers []string) []diagStep {
+	if len(servers) == 0 {
+		servers = []string{""}
+	}
+	first := slices.IndexFunc(steps, func(s diagStep) bool { return s.kind == diagDNS })
+	if first < 0 {
+		return steps
+	}
+	last := first
+	for last < len(steps) && steps[last].kind == diagDNS {
+		last++
+	}
+	old := steps[first:last]
+
+	dns := make([]diagStep, len(servers))
+	for i, server := range servers {
+		dns[i] = diagStep{kind: diagDNS, server: server}
+		if j := slices.IndexFunc(old, func(s diagStep) bool { return s.server == server }); j >= 0 {
+			dns[i] = old[j]
+		}
+	}
+	res := slices.Clone(steps[:first])
+	res = append(res, dns...)
+	return append(res, steps[last:]...)
+}
+
+// pickDevice returns the device carrying the connection: the first
+// connected one, NetworkManager lists the device of the default route first.
+// Without any, the first Wi-Fi or Ethernet device tells why.
+func pickDevice(devices []infra.NetworkDevice) (infra.NetworkDevice, bool) {
+	physical := func(d infra.NetworkDevice) bool {
+		return d.Type == "wifi" || d.Type == "ethernet"
+	}
+	for _, d := range devices {
+		if d.Type != "loopback" && strings.HasPrefix(d.State, "connected") {
+			return d, true
+		}
+	}
+	if i := slices.IndexFunc(devices, physical); i >= 0 {
+		return devices[i], false
+	}
+	return infra.NetworkDevice{}, false
+}
+
+// routableAddresses drops the link-local addresses, which are there without
+// any network.
+func routableAddresses(addresses []string) []string {
+	var res []string
+	for _, a := range addresses {
+		prefix, err := netip.ParsePrefix(a)
+		if err == nil && prefix.Addr().IsLinkLocalUnicast() {
+			continue
+		}
+		res = append(res, a)
+	}
+	return res
+}
+
+// causeOf returns the innermost error of a network failure, like "i/o
+// timeout", instead of the whole chain.
+func causeOf(err error) string {
+	if e, ok := errors.AsType[*net.DNSError](err); ok {
+		return e.Err
+	}
+	if e, ok := errors.AsType[*net.OpError](err); ok {
+		return e.Err.Error()
+	}
+	if e, ok := errors.AsType[*url.Error](err); ok {
+		return e.Err.Error()
+	}
+	return err.Error()
+}
+
+func formatLatency(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "-"
+	case d < time.Second:
+		return d.Round(100 * time.Microsecond).String()
+	default:
+		return d.Round(10 * time.Millisecond).String()
+	}
+}
+
+// diagReport renders the results as plain text to paste into a ticket or a
+// chat.
+func diagReport(steps []diagStep, target diagTarget, at time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "nm-tui diagnostics, %s\n", at.Format(time.DateTime))
+	if target.device.Device != "" {
+		fmt.Fprintf(&b, "Device: %s (%s), %s\n", target.device.Device, target.device.Type, target.device.State)
+	}
+	if len(target.ip.Addresses) > 0 {
+		fmt.Fprintf(&b, "Addresses: %s\n", strings.Join(target.ip.Addresses, ", "))
+	}
+	if len(target.ip.Gateways) > 0 {
+		fmt.Fprintf(&b, "Gateways: %s\n", strings.Join(target.ip.Gateways, ", "))
+	}
+	if len(target.ip.DNS) > 0 {
+		fmt.Fprintf(&b, "DNS: %s\n", strings.Join(target.ip.DNS, ", "))
+	}
+	b.WriteString("\n")
+
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STEP\tRESULT\tLATENCY\tDETAIL")
+	for _, s := range steps {
+		status := s.status.String()
+		if status == "" {
+			status = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", s.name(), status, formatLatency(s.latency), s.detail)
+	}
+	_ = tw.Flush()
+	return b.String()
+}
+
+type diagnosticsKeyMap struct {
+	rescan     key.Binding
+	rerunStep  key.Binding
+	copyReport key.Binding
+}
+
+// DiagnosticsModel checks the connection step by step, from the link up to
+// the web, to tell where it breaks.
+type DiagnosticsModel struct {
+	stepsTable table.Model
+	TableStyle lipgloss.Style
+
+	steps  []diagStep
+	target diagTarget
+	// runningAll is set while the steps run one after another.
+	runningAll bool
+	// run tells the results of a run from the ones of a run started over.
+	run int
+	// checkedAt is when the last step finished.
+	checkedAt time.Time
+
+	indicatorSpinner spinner.Model
+	IndicatorStyle   lipgloss.Style
+
+	focus  bool
+	clicks clickTracker
+
+	keys diagnosticsKeyMap
+
+	devMngr infra.DeviceManager
+	prober  infra.Prober
+	portal  infra.CaptivePortalOpener
+
+	Style lipgloss.Style
+}
+
+func NewDiagnosticsModel(
+	keys diagnosticsKeyMap,
+	deviceManager infra.DeviceManager,
+	prober infra.Prober,
+	portal infra.CaptivePortalOpener,
+) *DiagnosticsModel {
+	cols := make([]table.Column, 4)
+	cols[diagnosticsCfg.stepColIdx] = table.Column{
+		Title: diagnosticsCfg.stepColTitle,
+		Width: len(diagnosticsCfg.stepColTitle),
+	}
+	cols[diagnosticsCfg.resultColIdx] = table.Column{
+		Title: diagnosticsCfg.resultColTitle,
+		Width: len(diagnosticsCfg.resultColTitle),
+	}
+	cols[diagnosticsCfg.latencyColIdx] = table.Column{
+		Title: diagnosticsCfg.latencyColTitle,
+		Width: len(diagnosticsCfg.latencyColTitle),
+	}
+	cols[diagnosticsCfg.detailColIdx] = table.Column{
+		Title: diagnosticsCfg.detailColTitle,
+		Width: len(diagnosticsCfg.detailColTitle),
+	}
+
+	t := table.New(
+		table.WithColumns(cols),
+		table.WithStyles(styles.TableStyles),
+		table.WithFocused(true),
+	)
+
+	m := &DiagnosticsModel{
+		stepsTable: t,
+		TableStyle: lipgloss.NewStyle(),
+
+		steps: defaultDiagSteps(),
+
+		indicatorSpinner: newDefaultSpinner(),
+		IndicatorStyle:   lipgloss.NewStyle(),
+
+		keys: keys,
+
+		devMngr: deviceManager,
+		prober:  prober,
+		portal:  portal,
+
+		Style: lipgloss.NewStyle(),
+	}
+	m.setRows()
+	return m
+}
+
+// restyle applies the current styles to the table and indicator.
+func (m *DiagnosticsModel) restyle() {
+	m.stepsTable.SetStyles(styles.TableStyles)
+	restyleSpinner(&m.indicatorSpinner)
+	m.setRows()
+}
+
+func (m *DiagnosticsModel) Resize(width, height int) {
+	m.Style = m.Style.Width(width).Height(height)
+
+	border := m.Style.GetBorderStyle()
+	width -= border.GetLeftSize() + border.GetRightSize()
+	height -= border.GetBottomSize() + border.GetTopSize()
+
+	height -= lipgloss.Height(m.indicatorView())
+
+	tableBorder := m.TableStyle.GetBorderStyle()
+	width -= tableBorder.GetLeftSize() + tableBorder.GetRightSize()
+	height -= tableBorder.GetBottomSize() + tableBorder.GetTopSize()
+
+	m.stepsTable.SetWidth(width)
+	m.stepsTable.SetHeight(height)
+
+	tableUtilityOffset := len(m.stepsTable.Columns()) * 2
+
+	stepWidth := int(float32(width) * diagnosticsCfg.stepWidthProportion)
+	resultWidth := int(float32(width) * diagnosticsCfg.resultWidthProportion)
+	latencyWidth := int(float32(width) * diagnosticsCfg.latencyWidthProportion)
+	detailWidth := width - stepWidth - resultWidth - latencyWidth - tableUtilityOffset
+
+	m.stepsTable.Columns()[diagnosticsCfg.stepColIdx].Width = stepWidth
+	m.stepsTable.Columns()[diagnosticsCfg.resultColIdx].Width = resultWidth
+	m.stepsTable.Columns()[diagnosticsCfg.latencyColIdx].Width = latencyWidth
+	m.stepsTable.Columns()[diagnosticsCfg.detailColIdx].Width = detailWidth
+	m.stepsTable.UpdateViewport()
+}
+
+func (m *DiagnosticsModel) Width() int { return m.Style.GetWidth() }
+
+func (m *DiagnosticsModel) Height() int { return m.Style.GetHeight() }
+
+func (m *DiagnosticsModel) Title() string { return "Diagnostics" }
+
+func (m *DiagnosticsModel) Focus() { m.focus = true }
+
+func (m *DiagnosticsModel) Blur() { m.focus = false }
+
+func (m *DiagnosticsModel) Focused() bool { return m.focus }
+
+// Init runs the checks the first time the tab is opened.
+func (m *DiagnosticsModel) Init() tea.Cmd {
+	if !m.focus || m.run > 0 {
+		return nil
+	}
+	return m.runAllCmd()
+}
+
+func (m *DiagnosticsModel) Update(msg tea.Msg) (*DiagnosticsModel, tea.Cmd) {
+	// The steps finish even when the tab is left meanwhile.
+	if msg, ok := msg.(diagStepDoneMsg); ok {
+		return m, m.setStepResult(msg)
+	}
+	if !m.focus {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, m.keys.rescan):
+			return m, m.runAllCmd()
+		case key.Matches(msg, m.keys.rerunStep):
+			return m, m.rerunStepCmd(m.stepsTable.Cursor())
+		case key.Matches(msg, m.keys.copyReport):
+			return m, m.copyReportCmd()
+		}
+	case tea.MouseClickMsg, tea.MouseWheelMsg:
+		if !clickTableRow(&m.stepsTable, diagnosticsTableZoneID, msg) {
+			return m, nil
+		}
+		idx := m.stepsTable.Cursor()
+		if idx >= 0 && idx < len(m.steps) && m.clicks.click(m.steps[idx].name(), time.Now()) {
+			return m, m.rerunStepCmd(idx)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	var cmds []tea.Cmd
+
+	if m.running() {
+		m.indicatorSpinner, cmd = m.indicatorSpinner.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	m.stepsTable, cmd = m.stepsTable.Update(msg)
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *DiagnosticsModel) UpdateAsTab(msg tea.Msg) (tabview.TabModel, tea.Cmd) {
+	return m.Update(msg)
+}
+
+func (m *DiagnosticsModel) View() string {
+	table := m.TableStyle.Render(m.stepsTable.View())
+	table = zones.Mark(diagnosticsTableZoneID, table)
+
+	view := lipgloss.JoinVertical(
+		lipgloss.Center,
+		table,
+		m.indicatorView(),
+	)
+	return m.Style.Render(view)
+}
+
+func (m *DiagnosticsModel) indicatorView() string {
+	var view string
+	switch {
+	case m.running():
+		view = fmt.Sprintf("Checking %s", m.indicatorSpinner.View())
+	case m.checkedAt.IsZero():
+		view = "Not checked"
+	default:
+		view = fmt.Sprintf("%s checked at %s", styles.SymbolCheck, m.checkedAt.Format(time.TimeOnly))
+	}
+	return m.IndicatorStyle.Render(view)
+}
+
+func (m *DiagnosticsModel) setRows() {
+	rows := make([]table.Row, len(m.steps))
+	for i, s := range m.steps {
+		rows[i] = make(table.Row, 4)
+		rows[i][diagnosticsCfg.stepColIdx] = markRow(diagnosticsTableZoneID, i, s.name())
+		rows[i][diagnosticsCfg.resultColIdx] = s.result()
+		rows[i][diagnosticsCfg.latencyColIdx] = formatLatency(s.latency)
+		rows[i][diagnosticsCfg.detailColIdx] = s.detail
+	}
+	m.stepsTable.SetRows(rows)
+	m.stepsTable.UpdateViewport()
+}
+
+func (m *DiagnosticsModel) running() bool {
+	return slices.ContainsFunc(m.steps, func(s diagStep) bool { return s.status == diagRunning })
+}
+
+// runAllCmd starts the steps over from the first, the next one starts when
+// the previous one is done.
+func (m *DiagnosticsModel) runAllCmd() tea.Cmd {
+	m.run++
+	m.runningAll = true
+	for i := range m.steps {
+		m.steps[i].status = diagPending
+		m.steps[i].latency = 0
+		m.steps[i].detail = ""
+	}
+	return tea.Batch(m.stepCmd(0), m.indicatorSpinner.Tick)
+}
+
+func (m *DiagnosticsModel) rerunStepCmd(idx int) tea.Cmd {
+	if m.running() || idx < 0 || idx >= len(m.steps) {
+		return nil
+	}
+	return tea.Batch(m.stepCmd(idx), m.indicatorSpinner.Tick)
+}
+
+func (m *DiagnosticsModel) copyReportCmd() tea.Cmd {
+	if m.checkedAt.IsZero() {
+		return NotifyWarningCmd("Nothing to copy, the checks haven't run yet")
+	}
+	return tea.Batch(
+		tea.SetClipboard(diagReport(m.steps, m.target, m.checkedAt)),
+		NotifyCmd("Diagnostics report copied to the clipboard"),
+	)
+}
+
+type diagStepDoneMsg struct {
+	run    int
+	idx    int
+	step   diagStep
+	target diagTarget
+}
+
+func (m *DiagnosticsModel) stepCmd(idx int) tea.Cmd {
+	m.steps[idx].status = diagRunning
+	m.setRows()
+
+	step := m.steps[idx]
+	target := m.target
+	run := m.run
+	settings := portalCfg.settings
+	return func() tea.Msg {
+		m.runStep(context.Background(), &step, &target, settings)
+		return diagStepDoneMsg{run: run, idx: idx, step: step, target: target}
+	}
+}
+
+func (m *DiagnosticsModel) setStepResult(msg diagStepDoneMsg) tea.Cmd {
+	if msg.run != m.run || msg.idx >= len(m.steps) {
+		return nil
+	}
+	m.steps[msg.idx] = msg.step
+	m.target = msg.target
+	m.checkedAt = time.Now()
+	if msg.step.kind == diagIP {
+		m.steps = withDNSSteps(m.steps, m.target.ip.DNS)
+	}
+
+	if m.runningAll {
+		if next := msg.idx + 1; next < len(m.steps) {
+			return m.stepCmd(next)
+		}
+		m.runningAll = false
+	}
+	m.setRows()
+	return nil
+}
+
+// runStep checks step against target, the link and IP steps fill target in.
+func (m *DiagnosticsModel) runStep(
+	ctx context.Context,
+	step *diagStep,
+	target *diagTarget,
+	settings infra.PortalSettings,
+) {
+	start := time.Now()
+	pass := func(detail string) {
+		step.status = diagPassed
+		step.latency = time.Since(start)
+		step.detail = detail
+	}
+	fail := func(detail string) {
+		step.status = diagFailed
+		step.latency = time.Since(start)
+		step.detail = detail
+	}
+
+	switch step.kind {
+	case diagLink:
+		devices, err := m.devMngr.ListNetworkDevices(ctx)
+		if err != nil {
+			fail("cannot list network devices")
+			return
+		}
+		device, ok := pickDevice(devices)
+		target.device = device
+		switch {
+		case ok:
+			pass(fmt.Sprintf("%s (%s) %s", device.Device, device.Type, device.State))
+		case device.Device != "":
+			fail(fmt.Sprintf("%s (%s) %s", device.Device, device.Type, device.State))
+		default:
+			fail("no network device")
+		}
+	case diagIP:
+		target.ip = infra.IPConfig{}
+		if target.device.Device == "" {
+			fail("no device to check")
+			return
+		}
+		ip, err := m.devMngr.GetIPConfig(ctx, target.device.Device)
+		if err != nil {
+			fail("cannot get ip configuration")
+			return
+		}
+		target.ip = ip
+		if addresses := routableAddresses(ip.Addresses); len(addresses) > 0 {
+			pass(strings.Join(addresses, ", "))
+		} else {
+			fail("no address")
+		}
+	case diagGateway:
+		if len(target.ip.Gateways) == 0 {
+			fail("no gateway")
+			return
+		}
+		gateway := target.ip.Gateways[0]
+		rtt, err := m.prober.Ping(ctx, gateway)
+		if err == nil {
+			pass(gateway + " replies to ping")
+			step.latency = rtt
+			return
+		}
+		if err := m.prober.CheckARP(ctx, gateway); err == nil {
+			pass(gateway + " in the ARP table, no reply to ping")
+			step.latency = 0
+			return
+		}
+		fail(gateway + " doesn't reply to ping, not in the ARP table")
+	case diagDNS:
+		if step.server == "" {
+			fail("no DNS server configured")
+			return
+		}
+		host := checkHost(settings.CheckURL)
+		addrs, err := m.prober.Resolve(ctx, step.server, host)
+		if err != nil {
+			fail(fmt.Sprintf("%s: %s", host, causeOf(err)))
+			return
+		}
+		pass(fmt.Sprintf("%s is %s", host, strings.Join(addrs, ", ")))
+	case diagHTTP:
+		status, err := m.prober.FetchHTTP(ctx, settings.CheckURL)
+		if err != nil {
+			fail(causeOf(err))
+			return
+		}
+		pass(fmt.Sprintf("%s answers %d", checkHost(settings.CheckURL), status))
+	case diagPortal:
+		portalURL, err := m.portal.FindCaptivePortal(ctx, settings)
+		switch {
+		case errors.Is(err, infra.ErrNoCaptivePortal):
+			pass("none, online")
+		case err != nil:
+			fail(causeOf(err))
+		default:
+			fail("login at " + portalURL)
+		}
+	}
+}
+
+// checkHost returns the host of the check URL, resolved by the DNS steps.
+func checkHost(checkURL string) string {
+	u, err := url.Parse(checkURL)
+	if err != nil || u.Hostname() == "" {
+		return checkURL
+	}
+	return u.Hostname()
+}
@@ -0,0 +1,242 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/alphameo/nm-tui/internal/infra"
+)
+
+type diagDeviceStub struct {
+	infra.DeviceManager
+
+	devices []infra.NetworkDevice
+	ip      infra.IPConfig
+}
+
+func (s *diagDeviceStub) ListNetworkDevices(context.Context) ([]infra.NetworkDevice, error) {
+	return s.devices, nil
+}
+
+func (s *diagDeviceStub) GetIPConfig(context.Context, string) (infra.IPConfig, error) {
+	return s.ip, nil
+}
+
+type diagProberStub struct {
+	infra.Prober
+
+	pingErr error
+	arpErr  error
+}
+
+func (s *diagProberStub) Ping(context.Context, string) (time.Duration, error) {
+	return 2 * time.Millisecond, s.pingErr
+}
+
+func (s *diagProberStub) CheckARP(context.Context, string) error {
+	return s.arpErr
+}
+
+func (s *diagProberStub) Resolve(_ context.Context, server, _ string) ([]string, error) {
+	if server == "10.0.0.53" {
+		return nil, errors.New("i/o timeout")
+	}
+	return []string{"93.184.216.34"}, nil
+}
+
+func (s *diagProberStub) FetchHTTP(context.Context, string) (int, error) {
+	return 204, nil
+}
+
+type diagPortalStub struct {
+	infra.CaptivePortalOpener
+
+	url string
+}
+
+func (s *diagPortalStub) FindCaptivePortal(context.Context, infra.PortalSettings) (string, error) {
+	if s.url == "" {
+		return "", infra.ErrNoCaptivePortal
+	}
+	return s.url, nil
+}
+
+func TestWithDNSSteps(t *testing.T) {
+	t.Parallel()
+
+	steps := defaultDiagSteps()
+	steps = withDNSSteps(steps, []string{"1.1.1.1", "9.9.9.9"})
+	steps[3].status = diagPassed
+
+	steps = withDNSSteps(steps, []string{"1.1.1.1", "8.8.8.8"})
+	var got []string
+	for _, s := range steps {
+		got = append(got, s.name())
+	}
+	want := []string{"Link up", "IP assigned", "Gateway reachable", "DNS 1.1.1.1", "DNS 8.8.8.8", "HTTP reachable", "Captive portal"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("steps = %q, want %q", got, want)
+	}
+	if steps[3].status != diagPassed {
+		t.Errorf("result of DNS 1.1.1.1 = %v, want it kept", steps[3].status)
+	}
+
+	steps = withDNSSteps(steps, nil)
+	if n := len(steps); n != 6 || steps[3].name() != "DNS" {
+		t.Errorf("without servers: %d steps, DNS step %q, want 6 and %q", n, steps[3].name(), "DNS")
+	}
+}
+
+func TestPickDevice(t *testing.T) {
+	t.Parallel()
+
+	lo := infra.NetworkDevice{Device: "lo", Type: "loopback", State: "connected (externally)"}
+	wlan := infra.NetworkDevice{Device: "wlan0", Type: "wifi", State: "disconnected"}
+	eth := infra.NetworkDevice{Device: "eth0", Type: "ethernet", State: "connected"}
+
+	tests := []struct {
+		name    string
+		devices []infra.NetworkDevice
+		want    string
+		wantOK  bool
+	}{
+		{"connected", []infra.NetworkDevice{lo, wlan, eth}, "eth0", true},
+		{"none connected", []infra.NetworkDevice{lo, wlan}, "wlan0", false},
+		{"no device", []infra.NetworkDevice{lo}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := pickDevice(tt.devices)
+			if got.Device != tt.want || ok != tt.wantOK {
+				t.Errorf("pickDevice() = %q, %v, want %q, %v", got.Device, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDiagRunStep(t *testing.T) {
+	t.Parallel()
+
+	connected := diagTarget{
+		device: infra.NetworkDevice{Device: "wlan0", Type: "wifi", State: "connected"},
+		ip: infra.IPConfig{
+			Addresses: []string{"192.168.1.5/24"},
+			Gateways:  []string{"192.168.1.1"},
+			DNS:       []string{"1.1.1.1"},
+		},
+	}
+	settings := infra.PortalSettings{CheckURL: "http://nmcheck.gnome.org/check_network_status.txt"}
+
+	tests := []struct {
+		name       string
+		step       diagStep
+		target     diagTarget
+		ip         infra.IPConfig
+		prober     diagProberStub
+		portalURL  string
+		wantStatus diagStatus
+		wantDetail string
+	}{
+		{
+			name:       "link-local address only",
+			step:       diagStep{kind: diagIP},
+			target:     connected,
+			ip:         infra.IPConfig{Addresses: []string{"fe80::1/64"}},
+			wantStatus: diagFailed,
+			wantDetail: "no address",
+		},
+		{
+			name:       "gateway replies to ping",
+			step:       diagStep{kind: diagGateway},
+			target:     connected,
+			wantStatus: diagPassed,
+			wantDetail: "192.168.1.1 replies to ping",
+		},
+		{
+			name:       "gateway in ARP table",
+			step:       diagStep{kind: diagGateway},
+			target:     connected,
+			prober:     diagProberStub{pingErr: infra.ErrPing},
+			wantStatus: diagPassed,
+			wantDetail: "192.168.1.1 in the ARP table, no reply to ping",
+		},
+		{
+			name:       "gateway unreachable",
+			step:       diagStep{kind: diagGateway},
+			target:     connected,
+			prober:     diagProberStub{pingErr: infra.ErrPing, arpErr: infra.ErrNotInARPTable},
+			wantStatus: diagFailed,
+			wantDetail: "192.168.1.1 doesn't reply to ping, not in the ARP table",
+		},
+		{
+			name:       "no DNS server",
+			step:       diagStep{kind: diagDNS},
+			wantStatus: diagFailed,
+			wantDetail: "no DNS server configured",
+		},
+		{
+			name:       "DNS server timing out",
+			step:       diagStep{kind: diagDNS, server: "10.0.0.53"},
+			wantStatus: diagFailed,
+			wantDetail: "nmcheck.gnome.org: i/o timeout",
+		},
+		{
+			name:       "behind a portal",
+			step:       diagStep{kind: diagPortal},
+			portalURL:  "https://portal.example/login",
+			wantStatus: diagFailed,
+			wantDetail: "login at https://portal.example/login",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &DiagnosticsModel{
+				devMngr: &diagDeviceStub{ip: tt.ip},
+				prober:  &tt.prober,
+				portal:  &diagPortalStub{url: tt.portalURL},
+			}
+			step, target := tt.step, tt.target
+			m.runStep(context.Background(), &step, &target, settings)
+			if step.status != tt.wantStatus || step.detail != tt.wantDetail {
+				t.Errorf("runStep() = %v %q, want %v %q", step.status, step.detail, tt.wantStatus, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestDiagReport(t *testing.T) {
+	t.Parallel()
+
+	steps := []diagStep{
+		{kind: diagLink, status: diagPassed, latency: 3 * time.Millisecond, detail: "wlan0 (wifi) connected"},
+		{kind: diagDNS, server: "1.1.1.1", status: diagFailed, latency: 3 * time.Second, detail: "i/o timeout"},
+		{kind: diagHTTP},
+	}
+	target := diagTarget{
+		device: infra.NetworkDevice{Device: "wlan0", Type: "wifi", State: "connected"},
+		ip:     infra.IPConfig{DNS: []string{"1.1.1.1"}},
+	}
+	at := time.Date(2026, 10, 19, 12, 0, 5, 0, time.UTC)
+
+	got := diagReport(steps, target, at)
+	want := "nm-tui diagnostics, 2026-10-19 12:00:05\n" +
+		"Device: wlan0 (wifi), connected\n" +
+		"DNS: 1.1.1.1\n" +
+		"\n" +
+		"STEP            RESULT  LATENCY  DETAIL\n" +
+		"Link up         pass    3ms      wlan0 (wifi) connected\n" +
+		"DNS 1.1.1.1     fail    3s       i/o timeout\n" +
+		"HTTP reachable  -       -        \n"
+	if got != want {
+		t.Errorf("diagReport() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/alphameo/nm-tui/internal/ui/styles"
+	"github.com/alphameo/nm-tui/internal/ui/tools/fuzzy"
+)
+
+type tableFilterKeyMap struct {
+	open   key.Binding
+	accept key.Binding
+	clear  key.Binding
+}
+
+// tableFilter holds the query of a filterable table. While editing it
+// captures every key press, so the parent must forward keys to it before
+// matching its own bindings.
+type tableFilter struct {
+	input   textinput.Model
+	editing bool
+
+	keys tableFilterKeyMap
+}
+
+func newTableFilter(keys tableFilterKeyMap) tableFilter {
+	return tableFilter{
+		input: newDefaultFilterInput(),
+		keys:  keys,
+	}
+}
+
+func (f *tableFilter) Editing() bool { return f.editing }
+
+func (f *tableFilter) Query() string { return f.input.Value() }
+
+// Update reports whether the query changed.
+func (f *tableFilter) Update(msg tea.Msg) (tea.Cmd, bool) {
+	keyMsg, isKey := msg.(tea.KeyPressMsg)
+	if !f.editing {
+		if isKey && key.Matches(keyMsg, f.keys.open) {
+			f.editing = true
+			return f.input.Focus(), false
+		}
+		return nil, false
+	}
+
+	switch {
+	case isKey && key.Matches(keyMsg, f.keys.accept):
+		f.editing = false
+		f.input.Blur()
+		return nil, false
+	case isKey && key.Matches(keyMsg, f.keys.clear):
+		changed := f.input.Value() != ""
+		f.editing = false
+		f.input.Blur()
+		f.input.Reset()
+		return nil, changed
+	}
+
+	query := f.input.Value()
+	var cmd tea.Cmd
+	f.input, cmd = f.input.Update(msg)
+	return cmd, f.input.Value() != query
+}
+
+// Title appends the query to a table title while the filter is in use.
+func (f *tableFilter) Title(title string) string {
+	switch {
+	case f.editing:
+		return title + " " + f.input.View()
+	case f.Query() != "":
+		return title + " " + f.input.Prompt + f.Query()
+	default:
+		return title
+	}
+}
+
+// filterMatch matches the query against row fields. Every space separated
+// term must fuzzy match at least one field; the returned positions hold the
+// matched rune indices of each field for highlighting.
+func filterMatch(query string, fields ...string) ([][]int, bool) {
+	positions := make([][]int, len(fields))
+	for term := range strings.FieldsSeq(query) {
+		best, bestScore := -1, 0
+		var bestPositions []int
+		for i, field := range fields {
+			pos, score, ok := fuzzy.Match(term, field)
+			if ok && (best < 0 || score > bestScore) {
+				best, bestScore, bestPositions = i, score, pos
+			}
+		}
+		if best < 0 {
+			return nil, false
+		}
+		positions[best] = append(positions[best], bestPositions...)
+	}
+	return positions, true
+}
+
+// highlightMatches renders the runes of text at positions with the accent
+// style and the rest with base.
+func highlightMatches(text string, positions []int, base lipgloss.Style) string {
+	if len(positions) == 0 {
+		return base.Render(text)
+	}
+	matched := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		matched[pos] = true
+	}
+
+	var b, run strings.Builder
+	runMatched := false
+	flush := func() {
+		if run.Len() == 0 {
+			return
+		}
+		if runMatched {
+			b.WriteString(styles.AccentStyle.Render(run.String()))
+		} else {
+			b.WriteString(base.Render(run.String()))
+		}
+		run.Reset()
+	}
+	for i, r := range []rune(text) {
+		if matched[i] != runMatched {
+			flush()
+			runMatched = matched[i]
+		}
+		run.WriteRune(r)
+	}
+	flush()
+	return b.String()
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+)
+
+var testFilterKeys = tableFilterKeyMap{
+	open:   key.NewBinding(key.WithKeys("/")),
+	accept: key.NewBinding(key.WithKeys("enter")),
+	clear:  key.NewBinding(key.WithKeys("esc")),
+}
+
+func typeKeys(text string) []tea.Msg {
+	msgs := make([]tea.Msg, 0, len(text))
+	for _, r := range text {
+		msgs = append(msgs, tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	return msgs
+}
+
+func TestFilterMatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		query         string
+		fields        []string
+		wantPositions [][]int
+		wantOk        bool
+	}{
+		{"empty-query", "", []string{"Cafe", "WPA2"}, [][]int{nil, nil}, true},
+		{"term-per-field", "cf wpa", []string{"Cafe", "WPA2"}, [][]int{{0, 2}, {0, 1, 2}}, true},
+		{"every-term-must-match", "cafe wep", []string{"Cafe", "WPA2"}, nil, false},
+		{"best-field-wins", "home", []string{"h-o-m-e", "home"}, [][]int{nil, {0, 1, 2, 3}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			positions, ok := filterMatch(tt.query, tt.fields...)
+			if ok != tt.wantOk {
+				t.Fatalf("filterMatch(%q) ok = %v, want %v", tt.query, ok, tt.wantOk)
+			}
+			if !reflect.DeepEqual(positions, tt.wantPositions) {
+				t.Errorf("filterMatch(%q) = %v, want %v", tt.query, positions, tt.wantPositions)
+			}
+		})
+	}
+}
+
+func TestFilterSurvivesRescan(t *testing.T) {
+	t.Parallel()
+
+	available := NewAvailableNetworksModel(availableNetworksKeyMap{filter: testFilterKeys}, nil)
+	available.Focus()
+	profiles := NewNetworkProfilesModel(networkProfilesKeyMap{filter: testFilterKeys}, nil)
+	profiles.Focus()
+
+	available.setAvailable([]AvailableNetwork{{SSID: "HomeNet"}, {SSID: "Cafe", Security: "WPA2"}}, nil)
+	profiles.setProfiles([]NetworkProfileShort{{Name: "home", SSID: "HomeNet"}, {Name: "work", SSID: "Corp"}}, nil)
+
+	msgs := []tea.Msg{tea.KeyPressMsg{Code: '/', Text: "/"}}
+	msgs = append(msgs, typeKeys("wpa")...)
+	msgs = append(msgs, tea.KeyPressMsg{Code: tea.KeyEnter})
+	for _, msg := range msgs {
+		available.Update(msg)
+	}
+	for _, msg := range append([]tea.Msg{tea.KeyPressMsg{Code: '/', Text: "/"}}, typeKeys("crp")...) {
+		profiles.Update(msg)
+	}
+
+	if available.filter.Editing() {
+		t.Error("available filter still editing after accept")
+	}
+	if !profiles.filter.Editing() {
+		t.Error("profiles filter stopped editing before accept")
+	}
+
+	available.setAvailable([]AvailableNetwork{
+		{SSID: "Airport", Security: "WPA3"},
+		{SSID: "HomeNet"},
+		{SSID: "Cafe", Security: "WPA2"},
+	}, nil)
+	profiles.setProfiles([]NetworkProfileShort{
+		{Name: "corp-guest", SSID: "Guest"},
+		{Name: "home", SSID: "HomeNet"},
+		{Name: "work", SSID: "Corp"},
+	}, nil)
+
+	var ssids []string
+	for _, network := range available.visible {
+		ssids = append(ssids, network.SSID)
+	}
+	if want := []string{"Airport", "Cafe"}; !reflect.DeepEqual(ssids, want) {
+		t.Errorf("available after rescan = %v, want %v", ssids, want)
+	}
+	var names []string
+	for _, profile := range profiles.visible {
+		names = append(names, profile.Name)
+	}
+	if want := []string{"corp-guest", "work"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("profiles after rescan = %v, want %v", names, want)
+	}
+	if selected, _ := available.selected(); selected.SSID != "Cafe" {
+		t.Errorf("selected() = %q, want the network selected before the rescan", selected.SSID)
+	}
+
+	profiles.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if profiles.filter.Query() != "" || len(profiles.visible) != 3 {
+		t.Errorf("clearing the filter left query %q and %d rows", profiles.filter.Query(), len(profiles.visible))
+	}
+	if available.filter.Query() != "wpa" {
+		t.Errorf("available query = %q, want it kept after clearing the other table", available.filter.Query())
+	}
+}
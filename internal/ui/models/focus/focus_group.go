@@ -70,3 +70,7 @@ func (f *Group) FocusIdx() int {
 func (f *Group) FocusCurrent() tea.Cmd {
 	return f.focuses[f.focusIdx].Focus()
 }
+
+func (f *Group) Len() int {
+	return len(f.focuses)
+}
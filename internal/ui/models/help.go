@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"charm.land/bubbles/v2/help"
@@ -36,9 +37,6 @@ type HelpModel struct {
 func NewHelpModel(keys keyMaps) *HelpModel {
 	v := viewport.New()
 	h := help.New()
-	h.Styles = styles.HelpStyles
-	h.Ellipsis = styles.SymbolEllipsis
-	h.ShortSeparator = fmt.Sprintf(" %s ", styles.SymbolSeparator)
 
 	help := HelpModel{
 		viewport: v,
@@ -47,10 +45,19 @@ func NewHelpModel(keys keyMaps) *HelpModel {
 		keys:     keys.help,
 		Style:    lipgloss.NewStyle(),
 	}
-	help.viewport.SetContent(help.fullView())
+	help.restyle()
 	return &help
 }
 
+// restyle applies the current styles and renders the help text again, also
+// picking up changed bindings.
+func (m *HelpModel) restyle() {
+	m.help.Styles = styles.HelpStyles
+	m.help.Ellipsis = styles.SymbolEllipsis
+	m.help.ShortSeparator = fmt.Sprintf(" %s ", styles.SymbolSeparator)
+	m.viewport.SetContent(m.fullView())
+}
+
 func (m *HelpModel) Resize(width, height int) {
 	m.Style = m.Style.Width(width).Height(height)
 
@@ -120,6 +127,14 @@ func (m *HelpModel) fullView() string {
 	deviceTTL = styles.AccentStyle.Render(deviceTTL)
 	device := m.deviceFull()
 
+	diagnosticsTTL := "Diagnostics"
+	diagnosticsTTL = styles.AccentStyle.Render(diagnosticsTTL)
+	diagnostics := m.diagnosticsFull()
+
+	monitorTTL := "Monitor"
+	monitorTTL = styles.AccentStyle.Render(monitorTTL)
+	monitor := m.monitorFull()
+
 	availableNetworksTTL := "Available Networks"
 	availableNetworksTTL = styles.AccentStyle.Render(availableNetworksTTL)
 	availableNetworks := m.availableNetworksFull()
@@ -148,6 +163,39 @@ func (m *HelpModel) fullView() string {
 	profileEditorTTL = styles.AccentStyle.Render(profileEditorTTL)
 	profileEditor := m.profileEditorFull()
 
+	backupExportTTL := "Profiles Export"
+	backupExportTTL = styles.AccentStyle.Render(backupExportTTL)
+	backupExport := m.backupExportFull()
+
+	backupImportTTL := "Profiles Import"
+	backupImportTTL = styles.AccentStyle.Render(backupImportTTL)
+	backupImport := m.backupImportFull()
+
+	restorePreviewTTL := "Restore Preview"
+	restorePreviewTTL = styles.AccentStyle.Render(restorePreviewTTL)
+	restorePreview := m.restorePreviewFull()
+
+	markMatchingTTL := "Mark Matching"
+	markMatchingTTL = styles.AccentStyle.Render(markMatchingTTL)
+	markMatching := m.markMatchingFull()
+
+	commandPaletteTTL := "Command Palette"
+	commandPaletteTTL = styles.AccentStyle.Render(commandPaletteTTL)
+	commandPalette := m.commandPaletteFull()
+
+	bulkActionsTTL := "Bulk Actions"
+	bulkActionsTTL = styles.AccentStyle.Render(bulkActionsTTL)
+	bulkActions := m.bulkActionsFull()
+
+	if len(m.keyMap.conflicts) > 0 {
+		conflictsTTL := styles.AccentStyle.Foreground(styles.ErrorColor).Render("Conflicting keys")
+		conflictsTTL = styles.SymbolColoredError + " " + conflictsTTL
+		view = lipgloss.JoinVertical(
+			lipgloss.Left,
+			conflictsTTL, m.help.FullHelpView(m.conflictsFull()), "",
+		)
+	}
+
 	view = lipgloss.JoinVertical(
 		lipgloss.Left,
 		view,
@@ -160,12 +208,33 @@ func (m *HelpModel) fullView() string {
 		connectorTTL, m.help.FullHelpView(connector), "",
 		networkProfilesTTL, m.help.FullHelpView(networkProfiles), "",
 		profileEditorTTL, m.help.FullHelpView(profileEditor), "",
+		markMatchingTTL, m.help.FullHelpView(markMatching), "",
+		bulkActionsTTL, m.help.FullHelpView(bulkActions), "",
+		commandPaletteTTL, m.help.FullHelpView(commandPalette), "",
+		backupExportTTL, m.help.FullHelpView(backupExport), "",
+		backupImportTTL, m.help.FullHelpView(backupImport), "",
+		restorePreviewTTL, m.help.FullHelpView(restorePreview), "",
 		deviceTTL, m.help.FullHelpView(device), "",
+		diagnosticsTTL, m.help.FullHelpView(diagnostics), "",
+		monitorTTL, m.help.FullHelpView(monitor), "",
 	)
 
 	return view
 }
 
+// conflictsFull lists the keys of the config bound to several actions at
+// once, so a binding that never fires is not a mystery.
+func (m *HelpModel) conflictsFull() [][]key.Binding {
+	kbs := make([]key.Binding, len(m.keyMap.conflicts))
+	for i, c := range m.keyMap.conflicts {
+		kbs[i] = key.NewBinding(
+			key.WithKeys(c.Key),
+			key.WithHelp(c.Key, fmt.Sprintf("%s shadows %s", c.Action, c.Shadowed)),
+		)
+	}
+	return [][]key.Binding{kbs}
+}
+
 func (m *HelpModel) globalFull() [][]key.Binding {
 	return [][]key.Binding{{
 		m.fullKB(m.keyMap.toggle.Toggle, "Enable/Disable toggle button"),
@@ -178,6 +247,7 @@ func (m *HelpModel) mainFull() [][]key.Binding {
 		m.fullKB(m.keyMap.tabs.Next, "Move to next tab"),
 		m.fullKB(m.keyMap.tabs.Prev, "Move to previous tab"),
 		m.fullKB(m.keyMap.main.help, "Open/Close Help menu"),
+		m.fullKB(m.keyMap.main.palette, "Open Command Palette to search and run any action"),
 	}}
 }
 
@@ -200,18 +270,57 @@ func (m *HelpModel) deviceShort() []key.Binding {
 	return m.shortKBs(k)
 }
 
+func (m *HelpModel) diagnosticsFull() [][]key.Binding {
+	return [][]key.Binding{{
+		m.fullKB(m.keyMap.diagnostics.rescan, "Run all checks again"),
+		m.fullKB(m.keyMap.diagnostics.rerunStep, "Run the selected check again"),
+		m.fullKB(m.keyMap.diagnostics.copyReport, "Copy the report to the clipboard"),
+	}}
+}
+
+func (m *HelpModel) diagnosticsShort() []key.Binding {
+	k := []key.Binding{
+		m.keyMap.diagnostics.rescan,
+		m.keyMap.diagnostics.rerunStep,
+		m.keyMap.diagnostics.copyReport,
+	}
+	return m.shortKBs(k)
+}
+
+func (m *HelpModel) monitorFull() [][]key.Binding {
+	return [][]key.Binding{{
+		m.fullKB(m.keyMap.monitor.prev, "Show the previous device"),
+		m.fullKB(m.keyMap.monitor.next, "Show the next device"),
+		m.fullKB(m.keyMap.monitor.clear, "Clear the graphs"),
+	}}
+}
+
+func (m *HelpModel) monitorShort() []key.Binding {
+	k := []key.Binding{
+		m.keyMap.monitor.next,
+		m.keyMap.monitor.clear,
+	}
+	return m.shortKBs(k)
+}
+
 func (m *HelpModel) availableNetworksFull() [][]key.Binding {
 	return [][]key.Binding{{
 		m.fullKB(m.keyMap.availableNetworks.connect, "Open Connector for selected network"),
 		m.fullKB(
 			m.keyMap.availableNetworks.activate,
-			"Activate the connection to the selected network by SSID."+
+			"Activate the connection to the selected network by SSID. "+
 				"Use profile credentials if it exists, or create new one",
 		),
 		m.fullKB(
 			m.keyMap.availableNetworks.deactivate,
 			"Deactivate connection to the selected network if SSID matches profile name",
 		),
+		m.fullKB(m.keyMap.availableNetworks.details, "Show the signal history of the selected access point"),
+		m.fullKB(m.keyMap.availableNetworks.filter.open, "Fuzzy filter networks by SSID and security"),
+		m.fullKB(m.keyMap.availableNetworks.filter.accept, "Keep the filter and return to the table"),
+		m.fullKB(m.keyMap.availableNetworks.filter.clear, "Clear the filter"),
+		m.fullKB(m.keyMap.availableNetworks.sort.cycle, "Sort by signal, SSID, security, known networks or nothing"),
+		m.fullKB(m.keyMap.availableNetworks.sort.reverse, "Reverse the sort order"),
 	}}
 }
 
@@ -220,6 +329,16 @@ func (m *HelpModel) availableNetworksShort() []key.Binding {
 		m.keyMap.availableNetworks.connect,
 		m.keyMap.availableNetworks.activate,
 		m.keyMap.availableNetworks.deactivate,
+		m.keyMap.availableNetworks.details,
+		m.keyMap.availableNetworks.filter.open,
+	}
+	return m.shortKBs(k)
+}
+
+func (m *HelpModel) filterShort() []key.Binding {
+	k := []key.Binding{
+		m.keyMap.availableNetworks.filter.accept,
+		m.keyMap.availableNetworks.filter.clear,
 	}
 	return m.shortKBs(k)
 }
@@ -274,6 +393,23 @@ func (m *HelpModel) networkProfilesFull() [][]key.Binding {
 		),
 		m.fullKB(m.keyMap.networkProfiles.edit, "Open Profile Editor for selected profile"),
 		m.fullKB(m.keyMap.networkProfiles.delete, "Delete network profile"),
+		m.fullKB(m.keyMap.networkProfiles.share, "Show profile credentials as a Wi-Fi QR code"),
+		m.fullKB(m.keyMap.networkProfiles.mark, "Mark/Unmark selected profile for bulk actions"),
+		m.fullKB(m.keyMap.networkProfiles.markMatching, "Mark profiles whose name or SSID contains a pattern"),
+		m.fullKB(m.keyMap.networkProfiles.invertMarks, "Invert marks"),
+		m.fullKB(m.keyMap.networkProfiles.clearMarks, "Clear marks"),
+		m.fullKB(
+			m.keyMap.networkProfiles.bulkActions,
+			"Open Bulk Actions for marked profiles, or for selected one if nothing is marked",
+		),
+		m.fullKB(m.keyMap.networkProfiles.filter.open, "Fuzzy filter profiles by name and SSID"),
+		m.fullKB(m.keyMap.networkProfiles.filter.accept, "Keep the filter and return to the table"),
+		m.fullKB(m.keyMap.networkProfiles.filter.clear, "Clear the filter"),
+		m.fullKB(
+			m.keyMap.networkProfiles.sort.cycle,
+			"Sort by name, SSID, mode, active state, autoconnect priority, last use or nothing",
+		),
+		m.fullKB(m.keyMap.networkProfiles.sort.reverse, "Reverse the sort order"),
 	}}
 }
 
@@ -283,6 +419,10 @@ func (m *HelpModel) networkProfilesShort() []key.Binding {
 		m.keyMap.networkProfiles.deactivate,
 		m.keyMap.networkProfiles.edit,
 		m.keyMap.networkProfiles.delete,
+		m.keyMap.networkProfiles.share,
+		m.keyMap.networkProfiles.mark,
+		m.keyMap.networkProfiles.bulkActions,
+		m.keyMap.networkProfiles.filter.open,
 	}
 	return m.shortKBs(k)
 }
@@ -297,6 +437,9 @@ func (m *HelpModel) networksFull() [][]key.Binding {
 		m.fullKB(m.keyMap.networks.createHotspot, "Open Hotspot Creator"),
 		m.fullKB(m.keyMap.networks.quickHotspot, "Enable hotspot, silently create its profile if not present"),
 		m.fullKB(m.keyMap.networks.openCaptivePortal, "Open login (captive) portal in external browser"),
+		m.fullKB(m.keyMap.networks.shareHotspot, "Show active hotspot credentials as a Wi-Fi QR code"),
+		m.fullKB(m.keyMap.networks.exportProfiles, "Back up all saved profiles, secrets included, to a file"),
+		m.fullKB(m.keyMap.networks.importProfiles, "Restore profiles from a backup file"),
 		m.fullKB(m.keyMap.networks.rescan, "Rescan networks"),
 	}}
 }
@@ -317,7 +460,7 @@ func (m *HelpModel) profileCreatorFull() [][]key.Binding {
 		m.fullKB(m.keyMap.profileCreator.prev, "Move to previous field"),
 		m.fullKB(m.keyMap.profileCreator.next, "Move to next field"),
 		m.fullKB(m.keyMap.profileCreator.togglePWVisibility, "Toggle password visibility"),
-		m.fullKB(m.keyMap.profileCreator.create, "Create network profile with entered settings"),
+		m.fullKB(m.keyMap.profileCreator.create, "Create network profile, or fill fields from WIFI: URI or QR .png in Import field"),
 		m.fullKB(m.keyMap.main.closePopup, "Close Profile Creator"),
 	}}
 }
@@ -350,6 +493,126 @@ func (m *HelpModel) profileEditorShort() []key.Binding {
 	return m.shortKBs(k)
 }
 
+func (m *HelpModel) wifiShareShort() []key.Binding {
+	k := []key.Binding{
+		m.keyMap.main.closePopup,
+	}
+	return m.shortKBs(k)
+}
+
+func (m *HelpModel) networkDetailsShort() []key.Binding {
+	k := []key.Binding{
+		m.keyMap.main.closePopup,
+	}
+	return m.shortKBs(k)
+}
+
+func (m *HelpModel) backupExportFull() [][]key.Binding {
+	return [][]key.Binding{{
+		m.fullKB(m.keyMap.backupExport.prev, "Move to previous field"),
+		m.fullKB(m.keyMap.backupExport.next, "Move to next field"),
+		m.fullKB(m.keyMap.backupExport.togglePWVisibility, "Toggle passphrase visibility"),
+		m.fullKB(m.keyMap.backupExport.export, "Export profiles, encrypted if a passphrase is entered"),
+		m.fullKB(m.keyMap.main.closePopup, "Close Profiles Export"),
+	}}
+}
+
+func (m *HelpModel) backupExportShort() []key.Binding {
+	k := []key.Binding{
+		m.keyMap.backupExport.togglePWVisibility,
+		m.keyMap.backupExport.export,
+		m.keyMap.main.closePopup,
+	}
+	return m.shortKBs(k)
+}
+
+func (m *HelpModel) backupImportFull() [][]key.Binding {
+	return [][]key.Binding{{
+		m.fullKB(m.keyMap.backupImport.prev, "Move to previous field"),
+		m.fullKB(m.keyMap.backupImport.next, "Move to next field"),
+		m.fullKB(m.keyMap.backupImport.togglePWVisibility, "Toggle passphrase visibility"),
+		m.fullKB(m.keyMap.backupImport.load, "Read backup file and open Restore Preview"),
+		m.fullKB(m.keyMap.main.closePopup, "Close Profiles Import"),
+	}}
+}
+
+func (m *HelpModel) backupImportShort() []key.Binding {
+	k := []key.Binding{
+		m.keyMap.backupImport.togglePWVisibility,
+		m.keyMap.backupImport.load,
+		m.keyMap.main.closePopup,
+	}
+	return m.shortKBs(k)
+}
+
+func (m *HelpModel) restorePreviewFull() [][]key.Binding {
+	return [][]key.Binding{{
+		m.fullKB(m.keyMap.restorePreview.cycleAction, "Cycle action of selected profile: create/skip or skip/overwrite/rename"),
+		m.fullKB(m.keyMap.restorePreview.restore, "Restore profiles with chosen actions"),
+		m.fullKB(m.keyMap.main.closePopup, "Cancel restore"),
+	}}
+}
+
+func (m *HelpModel) restorePreviewShort() []key.Binding {
+	k := []key.Binding{
+		m.keyMap.restorePreview.cycleAction,
+		m.keyMap.restorePreview.restore,
+		m.keyMap.main.closePopup,
+	}
+	return m.shortKBs(k)
+}
+
+func (m *HelpModel) markMatchingFull() [][]key.Binding {
+	return [][]key.Binding{{
+		m.fullKB(m.keyMap.markMatching.mark, "Mark profiles matching entered pattern"),
+		m.fullKB(m.keyMap.main.closePopup, "Close Mark Matching"),
+	}}
+}
+
+func (m *HelpModel) markMatchingShort() []key.Binding {
+	k := []key.Binding{
+		m.keyMap.markMatching.mark,
+		m.keyMap.main.closePopup,
+	}
+	return m.shortKBs(k)
+}
+
+func (m *HelpModel) bulkActionsFull() [][]key.Binding {
+	return [][]key.Binding{{
+		m.fullKB(m.keyMap.bulkActions.prev, "Move to previous action"),
+		m.fullKB(m.keyMap.bulkActions.next, "Move to next action"),
+		m.fullKB(m.keyMap.bulkActions.apply, "Apply selected action to all target profiles"),
+		m.fullKB(m.keyMap.main.closePopup, "Close Bulk Actions"),
+	}}
+}
+
+func (m *HelpModel) bulkActionsShort() []key.Binding {
+	k := []key.Binding{
+		m.keyMap.bulkActions.next,
+		m.keyMap.bulkActions.apply,
+		m.keyMap.main.closePopup,
+	}
+	return m.shortKBs(k)
+}
+
+func (m *HelpModel) commandPaletteFull() [][]key.Binding {
+	return [][]key.Binding{{
+		m.fullKB(m.keyMap.commandPalette.prev, "Move to previous command"),
+		m.fullKB(m.keyMap.commandPalette.next, "Move to next command"),
+		m.fullKB(m.keyMap.commandPalette.run, "Run selected command, or list values of its parameter"),
+		m.fullKB(m.keyMap.main.closePopup, "Close Command Palette"),
+	}}
+}
+
+func (m *HelpModel) commandPaletteShort() []key.Binding {
+	k := []key.Binding{
+		m.keyMap.commandPalette.next,
+		m.keyMap.commandPalette.run,
+		m.keyMap.main.closePopup,
+	}
+	return m.shortKBs(k)
+}
+
 func (m *HelpModel) shortKB(kb key.Binding) key.Binding {
 	keys := kb.Keys()
 	desc := kb.Help().Desc
@@ -378,6 +641,41 @@ func (m *HelpModel) shortKBs(kbs []key.Binding) []key.Binding {
 	return shortKBs
 }
 
+// scopeFull returns the Help sections listing the actions of scope.
+func (m *HelpModel) scopeFull(scope actionScope) [][]key.Binding {
+	switch scope {
+	case scopeMain:
+		return m.mainFull()
+	case scopeNetworks:
+		return m.networksFull()
+	case scopeAvailableNetworks:
+		return m.availableNetworksFull()
+	case scopeNetworkProfiles:
+		return m.networkProfilesFull()
+	case scopeDevice:
+		return slices.Concat(m.deviceFull(), m.globalFull())
+	case scopeDiagnostics:
+		return m.diagnosticsFull()
+	case scopeMonitor:
+		return m.monitorFull()
+	default:
+		return nil
+	}
+}
+
+// fullDesc returns the description Help gives kb among the actions of scope,
+// or its short one when Help doesn't list it.
+func (m *HelpModel) fullDesc(scope actionScope, kb key.Binding) string {
+	for _, group := range m.scopeFull(scope) {
+		for _, full := range group {
+			if full.Help().Key == kb.Help().Key && slices.Equal(full.Keys(), kb.Keys()) {
+				return full.Help().Desc
+			}
+		}
+	}
+	return kb.Help().Desc
+}
+
 func (m *HelpModel) fullKB(kb key.Binding, desc string) key.Binding {
 	helpKey := kb.Help().Key
 	kb.SetHelp(helpKey, desc)
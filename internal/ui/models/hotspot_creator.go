@@ -19,6 +19,8 @@ type hotspotCreatorConfig struct {
 	title string
 }
 
+const hotspotCreatorZoneID = "hotspot_creator"
+
 var hotspotCreatorCfg = hotspotCreatorConfig{
 	title: "Create Hotspot",
 }
@@ -63,6 +65,11 @@ func NewHotspotCreatorModel(keys hotspotCreatorKeyMap, networksManager infra.Net
 	return model
 }
 
+// restyle applies the current styles to the inputs.
+func (m *HotspotCreatorModel) restyle() {
+	restyleInputs(&m.ssid, &m.name, &m.password)
+}
+
 func (m *HotspotCreatorModel) Reset() tea.Cmd {
 	m.ssid.Reset()
 
@@ -80,6 +87,10 @@ func (m *HotspotCreatorModel) Init() tea.Cmd {
 }
 
 func (m *HotspotCreatorModel) Update(msg tea.Msg) (*HotspotCreatorModel, tea.Cmd) {
+	if msg, ok := msg.(tea.MouseClickMsg); ok {
+		cmd, _ := focusClicked(&m.focuses, hotspotCreatorZoneID, msg)
+		return m, cmd
+	}
 	if msg, ok := msg.(tea.KeyPressMsg); ok {
 		switch {
 		case key.Matches(msg, m.keys.next):
@@ -126,12 +137,15 @@ func (m *HotspotCreatorModel) UpdateAsPopup(msg tea.Msg) (PopupModel, tea.Cmd) {
 func (m *HotspotCreatorModel) View() string {
 	ssid := styles.ViewBorderedFocusable(&m.ssid)
 	ssid = lipgloss.JoinHorizontal(lipgloss.Center, "SSID     ", ssid)
+	ssid = markField(hotspotCreatorZoneID, 0, ssid)
 
 	name := styles.ViewBorderedFocusable(&m.name)
 	name = lipgloss.JoinHorizontal(lipgloss.Center, "Name     ", name)
+	name = markField(hotspotCreatorZoneID, 1, name)
 
 	password := styles.ViewInputWithValidation(&m.password)
 	password = lipgloss.JoinHorizontal(lipgloss.Center, "Password ", password)
+	password = markField(hotspotCreatorZoneID, 2, password)
 
 	fields := []string{
 		ssid,
@@ -169,7 +183,7 @@ func (m *HotspotCreatorModel) createHotspotProfileCmd() tea.Cmd {
 			if err != nil {
 				return tea.Batch(
 					SetAvailableNetworksStateCmd(NetsDone),
-					NotifyCmd(fmt.Sprintf(
+					NotifyErrorCmd(fmt.Sprintf(
 						"Cannot create hotspot %s:\n%v",
 						m.ssid.Value(), err,
 					)),
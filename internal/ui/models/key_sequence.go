@@ -0,0 +1,183 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/alphameo/nm-tui/internal/ui/styles"
+	"github.com/alphameo/nm-tui/internal/ui/tools/compositor"
+	"github.com/alphameo/nm-tui/internal/ui/tools/renderer"
+)
+
+// keySequence collects the presses of a key sequence, like "g g". Bindings
+// keep a sequence as one key with the steps separated by spaces, so a
+// completed sequence is handed on as a single press with that text, which
+// key.Matches compares like any other key.
+type keySequence struct {
+	presses []tea.KeyPressMsg
+	// id tells the timeout of the latest press from the ones before it.
+	id int
+}
+
+type sequenceTimeoutMsg int
+
+func sequenceTimeoutCmd(id int, timeout time.Duration) tea.Cmd {
+	return tea.Tick(timeout, func(time.Time) tea.Msg {
+		return sequenceTimeoutMsg(id)
+	})
+}
+
+func (s *keySequence) pending() bool {
+	return len(s.presses) > 0
+}
+
+// typed returns the sequence typed so far followed by steps.
+func (s *keySequence) typed(steps ...string) string {
+	typed := make([]string, 0, len(s.presses)+len(steps))
+	for _, p := range s.presses {
+		typed = append(typed, p.String())
+	}
+	return strings.Join(append(typed, steps...), " ")
+}
+
+// press adds msg to the sequence. It returns the presses to handle now:
+// none while the sequence may go on, the completed sequence as one press, or
+// the presses that turned out not to form a sequence, one by one.
+func (s *keySequence) press(msg tea.KeyPressMsg, bindings []key.Binding) ([]tea.KeyPressMsg, tea.Cmd) {
+	typed := s.typed(msg.String())
+	if len(sequenceContinuations(bindings, typed)) > 0 {
+		s.presses = append(s.presses, msg)
+		s.id++
+		return nil, sequenceTimeoutCmd(s.id, mainCfg.sequenceTimeout)
+	}
+	if !s.pending() {
+		return []tea.KeyPressMsg{msg}, nil
+	}
+	if sequenceBound(bindings, typed) {
+		s.reset()
+		return []tea.KeyPressMsg{{Text: typed}}, nil
+	}
+	presses := s.flush(bindings)
+	more, cmd := s.press(msg, bindings)
+	return append(presses, more...), cmd
+}
+
+// flush ends the sequence without waiting for more presses.
+func (s *keySequence) flush(bindings []key.Binding) []tea.KeyPressMsg {
+	presses := s.presses
+	typed := s.typed()
+	s.reset()
+	if len(presses) > 1 && sequenceBound(bindings, typed) {
+		return []tea.KeyPressMsg{{Text: typed}}
+	}
+	return presses
+}
+
+func (s *keySequence) reset() {
+	s.presses = nil
+}
+
+func sequenceBound(bindings []key.Binding, typed string) bool {
+	for _, b := range bindings {
+		if b.Enabled() && slices.Contains(b.Keys(), typed) {
+			return true
+		}
+	}
+	return false
+}
+
+// sequenceContinuations returns a binding per key continuing typed, with the
+// remaining steps as its help key.
+func sequenceContinuations(bindings []key.Binding, typed string) []key.Binding {
+	var continuations []key.Binding
+	for _, b := range bindings {
+		if !b.Enabled() {
+			continue
+		}
+		for _, k := range b.Keys() {
+			rest, ok := strings.CutPrefix(k, typed+" ")
+			if !ok {
+				continue
+			}
+			continuations = append(continuations, key.NewBinding(
+				key.WithKeys(k),
+				key.WithHelp(rest, b.Help().Desc),
+			))
+		}
+	}
+	return continuations
+}
+
+// tabBindings returns the bindings of the active tab, described as in the
+// help. Key sequences are only matched on tabs, dialogs take typed text.
+func (m *MainModel) tabBindings() []key.Binding {
+	groups := m.help.mainFull()
+	switch m.tabs.ActiveTabIndex() {
+	case 1: // Device tab
+		groups = slices.Concat(groups, m.help.deviceFull(), m.help.globalFull())
+	case 2: // Diagnostics tab
+		groups = append(groups, m.help.diagnosticsFull()...)
+	case 3: // Monitor tab
+		groups = append(groups, m.help.monitorFull()...)
+	default:
+		groups = append(groups, m.help.networksFull()...)
+		if m.networks.available.Focused() {
+			groups = append(groups, m.help.availableNetworksFull()...)
+		} else {
+			groups = append(groups, m.help.networkProfilesFull()...)
+		}
+	}
+	return slices.Concat(groups...)
+}
+
+// updateOnSequence feeds msg to the pending key sequence and handles the
+// presses it gives back like any other.
+func (m *MainModel) updateOnSequence(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if m.sequence.pending() && key.Matches(msg, m.keys.closePopup) {
+		m.sequence.reset()
+		return m, nil
+	}
+	presses, cmd := m.sequence.press(msg, m.tabBindings())
+	return m, tea.Batch(cmd, m.handleKeyPresses(presses))
+}
+
+func (m *MainModel) updateOnSequenceTimeout(msg sequenceTimeoutMsg) (tea.Model, tea.Cmd) {
+	if int(msg) != m.sequence.id || !m.sequence.pending() {
+		return m, nil
+	}
+	// A dialog opened meanwhile must not receive keys meant for the tab.
+	if m.popup.active || m.networks.capturesInput() {
+		m.sequence.reset()
+		return m, nil
+	}
+	return m, m.handleKeyPresses(m.sequence.flush(m.tabBindings()))
+}
+
+func (m *MainModel) handleKeyPresses(presses []tea.KeyPressMsg) tea.Cmd {
+	if len(presses) == 1 {
+		return m.handleKeyPress(presses[0])
+	}
+	cmds := make([]tea.Cmd, len(presses))
+	for i, p := range presses {
+		cmds[i] = m.handleKeyPress(p)
+	}
+	return tea.Sequence(cmds...)
+}
+
+// sequenceHintView lists the ways the pending key sequence can go on.
+func (m *MainModel) sequenceHintView() string {
+	continuations := sequenceContinuations(m.tabBindings(), m.sequence.typed())
+	view := styles.OverlayStyle.Render(m.help.help.FullHelpView([][]key.Binding{continuations}))
+	title := styles.DefaultStyle.Render(renderer.RenderTitle(m.sequence.typed()))
+	return compositor.Compose(
+		title,
+		view,
+		compositor.Center,
+		compositor.Begin,
+		0,
+		0,
+	)
+}
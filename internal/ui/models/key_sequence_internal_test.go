@@ -0,0 +1,77 @@
+package models
+
+import (
+	"testing"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestKeySequencePress(t *testing.T) {
+	t.Parallel()
+
+	bindings := []key.Binding{
+		NewKey([]string{"d", "delete"}, "delete"),
+		NewKey([]string{"g g"}, "go to top"),
+		NewKey([]string{"g g g"}, "go further"),
+		NewKey([]string{"\\ h"}, "hotspot"),
+	}
+	tests := []struct {
+		name        string
+		presses     string
+		want        []string
+		wantPending bool
+	}{
+		{name: "single key", presses: "x", want: []string{"x"}},
+		{name: "bound single key", presses: "d", want: []string{"d"}},
+		{name: "prefix", presses: "g", wantPending: true},
+		{name: "completed", presses: `\h`, want: []string{`\ h`}},
+		{name: "completed and extendable", presses: "gg", wantPending: true},
+		{name: "longest", presses: "ggg", want: []string{"g g g"}},
+		{name: "shorter on mismatch", presses: "ggx", want: []string{"g g", "x"}},
+		{name: "broken", presses: "gx", want: []string{"g", "x"}},
+		{name: "broken by prefix", presses: `g\`, want: []string{"g"}, wantPending: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var s keySequence
+			var got []string
+			for _, r := range tt.presses {
+				presses, _ := s.press(tea.KeyPressMsg{Code: r, Text: string(r)}, bindings)
+				for _, p := range presses {
+					got = append(got, p.String())
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("handled %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("handled %q, want %q", got, tt.want)
+				}
+			}
+			if s.pending() != tt.wantPending {
+				t.Errorf("pending = %v, want %v", s.pending(), tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestKeySequenceFlush(t *testing.T) {
+	t.Parallel()
+
+	bindings := []key.Binding{NewKey([]string{"g g", "g g g"}, "go to top")}
+	var s keySequence
+	for _, r := range "gg" {
+		s.press(tea.KeyPressMsg{Code: r, Text: string(r)}, bindings)
+	}
+	presses := s.flush(bindings)
+	if len(presses) != 1 || !key.Matches(presses[0], bindings[0]) {
+		t.Errorf("flush() = %v, want the completed sequence", presses)
+	}
+	if s.pending() {
+		t.Error("sequence still pending after flush")
+	}
+}
@@ -21,6 +21,8 @@ type keyMaps struct {
 	tabs              tabview.KeyMap
 	toggle            toggle.KeyMap
 	device            deviceKeyMap
+	diagnostics       diagnosticsKeyMap
+	monitor           monitorKeyMap
 	networks          networksKeyMap
 	networkProfiles   networkProfilesKeyMap
 	profileEditor     profileEditorKeyMap
@@ -28,76 +30,157 @@ type keyMaps struct {
 	connector         connectorKeyMap
 	profileCreator    profileCreatorKeyMap
 	hotspotCreator    hotspotCreatorKeyMap
+	backupExport      backupExportKeyMap
+	backupImport      backupImportKeyMap
+	restorePreview    restorePreviewKeyMap
+	markMatching      markMatchingKeyMap
+	bulkActions       bulkActionsKeyMap
+	commandPalette    commandPaletteKeyMap
+	picker            pickerKeyMap
 	help              helpKeyMap
+
+	// conflicts are the keys shadowing one another, flagged in the help.
+	conflicts []config.KeyConflict
 }
 
 func initKeys(keys config.KeyConfig) keyMaps {
+	filter := tableFilterKeyMap{
+		open:   NewKey(keys.Expand(keys.Filter), "filter"),
+		accept: NewKey(keys.Expand(keys.Dialog.Accept), "keep filter"),
+		clear:  NewKey(keys.Expand(keys.Dialog.Close), "clear filter"),
+	}
+	sort := tableSortKeyMap{
+		cycle:   NewKey(keys.Expand(keys.Sort), "sort"),
+		reverse: NewKey(keys.Expand(keys.ReverseSort), "reverse sort"),
+	}
 	return keyMaps{
+		conflicts: keys.Conflicts(),
 		main: mainKeyMap{
-			quit:       NewKey(*keys.Main.Quit, "quit"),
-			closePopup: NewKey(*keys.Dialog.Close, "close popup"),
-			help:       NewKey(*keys.Main.Help, "help"),
+			quit:       NewKey(keys.Expand(keys.Main.Quit), "quit"),
+			closePopup: NewKey(keys.Expand(keys.Dialog.Close), "close popup"),
+			help:       NewKey(keys.Expand(keys.Main.Help), "help"),
+			palette:    NewKey(keys.Expand(keys.Main.CommandPalette), "commands"),
 		},
 		tabs: tabview.KeyMap{
-			Next: NewKey(*keys.Main.TabNext, "next tab"),
-			Prev: NewKey(*keys.Main.TabPrev, "prev tab"),
+			Next: NewKey(keys.Expand(keys.Main.TabNext), "next tab"),
+			Prev: NewKey(keys.Expand(keys.Main.TabPrev), "prev tab"),
 		},
 		toggle: toggle.KeyMap{
-			Toggle: NewKey(*keys.Toggle, "toggle"),
+			Toggle: NewKey(keys.Expand(keys.Toggle), "toggle"),
 		},
 		device: deviceKeyMap{
-			prev:   NewKey(*keys.FocusPrev, "prev field"),
-			next:   NewKey(*keys.FocusNext, "next field"),
-			rescan: NewKey(*keys.Rescan, "rescan"),
+			prev:   NewKey(keys.Expand(keys.FocusPrev), "prev field"),
+			next:   NewKey(keys.Expand(keys.FocusNext), "next field"),
+			rescan: NewKey(keys.Expand(keys.Rescan), "rescan"),
+		},
+		diagnostics: diagnosticsKeyMap{
+			rescan:     NewKey(keys.Expand(keys.Rescan), "run all"),
+			rerunStep:  NewKey(keys.Expand(keys.Diagnostics.RerunStep), "rerun step"),
+			copyReport: NewKey(keys.Expand(keys.Diagnostics.CopyReport), "copy report"),
+		},
+		monitor: monitorKeyMap{
+			prev:  NewKey(keys.Expand(keys.FocusPrev), "prev device"),
+			next:  NewKey(keys.Expand(keys.FocusNext), "next device"),
+			clear: NewKey(keys.Expand(keys.Rescan), "clear graphs"),
 		},
 		networks: networksKeyMap{
-			winNext:           NewKey(*keys.FocusNext, "next window"),
-			winPrev:           NewKey(*keys.FocusPrev, "prev window"),
-			rescan:            NewKey(*keys.Rescan, "rescan"),
-			createProfile:     NewKey(*keys.Networks.CreateProfile, "create profile"),
-			createHotspot:     NewKey(*keys.Networks.CreateHotspot, "create hotspot"),
-			quickHotspot:      NewKey(*keys.Networks.QuickHotspot, "quick hotspot"),
-			openCaptivePortal: NewKey(*keys.Networks.OpenCaptivePortal, "login portal"),
-			win1:              NewKey(*keys.Focus1, "1st window"),
-			win2:              NewKey(*keys.Focus2, "2nd window"),
+			winNext:           NewKey(keys.Expand(keys.FocusNext), "next window"),
+			winPrev:           NewKey(keys.Expand(keys.FocusPrev), "prev window"),
+			rescan:            NewKey(keys.Expand(keys.Rescan), "rescan"),
+			createProfile:     NewKey(keys.Expand(keys.Networks.CreateProfile), "create profile"),
+			createHotspot:     NewKey(keys.Expand(keys.Networks.CreateHotspot), "create hotspot"),
+			quickHotspot:      NewKey(keys.Expand(keys.Networks.QuickHotspot), "quick hotspot"),
+			openCaptivePortal: NewKey(keys.Expand(keys.Networks.OpenCaptivePortal), "login portal"),
+			shareHotspot:      NewKey(keys.Expand(keys.Networks.ShareHotspot), "share hotspot"),
+			exportProfiles:    NewKey(keys.Expand(keys.Networks.ExportProfiles), "export profiles"),
+			importProfiles:    NewKey(keys.Expand(keys.Networks.ImportProfiles), "import profiles"),
+			win1:              NewKey(keys.Expand(keys.Focus1), "1st window"),
+			win2:              NewKey(keys.Expand(keys.Focus2), "2nd window"),
 		},
 		networkProfiles: networkProfilesKeyMap{
-			edit:       NewKey(*keys.NetworkProfiles.Edit, "edit"),
-			activate:   NewKey(*keys.NetworkProfiles.Activate, "activate"),
-			deactivate: NewKey(*keys.NetworkProfiles.Deactivate, "deactivate"),
-			delete:     NewKey(*keys.NetworkProfiles.Delete, "delete"),
+			edit:         NewKey(keys.Expand(keys.NetworkProfiles.Edit), "edit"),
+			activate:     NewKey(keys.Expand(keys.NetworkProfiles.Activate), "activate"),
+			deactivate:   NewKey(keys.Expand(keys.NetworkProfiles.Deactivate), "deactivate"),
+			delete:       NewKey(keys.Expand(keys.NetworkProfiles.Delete), "delete"),
+			share:        NewKey(keys.Expand(keys.NetworkProfiles.Share), "share"),
+			mark:         NewKey(keys.Expand(keys.NetworkProfiles.Mark), "mark"),
+			markMatching: NewKey(keys.Expand(keys.NetworkProfiles.MarkMatching), "mark matching"),
+			invertMarks:  NewKey(keys.Expand(keys.NetworkProfiles.InvertMarks), "invert marks"),
+			clearMarks:   NewKey(keys.Expand(keys.NetworkProfiles.ClearMarks), "clear marks"),
+			bulkActions:  NewKey(keys.Expand(keys.NetworkProfiles.BulkActions), "bulk actions"),
+			filter:       filter,
+			sort:         sort,
 		},
 		availableNetworks: availableNetworksKeyMap{
-			connect:    NewKey(*keys.AvailableNetworks.Connect, "connect"),
-			activate:   NewKey(*keys.AvailableNetworks.Activate, "activate"),
-			deactivate: NewKey(*keys.AvailableNetworks.Deactivate, "deactivate"),
+			connect:    NewKey(keys.Expand(keys.AvailableNetworks.Connect), "connect"),
+			activate:   NewKey(keys.Expand(keys.AvailableNetworks.Activate), "activate"),
+			deactivate: NewKey(keys.Expand(keys.AvailableNetworks.Deactivate), "deactivate"),
+			details:    NewKey(keys.Expand(keys.AvailableNetworks.Details), "details"),
+			filter:     filter,
+			sort:       sort,
 		},
 		profileEditor: profileEditorKeyMap{
-			prev:               NewKey(*keys.FocusPrev, "prev field"),
-			next:               NewKey(*keys.FocusNext, "next field"),
-			save:               NewKey(*keys.Dialog.Accept, "save"),
-			togglePWVisibility: NewKey(*keys.Dialog.TogglePWVisibility, "pw visibility"),
+			prev:               NewKey(keys.Expand(keys.FocusPrev), "prev field"),
+			next:               NewKey(keys.Expand(keys.FocusNext), "next field"),
+			save:               NewKey(keys.Expand(keys.Dialog.Accept), "save"),
+			togglePWVisibility: NewKey(keys.Expand(keys.Dialog.TogglePWVisibility), "pw visibility"),
 		},
 		connector: connectorKeyMap{
-			prev:               NewKey(*keys.FocusPrev, "prev field"),
-			next:               NewKey(*keys.FocusNext, "next field"),
-			connect:            NewKey(*keys.Dialog.Accept, "connect"),
-			togglePWVisibility: NewKey(*keys.Dialog.TogglePWVisibility, "pw visibility"),
+			prev:               NewKey(keys.Expand(keys.FocusPrev), "prev field"),
+			next:               NewKey(keys.Expand(keys.FocusNext), "next field"),
+			connect:            NewKey(keys.Expand(keys.Dialog.Accept), "connect"),
+			togglePWVisibility: NewKey(keys.Expand(keys.Dialog.TogglePWVisibility), "pw visibility"),
 		},
 		profileCreator: profileCreatorKeyMap{
-			prev:               NewKey(*keys.FocusPrev, "prev field"),
-			next:               NewKey(*keys.FocusNext, "next field"),
-			create:             NewKey(*keys.Dialog.Accept, "create"),
-			togglePWVisibility: NewKey(*keys.Dialog.TogglePWVisibility, "pw visibility"),
+			prev:               NewKey(keys.Expand(keys.FocusPrev), "prev field"),
+			next:               NewKey(keys.Expand(keys.FocusNext), "next field"),
+			create:             NewKey(keys.Expand(keys.Dialog.Accept), "create"),
+			togglePWVisibility: NewKey(keys.Expand(keys.Dialog.TogglePWVisibility), "pw visibility"),
 		},
 		hotspotCreator: hotspotCreatorKeyMap{
-			prev:               NewKey(*keys.FocusPrev, "prev field"),
-			next:               NewKey(*keys.FocusNext, "next field"),
-			create:             NewKey(*keys.Dialog.Accept, "create"),
-			togglePWVisibility: NewKey(*keys.Dialog.TogglePWVisibility, "pw visibility"),
+			prev:               NewKey(keys.Expand(keys.FocusPrev), "prev field"),
+			next:               NewKey(keys.Expand(keys.FocusNext), "next field"),
+			create:             NewKey(keys.Expand(keys.Dialog.Accept), "create"),
+			togglePWVisibility: NewKey(keys.Expand(keys.Dialog.TogglePWVisibility), "pw visibility"),
+		},
+		backupExport: backupExportKeyMap{
+			prev:               NewKey(keys.Expand(keys.FocusPrev), "prev field"),
+			next:               NewKey(keys.Expand(keys.FocusNext), "next field"),
+			export:             NewKey(keys.Expand(keys.Dialog.Accept), "export"),
+			togglePWVisibility: NewKey(keys.Expand(keys.Dialog.TogglePWVisibility), "pw visibility"),
+		},
+		backupImport: backupImportKeyMap{
+			prev:               NewKey(keys.Expand(keys.FocusPrev), "prev field"),
+			next:               NewKey(keys.Expand(keys.FocusNext), "next field"),
+			load:               NewKey(keys.Expand(keys.Dialog.Accept), "preview"),
+			togglePWVisibility: NewKey(keys.Expand(keys.Dialog.TogglePWVisibility), "pw visibility"),
+		},
+		restorePreview: restorePreviewKeyMap{
+			cycleAction: NewKey(keys.Expand(keys.Toggle), "change action"),
+			restore:     NewKey(keys.Expand(keys.Dialog.Accept), "restore"),
+		},
+		markMatching: markMatchingKeyMap{
+			mark: NewKey(keys.Expand(keys.Dialog.Accept), "mark"),
+		},
+		bulkActions: bulkActionsKeyMap{
+			prev:  NewKey(keys.Expand(keys.FocusPrev), "prev action"),
+			next:  NewKey(keys.Expand(keys.FocusNext), "next action"),
+			apply: NewKey(keys.Expand(keys.Dialog.Accept), "apply"),
+		},
+		commandPalette: commandPaletteKeyMap{
+			prev: NewKey(append(keys.Expand(keys.FocusPrev), "up"), "prev command"),
+			next: NewKey(append(keys.Expand(keys.FocusNext), "down"), "next command"),
+			run:  NewKey(keys.Expand(keys.Dialog.Accept), "run"),
+		},
+		picker: pickerKeyMap{
+			prev:               NewKey(append(keys.Expand(keys.FocusPrev), "up"), "prev"),
+			next:               NewKey(append(keys.Expand(keys.FocusNext), "down"), "next"),
+			pick:               NewKey(keys.Expand(keys.Dialog.Accept), "connect"),
+			cancel:             NewKey(keys.Expand(keys.Dialog.Close), "cancel"),
+			togglePWVisibility: NewKey(keys.Expand(keys.Dialog.TogglePWVisibility), "pw visibility"),
 		},
 		help: helpKeyMap{
-			quit: NewKey(*keys.Main.Help, "quit help"),
+			quit: NewKey(keys.Expand(keys.Main.Help), "quit help"),
 		},
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"charm.land/lipgloss/v2"
 	"github.com/alphameo/nm-tui/internal/config"
 	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/alphameo/nm-tui/internal/state"
 	"github.com/alphameo/nm-tui/internal/ui/models/tabview"
 	"github.com/alphameo/nm-tui/internal/ui/styles"
 	"github.com/alphameo/nm-tui/internal/ui/tools/compositor"
@@ -17,17 +18,35 @@ import (
 type mainConfig struct {
 	notificationCloseTime time.Duration
 	rescanInterval        time.Duration
+	mouse                 bool
+	sequenceTimeout       time.Duration
 }
 
 var mainCfg = mainConfig{
 	notificationCloseTime: 50 * time.Second,
 	rescanInterval:        10 * time.Second,
+	mouse:                 true,
+	sequenceTimeout:       time.Second,
 }
 
+// Names of the tabs to start on.
+const (
+	TabNetworks    = "networks"
+	TabDevice      = "device"
+	TabDiagnostics = "diagnostics"
+	TabMonitor     = "monitor"
+)
+
+const (
+	popupZoneID        = "popup"
+	notificationZoneID = "notification"
+)
+
 type mainKeyMap struct {
 	quit       key.Binding
 	closePopup key.Binding
 	help       key.Binding
+	palette    key.Binding
 }
 
 type MainModel struct {
@@ -36,14 +55,38 @@ type MainModel struct {
 	tabs         tabview.Model
 	popup        Popup
 	notification Notification
+	sequence     keySequence
 
-	networks *NetworksModel
-	device   *DeviceModel
+	networks    *NetworksModel
+	device      *DeviceModel
+	diagnostics *DiagnosticsModel
+	monitor     *MonitorModel
 
 	connector      *ConnectorModel
 	profileCreator *ProfileCreatorModel
 	hotspotCreator *HotspotCreatorModel
 	profileEditor  *ProfileEditorModel
+	wifiShare      *WifiShareModel
+	networkDetails *NetworkDetailsModel
+	backupExport   *BackupExportModel
+	backupImport   *BackupImportModel
+	restorePreview *RestorePreviewModel
+	markMatching   *MarkMatchingModel
+	bulkActions    *BulkActionsModel
+	commandPalette *CommandPaletteModel
+
+	// configChanges signals edits of the config file, nil disables reloading.
+	configChanges <-chan struct{}
+	// overrides are merged over the config file on every reload.
+	overrides *config.Config
+
+	startTab  int
+	startSSID string
+
+	watchdog        watchdog
+	watchdogRunning bool
+	// connectivity is the last one checked, to notice captive portals.
+	connectivity infra.ConnectivityStatus
 
 	keys  *mainKeyMap
 	help  *HelpModel
@@ -54,6 +97,8 @@ func NewMainModel(
 	networksManager infra.NetworksManager,
 	deviceManager infra.DeviceManager,
 	portalOpener infra.CaptivePortalOpener,
+	prober infra.Prober,
+	store *state.Store,
 	cfg config.Config,
 ) (*MainModel, error) {
 	err := styles.Init(cfg)
@@ -62,79 +107,201 @@ func NewMainModel(
 	}
 
 	keys := initKeys(*cfg.Keys)
-
-	mainCfg.notificationCloseTime = time.Duration(*cfg.NotifCloseTime) * time.Second
-	mainCfg.rescanInterval = time.Duration(*cfg.RescanInterval) * time.Second
+	applyMainConfig(cfg)
 
 	connector := NewConnectorModel(keys.connector, networksManager)
-	connector.Style = styles.OverlayStyle
 	profileCreator := NewProfileCreatorModel(keys.profileCreator, networksManager)
-	profileCreator.Style = styles.OverlayStyle
 	hotspotCreator := NewHotspotCreatorModel(keys.hotspotCreator, networksManager)
-	hotspotCreator.Style = styles.OverlayStyle
 	profileEditor := NewProfileEditorModel(keys.profileEditor, networksManager)
-	profileEditor.Style = styles.OverlayStyle
+	wifiShare := NewWifiShareModel(networksManager)
+	backupExport := NewBackupExportModel(keys.backupExport, networksManager)
+	backupImport := NewBackupImportModel(keys.backupImport, networksManager)
+	restorePreview := NewRestorePreviewModel(keys.restorePreview, networksManager)
+	markMatching := NewMarkMatchingModel(keys.markMatching)
+	bulkActions := NewBulkActionsModel(keys.bulkActions, networksManager)
+	commandPalette := NewCommandPaletteModel(keys.commandPalette)
 
 	available := NewAvailableNetworksModel(keys.availableNetworks, networksManager)
-	available.focusedStyle = styles.BorderedFocusedStyle
-	available.bluredStyle = styles.BorderedStyle
-	available.SetTableStyles(styles.TableStyles, styles.DataTableStyles)
-
 	profiles := NewNetworkProfilesModel(keys.networkProfiles, networksManager)
-	profiles.focusedStyle = styles.BorderedFocusedStyle
-	profiles.bluredStyle = styles.BorderedStyle
-	profiles.SetTableStyles(styles.TableStyles, styles.DataTableStyles)
 
-	networks := NewNetworksModel(available, profiles, keys.networks, networksManager, portalOpener)
-	networks.IndicatorStyle = styles.DefaultStyle
+	if store != nil {
+		prefs := store.State()
+		available.store = store
+		available.restoreSort(prefs.AvailableSort)
+		profiles.store = store
+		profiles.restoreSort(prefs.ProfilesSort)
+	}
 
+	networkDetails := NewNetworkDetailsModel(available)
+	networks := NewNetworksModel(available, profiles, keys.networks, networksManager, portalOpener)
 	device := NewDeviceModel(keys.device, deviceManager)
-	device.TableStyle = styles.BorderedStyle
-	device.IndicatorStyle = styles.DefaultStyle
-
-	tabContentBorder := tabview.DefaultContentBorder(styles.Border)
-	tabContentStyle := styles.DefaultStyle.Border(tabContentBorder)
-	networks.Style = tabContentStyle
-	device.Style = tabContentStyle
+	diagnostics := NewDiagnosticsModel(keys.diagnostics, deviceManager, prober, portalOpener)
+	monitor := NewMonitorModel(keys.monitor, deviceManager, prober)
 
 	tabs := tabview.New([]tabview.Tab{
 		{Title: networks.Title(), Content: networks},
 		{Title: device.Title(), Content: device},
+		{Title: diagnostics.Title(), Content: diagnostics},
+		{Title: monitor.Title(), Content: monitor},
 	})
-	tabs.SetStyles(styles.TabViewStyles)
 	tabs.Keys = keys.tabs
+	tabs.Zones = zones
 
 	p := Popup{
 		active: false,
 	}
 
-	notifStyle := lipgloss.NewStyle().Inherit(styles.NotifBorderedStyle)
-	n := Notification{style: notifStyle, closeTime: mainCfg.notificationCloseTime}
-
-	help := NewHelpModel(keys)
-	help.Style = styles.OverlayStyle
+	n := Notification{closeTime: mainCfg.notificationCloseTime}
 
-	return &MainModel{
+	m := &MainModel{
 		tabs:         tabs,
 		popup:        p,
 		notification: n,
 
-		networks: networks,
-		device:   device,
+		networks:    networks,
+		device:      device,
+		diagnostics: diagnostics,
+		monitor:     monitor,
 
 		connector:      connector,
 		profileCreator: profileCreator,
 		hotspotCreator: hotspotCreator,
 		profileEditor:  profileEditor,
+		wifiShare:      wifiShare,
+		networkDetails: networkDetails,
+		backupExport:   backupExport,
+		backupImport:   backupImport,
+		restorePreview: restorePreview,
+		markMatching:   markMatching,
+		bulkActions:    bulkActions,
+		commandPalette: commandPalette,
 
 		keys:  &keys.main,
-		help:  help,
+		help:  NewHelpModel(keys),
 		Style: lipgloss.NewStyle(),
-	}, nil
+	}
+	m.applyStyles()
+	return m, nil
+}
+
+func applyMainConfig(cfg config.Config) {
+	mainCfg.notificationCloseTime = time.Duration(*cfg.NotifCloseTime) * time.Second
+	mainCfg.rescanInterval = time.Duration(*cfg.RescanInterval) * time.Second
+	mainCfg.mouse = *cfg.Mouse
+	mainCfg.sequenceTimeout = time.Duration(*cfg.Keys.SequenceTimeout) * time.Millisecond
+	applyWatchdogConfig(cfg.Watchdog)
+	applyPortalConfig(cfg.Portal)
+	applyMonitorConfig(cfg.Monitor)
+}
+
+// applyStyles hands the styles built by styles.Init to every model.
+func (m *MainModel) applyStyles() {
+	m.connector.Style = styles.OverlayStyle
+	m.connector.restyle()
+	m.profileCreator.Style = styles.OverlayStyle
+	m.profileCreator.restyle()
+	m.hotspotCreator.Style = styles.OverlayStyle
+	m.hotspotCreator.restyle()
+	m.profileEditor.Style = styles.OverlayStyle
+	m.profileEditor.restyle()
+	m.wifiShare.Style = styles.OverlayStyle
+	m.networkDetails.Style = styles.OverlayStyle
+	m.backupExport.Style = styles.OverlayStyle
+	m.backupExport.restyle()
+	m.backupImport.Style = styles.OverlayStyle
+	m.backupImport.restyle()
+	m.restorePreview.Style = styles.OverlayStyle
+	m.restorePreview.SetTableStyles(styles.TableStyles)
+	m.markMatching.Style = styles.OverlayStyle
+	m.markMatching.restyle()
+	m.bulkActions.Style = styles.OverlayStyle
+	m.bulkActions.restyle()
+	m.commandPalette.Style = styles.OverlayStyle
+	m.commandPalette.restyle()
+
+	available := m.networks.available
+	available.focusedStyle = styles.BorderedFocusedStyle
+	available.bluredStyle = styles.BorderedStyle
+	available.SetTableStyles(styles.TableStyles, styles.DataTableStyles)
+
+	profiles := m.networks.profiles
+	profiles.focusedStyle = styles.BorderedFocusedStyle
+	profiles.bluredStyle = styles.BorderedStyle
+	profiles.SetTableStyles(styles.TableStyles, styles.DataTableStyles)
+
+	m.networks.IndicatorStyle = styles.DefaultStyle
+	m.networks.restyle()
+
+	m.device.TableStyle = styles.BorderedStyle
+	m.device.IndicatorStyle = styles.DefaultStyle
+	m.device.restyle()
+
+	m.diagnostics.TableStyle = styles.BorderedStyle
+	m.diagnostics.IndicatorStyle = styles.DefaultStyle
+	m.diagnostics.restyle()
+
+	m.monitor.restyle()
+
+	tabContentBorder := tabview.DefaultContentBorder(styles.Border)
+	tabContentStyle := styles.DefaultStyle.Border(tabContentBorder)
+	m.networks.Style = tabContentStyle
+	m.device.Style = tabContentStyle
+	m.diagnostics.Style = tabContentStyle
+	m.monitor.Style = tabContentStyle
+
+	m.tabs.SetStyles(styles.TabViewStyles)
+
+	m.notification.styles[notificationInfo] = lipgloss.NewStyle().Inherit(styles.NotifBorderedStyle)
+	m.notification.styles[notificationWarning] = lipgloss.NewStyle().Inherit(styles.NotifWarningStyle)
+	m.notification.styles[notificationError] = lipgloss.NewStyle().Inherit(styles.NotifErrorStyle)
+
+	m.help.Style = styles.OverlayStyle
+	m.help.restyle()
+}
+
+// StartOn makes the program open on the tab called name, TabNetworks,
+// TabDevice, TabDiagnostics or TabMonitor. It must be called before the program starts.
+func (m *MainModel) StartOn(name string) error {
+	switch name {
+	case TabNetworks:
+		m.startTab = 0
+	case TabDevice:
+		m.startTab = 1
+	case TabDiagnostics:
+		m.startTab = 2
+	case TabMonitor:
+		m.startTab = 3
+	default:
+		return fmt.Errorf("unknown tab %q", name)
+	}
+	return nil
+}
+
+// StartConnector makes the program open the connector for ssid. It must be
+// called before the program starts.
+func (m *MainModel) StartConnector(ssid string) {
+	m.startSSID = ssid
 }
 
 func (m *MainModel) Init() tea.Cmd {
-	return tea.Batch(m.tabs.Init(), IntervalRescanCmd(mainCfg.rescanInterval))
+	var connect tea.Cmd
+	if m.startSSID != "" {
+		connect = OpenConnectorCmd(m.startSSID)
+	}
+	var portal tea.Cmd
+	if portalCfg.autoOpen {
+		portal = checkConnectivityCmd(m.device.connMngr)
+	}
+	return tea.Batch(
+		m.tabs.Init(),
+		m.tabs.SetActiveTab(m.startTab),
+		connect,
+		portal,
+		IntervalRescanCmd(mainCfg.rescanInterval),
+		m.startWatchdog(),
+		waitConfigChangeCmd(m.configChanges),
+		keyConflictsCmd(m.help.keyMap.conflicts),
+	)
 }
 
 func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -151,8 +318,19 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.device.indicatorState == DeviceDone {
 			cmds = append(cmds, RescanDeviceCmd())
 		}
+		if portalCfg.autoOpen {
+			cmds = append(cmds, checkConnectivityCmd(m.device.connMngr))
+		}
 		cmds = append(cmds, IntervalRescanCmd(mainCfg.rescanInterval))
 		return m, tea.Batch(cmds...)
+	case configChangedMsg:
+		return m, tea.Batch(m.reloadConfig(), m.startWatchdog(), waitConfigChangeCmd(m.configChanges))
+	case networkActivatedMsg:
+		return m, m.portalLoginCmd(m.loginNames(string(msg)), nil)
+	case connectivityMsg:
+		return m, m.autoOpenPortal(infra.ConnectivityStatus(msg))
+	case watchdogTickMsg, watchdogCheckedMsg:
+		return m, m.updateWatchdog(msg)
 	case NetworksRescannedMsg:
 		return m, tea.Batch(
 			m.networks.available.setAvailable(msg.Available, msg.ScanErr),
@@ -186,6 +364,40 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.profileEditor.setNewProfile(string(msg)),
 			OpenPopupCmd(m.profileEditor),
 		)
+	case openWifiShareMsg:
+		return m, m.wifiShare.setProfileCmd(string(msg))
+	case wifiShareMsg:
+		var cmd tea.Cmd
+		m.wifiShare, cmd = m.wifiShare.Update(msg)
+		return m, cmd
+	case openNetworkDetailsMsg:
+		return m, m.networkDetails.setNetworkCmd(string(msg))
+	case openBackupExportMsg:
+		return m, tea.Batch(
+			m.backupExport.Reset(),
+			OpenPopupCmd(m.backupExport),
+		)
+	case openBackupImportMsg:
+		return m, tea.Batch(
+			m.backupImport.Reset(),
+			OpenPopupCmd(m.backupImport),
+		)
+	case openRestorePreviewMsg:
+		m.restorePreview.setEntries(msg)
+		return m, OpenPopupCmd(m.restorePreview)
+	case openMarkMatchingMsg:
+		return m, tea.Batch(
+			m.markMatching.Reset(),
+			OpenPopupCmd(m.markMatching),
+		)
+	case openBulkActionsMsg:
+		return m, tea.Batch(
+			m.bulkActions.setTargets(msg),
+			OpenPopupCmd(m.bulkActions),
+		)
+	case notificationLevelMsg:
+		m.notification.level = notificationLevel(msg)
+		return m, nil
 	case NotificationTextMsg:
 		m.notification.message = string(msg)
 		return m, nil
@@ -196,6 +408,12 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, msg
 	case tea.KeyPressMsg:
 		return m.updateOnKeyPress(msg)
+	case sequenceTimeoutMsg:
+		return m.updateOnSequenceTimeout(msg)
+	case paletteActionMsg:
+		return m, tea.Batch(m.showScope(msg.scope), m.handleKeyPress(msg.press))
+	case tea.MouseClickMsg, tea.MouseWheelMsg:
+		return m.updateOnMouse(msg.(tea.MouseMsg))
 	}
 
 	var cmd tea.Cmd
@@ -219,11 +437,48 @@ func (m *MainModel) updateOnKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		m.popup, cmd = m.popup.Update(msg)
 		return m, cmd
 	}
+	if m.networks.capturesInput() {
+		m.networks, cmd = m.networks.Update(msg)
+		return m, cmd
+	}
+	return m.updateOnSequence(msg)
+}
+
+// handleKeyPress handles a key press, or a completed key sequence, on tabs.
+func (m *MainModel) handleKeyPress(msg tea.KeyPressMsg) tea.Cmd {
 	switch {
 	case key.Matches(msg, m.keys.quit):
-		return m, tea.Quit
+		return tea.Quit
 	case key.Matches(msg, m.keys.help):
-		return m, OpenPopupCmd(m.help)
+		return OpenPopupCmd(m.help)
+	case key.Matches(msg, m.keys.palette):
+		return tea.Batch(
+			m.commandPalette.setCommands(m.paletteCommands()),
+			OpenPopupCmd(m.commandPalette),
+		)
+	}
+	var cmd tea.Cmd
+	m.tabs, cmd = m.tabs.Update(msg)
+	return cmd
+}
+
+// updateOnMouse hands the event to whatever is drawn on top at its position.
+// Popups are modal, so the rest of the screen does not react while one is open.
+func (m *MainModel) updateOnMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	mouse := msg.Mouse()
+	if m.notification.active && zones.Contains(notificationZoneID, mouse.X, mouse.Y) {
+		if _, ok := msg.(tea.MouseClickMsg); ok {
+			return m, SetNotificationActivityCmd(false)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	if m.popup.active {
+		if zones.Contains(popupZoneID, mouse.X, mouse.Y) {
+			m.popup, cmd = m.popup.Update(msg)
+		}
+		return m, cmd
 	}
 	m.tabs, cmd = m.tabs.Update(msg)
 	return m, cmd
@@ -237,7 +492,7 @@ func (m *MainModel) View() tea.View {
 	view := m.tabs.View()
 
 	if m.popup.active {
-		popupView := m.popup.View()
+		popupView := zones.Mark(popupZoneID, m.popup.View())
 		view = compositor.Compose(
 			popupView,
 			view,
@@ -247,9 +502,19 @@ func (m *MainModel) View() tea.View {
 			0,
 		)
 	}
+	if m.sequence.pending() {
+		view = compositor.Compose(
+			m.sequenceHintView(),
+			view,
+			compositor.End,
+			compositor.End,
+			-1,
+			-1,
+		)
+	}
 	if m.notification.active {
 		notificationView := m.notification.message
-		notificationView = m.notification.style.Render(notificationView)
+		notificationView = m.notification.style().Render(notificationView)
 		notificationView = compositor.Compose(
 			m.notification.title,
 			notificationView,
@@ -259,7 +524,7 @@ func (m *MainModel) View() tea.View {
 			0,
 		)
 		view = compositor.Compose(
-			notificationView,
+			zones.Mark(notificationZoneID, notificationView),
 			view,
 			compositor.End,
 			compositor.Begin,
@@ -271,8 +536,11 @@ func (m *MainModel) View() tea.View {
 	help := m.shortHelpView()
 	view = lipgloss.JoinVertical(lipgloss.Center, view, help)
 	view = m.Style.Render(view)
-	v := tea.NewView(view)
+	v := tea.NewView(zones.Scan(view))
 	v.AltScreen = true
+	if mainCfg.mouse {
+		v.MouseMode = tea.MouseModeCellMotion
+	}
 	return v
 }
 
@@ -293,8 +561,9 @@ func (m *MainModel) Resize(width, height int) {
 	m.help.Resize(int(float32(width)*0.8), int(float32(height)*0.8))
 	m.help.help.SetWidth(width)
 
-	notifStyle := m.notification.style.Width(width / 2)
-	m.notification.style = notifStyle
+	for i, style := range m.notification.styles {
+		m.notification.styles[i] = style.Width(width / 2)
+	}
 }
 
 func (m *MainModel) activeBindingsShort() []key.Binding {
@@ -308,15 +577,38 @@ func (m *MainModel) activeBindingsShort() []key.Binding {
 			return m.help.hotspotCreatorShort()
 		case *ProfileEditorModel:
 			return m.help.profileEditorShort()
+		case *WifiShareModel:
+			return m.help.wifiShareShort()
+		case *NetworkDetailsModel:
+			return m.help.networkDetailsShort()
+		case *BackupExportModel:
+			return m.help.backupExportShort()
+		case *BackupImportModel:
+			return m.help.backupImportShort()
+		case *RestorePreviewModel:
+			return m.help.restorePreviewShort()
+		case *MarkMatchingModel:
+			return m.help.markMatchingShort()
+		case *BulkActionsModel:
+			return m.help.bulkActionsShort()
+		case *CommandPaletteModel:
+			return m.help.commandPaletteShort()
 		}
 		return m.help.mainShort()
 	}
+	if m.networks.capturesInput() {
+		return m.help.filterShort()
+	}
 
 	helpKey := m.help.mainShort()
 
 	switch m.tabs.ActiveTabIndex() {
 	case 1: // Device tab
 		return append(helpKey, m.help.deviceShort()...)
+	case 2: // Diagnostics tab
+		return append(helpKey, m.help.diagnosticsShort()...)
+	case 3: // Monitor tab
+		return append(helpKey, m.help.monitorShort()...)
 	default: // Networks tab: tab actions + focused window
 		keys := []key.Binding{}
 		keys = append(keys, m.help.networksShort()...)
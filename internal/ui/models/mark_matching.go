@@ -0,0 +1,99 @@
+package models
+
+import (
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/alphameo/nm-tui/internal/ui/styles"
+	"github.com/alphameo/nm-tui/internal/ui/tools/compositor"
+	"github.com/alphameo/nm-tui/internal/ui/tools/renderer"
+)
+
+type markMatchingConfig struct {
+	title string
+}
+
+var markMatchingCfg = markMatchingConfig{
+	title: "Mark matching profiles",
+}
+
+type markMatchingKeyMap struct {
+	mark key.Binding
+}
+
+// MarkMatchingModel asks for a pattern and marks every profile whose name or
+// SSID contains it.
+type MarkMatchingModel struct {
+	pattern textinput.Model
+
+	keys markMatchingKeyMap
+
+	Style lipgloss.Style
+}
+
+func NewMarkMatchingModel(keys markMatchingKeyMap) *MarkMatchingModel {
+	return &MarkMatchingModel{
+		pattern: newDefaultPatternInput(),
+		keys:    keys,
+		Style:   lipgloss.NewStyle(),
+	}
+}
+
+// restyle applies the current styles to the input.
+func (m *MarkMatchingModel) restyle() {
+	restyleInputs(&m.pattern)
+}
+
+func (m *MarkMatchingModel) Reset() tea.Cmd {
+	m.pattern.Reset()
+	return m.pattern.Focus()
+}
+
+func (m *MarkMatchingModel) Init() tea.Cmd {
+	return m.pattern.Focus()
+}
+
+func (m *MarkMatchingModel) Update(msg tea.Msg) (*MarkMatchingModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyPressMsg); ok && key.Matches(msg, m.keys.mark) {
+		if m.pattern.Value() == "" {
+			return m, nil
+		}
+		return m, tea.Sequence(
+			ClosePopupCmd(),
+			markMatchingCmd(m.pattern.Value()),
+		)
+	}
+
+	var cmd tea.Cmd
+	m.pattern, cmd = m.pattern.Update(msg)
+	return m, cmd
+}
+
+func (m *MarkMatchingModel) UpdateAsPopup(msg tea.Msg) (PopupModel, tea.Cmd) {
+	return m.Update(msg)
+}
+
+func (m *MarkMatchingModel) View() string {
+	pattern := styles.ViewBorderedFocusable(&m.pattern)
+	pattern = lipgloss.JoinHorizontal(lipgloss.Center, "Name or SSID contains ", pattern)
+
+	view := m.Style.Render(pattern)
+	title := styles.DefaultStyle.Render(renderer.RenderTitle(markMatchingCfg.title))
+	return compositor.Compose(
+		title,
+		view,
+		compositor.Center,
+		compositor.Begin,
+		0,
+		0,
+	)
+}
+
+type markMatchingMsg string
+
+func markMatchingCmd(pattern string) tea.Cmd {
+	return func() tea.Msg {
+		return markMatchingMsg(pattern)
+	}
+}
@@ -0,0 +1,491 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/alphameo/nm-tui/internal/config"
+	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/alphameo/nm-tui/internal/ui/models/tabview"
+	"github.com/alphameo/nm-tui/internal/ui/styles"
+	"github.com/alphameo/nm-tui/internal/ui/tools/graph"
+)
+
+type monitorConfig struct {
+	sampleInterval time.Duration
+	history        time.Duration
+
+	deviceColWidth int
+	connColWidth   int
+	rateColWidth   int
+}
+
+const monitorDevicesZoneID = "monitor.devices"
+
+var monitorCfg = monitorConfig{
+	sampleInterval: time.Second,
+	history:        5 * time.Minute,
+
+	deviceColWidth: 10,
+	connColWidth:   16,
+	rateColWidth:   10,
+}
+
+func applyMonitorConfig(cfg *config.MonitorConfig) {
+	monitorCfg.sampleInterval = time.Duration(*cfg.SampleInterval) * time.Second
+	monitorCfg.history = time.Duration(*cfg.History) * time.Second
+}
+
+// samples returns how many samples the history holds.
+func (c monitorConfig) samples() int {
+	return max(int(c.history/c.sampleInterval), 1)
+}
+
+// monitoredDevice holds the graphs of a device and the last reading of its
+// counters, the rates are taken from the difference.
+type monitoredDevice struct {
+	device  infra.NetworkDevice
+	gateway string
+
+	prev   infra.InterfaceStats
+	prevAt time.Time
+
+	// rx and tx are in bytes per second, errors counts errors and drops per
+	// second, latency is in milliseconds.
+	rx      *graph.Series
+	tx      *graph.Series
+	errors  *graph.Series
+	latency *graph.Series
+}
+
+func newMonitoredDevice(device infra.NetworkDevice) *monitoredDevice {
+	n := monitorCfg.samples()
+	return &monitoredDevice{
+		device:  device,
+		rx:      graph.NewSeries(n),
+		tx:      graph.NewSeries(n),
+		errors:  graph.NewSeries(n),
+		latency: graph.NewSeries(n),
+	}
+}
+
+func (d *monitoredDevice) series() []*graph.Series {
+	return []*graph.Series{d.rx, d.tx, d.errors, d.latency}
+}
+
+// add records a sample. The first one, and the first after the counters
+// went back, only sets the reading the next rates start from.
+func (d *monitoredDevice) add(s monitorSample) {
+	for _, series := range d.series() {
+		if series.Cap() != monitorCfg.samples() {
+			series.Resize(monitorCfg.samples())
+		}
+	}
+	d.device = s.device
+	d.gateway = s.gateway
+
+	if s.pinged {
+		d.latency.Push(float64(s.latency) / float64(time.Millisecond))
+	} else {
+		d.latency.Push(math.NaN())
+	}
+
+	if !s.statsRead {
+		d.prevAt = time.Time{}
+		for _, series := range []*graph.Series{d.rx, d.tx, d.errors} {
+			series.Push(math.NaN())
+		}
+		return
+	}
+	prev, prevAt := d.prev, d.prevAt
+	d.prev, d.prevAt = s.stats, s.at
+	elapsed := s.at.Sub(prevAt).Seconds()
+	if prevAt.IsZero() || elapsed <= 0 || s.stats.RxBytes < prev.RxBytes || s.stats.TxBytes < prev.TxBytes {
+		return
+	}
+	d.rx.Push(float64(s.stats.RxBytes-prev.RxBytes) / elapsed)
+	d.tx.Push(float64(s.stats.TxBytes-prev.TxBytes) / elapsed)
+	errs, prevErrs := failedPackets(s.stats), failedPackets(prev)
+	d.errors.Push(float64(max(errs, prevErrs)-prevErrs) / elapsed)
+}
+
+// failedPackets counts the packets with errors and the dropped ones.
+func failedPackets(s infra.InterfaceStats) uint64 {
+	return s.RxErrors + s.TxErrors + s.RxDropped + s.TxDropped
+}
+
+// formatRate formats bytes per second with decimal units.
+func formatRate(v float64) string {
+	if math.IsNaN(v) {
+		return "-"
+	}
+	units := []string{"B/s", "kB/s", "MB/s", "GB/s"}
+	i := 0
+	for v >= 1000 && i < len(units)-1 {
+		v /= 1000
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f %s", v, units[i])
+	}
+	return fmt.Sprintf("%.1f %s", v, units[i])
+}
+
+func lastValue(s *graph.Series) float64 {
+	v, ok := s.Last()
+	if !ok {
+		return math.NaN()
+	}
+	return v
+}
+
+type monitorKeyMap struct {
+	prev  key.Binding
+	next  key.Binding
+	clear key.Binding
+}
+
+// MonitorModel graphs the traffic, the failed packets and the gateway
+// latency of the connected devices. It samples them only while it is open.
+type MonitorModel struct {
+	devices  []*monitoredDevice
+	selected int
+	// sampling is set while a sample, or the tick to the next one, is on the
+	// way.
+	sampling bool
+	// gateways caches the gateway of a device by device and connection, so
+	// nmcli isn't asked on every sample.
+	gateways map[string]string
+
+	titleStyle lipgloss.Style
+	graphStyle lipgloss.Style
+
+	focus bool
+
+	keys monitorKeyMap
+
+	devMngr infra.DeviceManager
+	prober  infra.Prober
+
+	Style lipgloss.Style
+}
+
+func NewMonitorModel(keys monitorKeyMap, deviceManager infra.DeviceManager, prober infra.Prober) *MonitorModel {
+	m := &MonitorModel{
+		gateways: make(map[string]string),
+		keys:     keys,
+		devMngr:  deviceManager,
+		prober:   prober,
+		Style:    lipgloss.NewStyle(),
+	}
+	m.restyle()
+	return m
+}
+
+// restyle applies the current styles to the titles and graphs.
+func (m *MonitorModel) restyle() {
+	m.titleStyle = styles.AccentStyle
+	m.graphStyle = styles.DefaultStyle.Foreground(styles.AccentColor)
+}
+
+func (m *MonitorModel) Resize(width, height int) {
+	m.Style = m.Style.Width(width).Height(height)
+}
+
+func (m *MonitorModel) Width() int { return m.Style.GetWidth() }
+
+func (m *MonitorModel) Height() int { return m.Style.GetHeight() }
+
+func (m *MonitorModel) Title() string { return "Monitor" }
+
+func (m *MonitorModel) Focus() { m.focus = true }
+
+func (m *MonitorModel) Blur() { m.focus = false }
+
+func (m *MonitorModel) Focused() bool { return m.focus }
+
+// Init starts sampling when the tab is opened.
+func (m *MonitorModel) Init() tea.Cmd {
+	if !m.focus || m.sampling {
+		return nil
+	}
+	m.sampling = true
+	return m.sampleCmd()
+}
+
+func (m *MonitorModel) Update(msg tea.Msg) (*MonitorModel, tea.Cmd) {
+	// Sampling stops on its own once the tab is left.
+	switch msg := msg.(type) {
+	case monitorTickMsg:
+		if !m.focus {
+			m.sampling = false
+			return m, nil
+		}
+		return m, m.sampleCmd()
+	case monitorSampledMsg:
+		if msg.err != nil {
+			m.sampling = false
+			return m, NotifyErrorCmd("Cannot get network devices to monitor")
+		}
+		m.gateways = msg.gateways
+		m.addSamples(msg.samples)
+		if !m.focus {
+			m.sampling = false
+			return m, nil
+		}
+		return m, monitorTickCmd(monitorCfg.sampleInterval)
+	}
+	if !m.focus {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, m.keys.next):
+			m.selectDevice(m.selected + 1)
+		case key.Matches(msg, m.keys.prev):
+			m.selectDevice(m.selected - 1)
+		case key.Matches(msg, m.keys.clear):
+			// The gateways are looked up again too, in case they changed.
+			clear(m.gateways)
+			for _, d := range m.devices {
+				for _, s := range d.series() {
+					s.Reset()
+				}
+			}
+		}
+	case tea.MouseClickMsg:
+		if msg.Button != tea.MouseLeft || !zones.Contains(monitorDevicesZoneID, msg.X, msg.Y) {
+			return m, nil
+		}
+		if idx, ok := clickedRow(monitorDevicesZoneID, len(m.devices), msg.Y); ok {
+			m.selected = idx
+		}
+	}
+	return m, nil
+}
+
+func (m *MonitorModel) UpdateAsTab(msg tea.Msg) (tabview.TabModel, tea.Cmd) {
+	return m.Update(msg)
+}
+
+// selectDevice selects the device idx, wrapping around the list.
+func (m *MonitorModel) selectDevice(idx int) {
+	if len(m.devices) == 0 {
+		return
+	}
+	m.selected = (idx%len(m.devices) + len(m.devices)) % len(m.devices)
+}
+
+// addSamples records samples, one per connected device. Devices gone are
+// dropped, the selection stays on the same device.
+func (m *MonitorModel) addSamples(samples []monitorSample) {
+	var selected string
+	if m.selected < len(m.devices) {
+		selected = m.devices[m.selected].device.Device
+	}
+
+	devices := make([]*monitoredDevice, 0, len(samples))
+	for _, s := range samples {
+		i := slices.IndexFunc(m.devices, func(d *monitoredDevice) bool {
+			return d.device.Device == s.device.Device
+		})
+		var d *monitoredDevice
+		if i >= 0 {
+			d = m.devices[i]
+		} else {
+			d = newMonitoredDevice(s.device)
+		}
+		d.add(s)
+		devices = append(devices, d)
+	}
+	m.devices = devices
+
+	m.selected = max(slices.IndexFunc(m.devices, func(d *monitoredDevice) bool {
+		return d.device.Device == selected
+	}), 0)
+}
+
+func (m *MonitorModel) View() string {
+	width := m.Style.GetWidth() - m.Style.GetHorizontalFrameSize()
+	height := m.Style.GetHeight() - m.Style.GetVerticalFrameSize()
+
+	statusline := fmt.Sprintf(
+		"Sampling every %s, graphs of the last %s",
+		monitorCfg.sampleInterval, monitorCfg.history,
+	)
+	if len(m.devices) == 0 {
+		view := lipgloss.JoinVertical(lipgloss.Center, "No connected device", "", statusline)
+		return m.Style.Render(lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, view))
+	}
+
+	overview := m.overviewView(width)
+	// The details have three graphs with a title each, the errors sparkline
+	// with its title and a blank line before the status line.
+	graphHeight := (height - lipgloss.Height(overview) - 1 - 3 - 2 - 1 - 1) / 3
+	details := m.detailsView(m.devices[m.selected], width, max(graphHeight, 1))
+
+	view := lipgloss.JoinVertical(lipgloss.Left, overview, "", details, "", statusline)
+	return m.Style.Render(view)
+}
+
+// overviewView lists the devices with sparklines of their traffic.
+func (m *MonitorModel) overviewView(width int) string {
+	c := monitorCfg
+	sparkWidth := max((width-2-c.deviceColWidth-c.connColWidth-2*c.rateColWidth-6)/2, 1)
+	cell := func(s string, w int) string {
+		return lipgloss.NewStyle().Width(w).MaxWidth(w).Render(s)
+	}
+
+	header := lipgloss.JoinHorizontal(lipgloss.Top,
+		"  ",
+		cell("Device", c.deviceColWidth),
+		cell("Connection", c.connColWidth),
+		cell("↓ Download", c.rateColWidth+sparkWidth+3),
+		cell("↑ Upload", c.rateColWidth+sparkWidth+3),
+	)
+	lines := []string{m.titleStyle.Render(header)}
+	for i, d := range m.devices {
+		marker := "  "
+		if i == m.selected {
+			marker = styles.SymbolCheck + " "
+		}
+		rx, tx := d.rx.Values(), d.tx.Values()
+		line := lipgloss.JoinHorizontal(lipgloss.Top,
+			marker,
+			cell(d.device.Device, c.deviceColWidth),
+			cell(d.device.Connection, c.connColWidth),
+			cell(formatRate(lastValue(d.rx)), c.rateColWidth),
+			m.graphStyle.Render(graph.Sparkline(rx, sparkWidth, graph.Max(rx))),
+			"   ",
+			cell(formatRate(lastValue(d.tx)), c.rateColWidth),
+			m.graphStyle.Render(graph.Sparkline(tx, sparkWidth, graph.Max(tx))),
+		)
+		lines = append(lines, markRow(monitorDevicesZoneID, i, line))
+	}
+	return zones.Mark(monitorDevicesZoneID, lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// detailsView draws the graphs of d, height lines each.
+func (m *MonitorModel) detailsView(d *monitoredDevice, width, height int) string {
+	rx, tx, latency, errs := d.rx.Values(), d.tx.Values(), d.latency.Values(), d.errors.Values()
+	rxTop, txTop, latencyTop := graph.Max(rx), graph.Max(tx), graph.Max(latency)
+
+	latencyTitle := "Latency, no gateway"
+	if d.gateway != "" {
+		last := lastValue(d.latency)
+		current := "no reply"
+		if !math.IsNaN(last) {
+			current = formatLatency(time.Duration(last * float64(time.Millisecond)))
+		}
+		latencyTitle = fmt.Sprintf(
+			"Latency to %s  %s, peak %s",
+			d.gateway, current, formatLatency(time.Duration(latencyTop*float64(time.Millisecond))),
+		)
+	}
+	lastErrs := lastValue(d.errors)
+	errsTitle := "Errors and drops  -"
+	if !math.IsNaN(lastErrs) {
+		errsTitle = fmt.Sprintf("Errors and drops  %.1f/s", lastErrs)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		m.titleStyle.Render(fmt.Sprintf(
+			"↓ Download  %s, peak %s", formatRate(lastValue(d.rx)), formatRate(rxTop),
+		)),
+		m.graphStyle.Render(graph.Braille(rx, width, height, rxTop)),
+		m.titleStyle.Render(fmt.Sprintf(
+			"↑ Upload  %s, peak %s", formatRate(lastValue(d.tx)), formatRate(txTop),
+		)),
+		m.graphStyle.Render(graph.Braille(tx, width, height, txTop)),
+		m.titleStyle.Render(latencyTitle),
+		m.graphStyle.Render(graph.Braille(latency, width, height, latencyTop)),
+		m.titleStyle.Render(errsTitle),
+		m.graphStyle.Render(graph.Sparkline(errs, width, max(graph.Max(errs), 1))),
+	)
+}
+
+// monitorSample is a reading of a connected device.
+type monitorSample struct {
+	device  infra.NetworkDevice
+	gateway string
+	at      time.Time
+
+	stats     infra.InterfaceStats
+	statsRead bool
+
+	latency time.Duration
+	pinged  bool
+}
+
+type monitorTickMsg struct{}
+
+type monitorSampledMsg struct {
+	samples []monitorSample
+	// gateways is the gateway cache, with the devices new to it.
+	gateways map[string]string
+	err      error
+}
+
+func monitorTickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return monitorTickMsg{}
+	})
+}
+
+// sampleCmd reads the counters of every connected device and pings their
+// gateways, all at once so a gateway not answering doesn't hold the others.
+func (m *MonitorModel) sampleCmd() tea.Cmd {
+	gateways := maps.Clone(m.gateways)
+	return func() tea.Msg {
+		ctx := context.Background()
+		devices, err := m.devMngr.ListNetworkDevices(ctx)
+		if err != nil {
+			return monitorSampledMsg{err: err}
+		}
+
+		var samples []monitorSample
+		for _, d := range devices {
+			if d.Type == "loopback" || !strings.HasPrefix(d.State, "connected") {
+				continue
+			}
+			cacheKey := d.Device + "\x00" + d.Connection
+			gateway, ok := gateways[cacheKey]
+			if !ok {
+				if ip, err := m.devMngr.GetIPConfig(ctx, d.Device); err == nil && len(ip.Gateways) > 0 {
+					gateway = ip.Gateways[0]
+				}
+				gateways[cacheKey] = gateway
+			}
+			samples = append(samples, monitorSample{device: d, gateway: gateway})
+		}
+
+		var wg sync.WaitGroup
+		for i := range samples {
+			s := &samples[i]
+			wg.Go(func() {
+				stats, err := m.prober.ReadStats(ctx, s.device.Device)
+				s.at = time.Now()
+				s.stats, s.statsRead = stats, err == nil
+				if s.gateway == "" {
+					return
+				}
+				latency, err := m.prober.Ping(ctx, s.gateway)
+				s.latency, s.pinged = latency, err == nil
+			})
+		}
+		wg.Wait()
+
+		return monitorSampledMsg{samples: samples, gateways: gateways}
+	}
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/alphameo/nm-tui/internal/infra"
+)
+
+func TestMonitoredDeviceAdd(t *testing.T) {
+	t.Parallel()
+
+	wlan := infra.NetworkDevice{Device: "wlan0", Type: "wifi", State: "connected"}
+	at := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	sample := func(sec int, rx, tx, drops uint64) monitorSample {
+		return monitorSample{
+			device:    wlan,
+			gateway:   "192.168.1.1",
+			at:        at.Add(time.Duration(sec) * time.Second),
+			stats:     infra.InterfaceStats{RxBytes: rx, TxBytes: tx, RxDropped: drops},
+			statsRead: true,
+			latency:   4 * time.Millisecond,
+			pinged:    true,
+		}
+	}
+
+	d := newMonitoredDevice(wlan)
+	d.add(sample(0, 1000, 500, 0))
+	if d.rx.Len() != 0 || d.latency.Len() != 1 {
+		t.Fatalf("after the first sample: %d rates, %d latencies, want 0 and 1", d.rx.Len(), d.latency.Len())
+	}
+	d.add(sample(2, 5000, 1500, 4))
+	// The counters went back, the interface was reset.
+	d.add(sample(3, 100, 100, 0))
+	d.add(sample(4, 1100, 300, 0))
+	failed := sample(5, 0, 0, 0)
+	failed.statsRead, failed.pinged = false, false
+	d.add(failed)
+
+	if got, want := d.rx.Values()[:2], []float64{2000, 1000}; !slices.Equal(got, want) {
+		t.Errorf("rx = %v, want %v", got, want)
+	}
+	if got, want := d.tx.Values()[:2], []float64{500, 200}; !slices.Equal(got, want) {
+		t.Errorf("tx = %v, want %v", got, want)
+	}
+	if got, want := d.errors.Values()[:2], []float64{2, 0}; !slices.Equal(got, want) {
+		t.Errorf("errors = %v, want %v", got, want)
+	}
+	if last := lastValue(d.rx); !math.IsNaN(last) {
+		t.Errorf("rate after a failed read = %v, want a gap", last)
+	}
+	if last := lastValue(d.latency); !math.IsNaN(last) || d.latency.Len() != 5 {
+		t.Errorf("latencies = %v, want 5 ending with a gap", d.latency.Values())
+	}
+}
+
+func TestMonitorAddSamples(t *testing.T) {
+	t.Parallel()
+
+	eth := monitorSample{device: infra.NetworkDevice{Device: "eth0"}}
+	wlan := monitorSample{device: infra.NetworkDevice{Device: "wlan0"}}
+	tun := monitorSample{device: infra.NetworkDevice{Device: "tun0"}}
+
+	m := &MonitorModel{}
+	m.addSamples([]monitorSample{eth, wlan})
+	m.selected = 1
+	first := m.devices[1]
+
+	m.addSamples([]monitorSample{tun, wlan})
+	if m.devices[m.selected].device.Device != "wlan0" {
+		t.Errorf("selected %q, want wlan0 kept", m.devices[m.selected].device.Device)
+	}
+	if m.devices[1] != first {
+		t.Error("the graphs of wlan0 were not kept")
+	}
+
+	m.addSamples([]monitorSample{eth})
+	if len(m.devices) != 1 || m.selected != 0 {
+		t.Errorf("%d devices, selected %d, want 1 and 0", len(m.devices), m.selected)
+	}
+}
+
+func TestFormatRate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		rate float64
+		want string
+	}{
+		{math.NaN(), "-"},
+		{0, "0 B/s"},
+		{999, "999 B/s"},
+		{1500, "1.5 kB/s"},
+		{12_300_000, "12.3 MB/s"},
+		{4e12, "4000.0 GB/s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+
+			if got := formatRate(tt.rate); got != tt.want {
+				t.Errorf("formatRate(%v) = %q, want %q", tt.rate, got, tt.want)
+			}
+		})
+	}
+}
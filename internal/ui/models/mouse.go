@@ -0,0 +1,108 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	"charm.land/bubbles/v2/table"
+	tea "charm.land/bubbletea/v2"
+	"github.com/alphameo/nm-tui/internal/ui/models/focus"
+	"github.com/alphameo/nm-tui/internal/ui/tools/zone"
+)
+
+type mouseConfig struct {
+	doubleClickInterval time.Duration
+}
+
+var mouseCfg = mouseConfig{
+	doubleClickInterval: 400 * time.Millisecond,
+}
+
+// zones remembers where clickable parts of the interface were drawn. Models
+// mark their views and hit-test mouse events against it, while MainModel
+// scans the final frame, after popups and notifications are composed.
+var zones = zone.New()
+
+func itemZoneID(prefix string, idx int) string {
+	return prefix + "." + strconv.Itoa(idx)
+}
+
+// markRow tags the first cell of a table row, so the row can be found by the
+// line it is drawn on. Rows scrolled out of view are not drawn and can't be hit.
+func markRow(table string, idx int, cell string) string {
+	return zones.Mark(itemZoneID(table+".row", idx), cell)
+}
+
+// clickedRow returns the index of the row of table drawn on line y.
+func clickedRow(table string, rowCount, y int) (int, bool) {
+	for i := range rowCount {
+		if r, ok := zones.Get(itemZoneID(table+".row", i)); ok && r.Y == y {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// clickTableRow moves the cursor of t to the clicked row, or scrolls it with
+// the wheel, when the event lands on the table marked as name. It reports
+// whether a row was clicked.
+func clickTableRow(t *table.Model, name string, msg tea.Msg) bool {
+	switch msg := msg.(type) {
+	case tea.MouseClickMsg:
+		if msg.Button != tea.MouseLeft || !zones.Contains(name, msg.X, msg.Y) {
+			return false
+		}
+		idx, ok := clickedRow(name, len(t.Rows()), msg.Y)
+		if ok {
+			t.SetCursor(idx)
+		}
+		return ok
+	case tea.MouseWheelMsg:
+		if !zones.Contains(name, msg.X, msg.Y) {
+			return false
+		}
+		switch msg.Button {
+		case tea.MouseWheelUp:
+			t.MoveUp(1)
+		case tea.MouseWheelDown:
+			t.MoveDown(1)
+		}
+	}
+	return false
+}
+
+// focusClicked focuses the field of the group whose zone, marked with
+// markField, contains the click.
+func focusClicked(g *focus.Group, form string, msg tea.MouseClickMsg) (tea.Cmd, bool) {
+	if msg.Button != tea.MouseLeft {
+		return nil, false
+	}
+	for i := range g.Len() {
+		if zones.Contains(itemZoneID(form+".field", i), msg.X, msg.Y) {
+			return g.SetFocusIdx(i), true
+		}
+	}
+	return nil, false
+}
+
+func markField(form string, idx int, view string) string {
+	return zones.Mark(itemZoneID(form+".field", idx), view)
+}
+
+// clickTracker tells double clicks from single ones.
+type clickTracker struct {
+	id string
+	at time.Time
+}
+
+// click registers a click on the item id and reports whether it completes
+// a double click.
+func (c *clickTracker) click(id string, now time.Time) bool {
+	double := c.id == id && now.Sub(c.at) <= mouseCfg.doubleClickInterval
+	if double {
+		*c = clickTracker{}
+	} else {
+		*c = clickTracker{id: id, at: now}
+	}
+	return double
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestClickTracker(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	tests := []struct {
+		name   string
+		clicks []string
+		after  time.Duration
+		want   bool
+	}{
+		{"single", nil, 0, false},
+		{"same-item", []string{"a"}, 0, true},
+		{"other-item", []string{"b"}, 0, false},
+		{"too-slow", []string{"a"}, mouseCfg.doubleClickInterval + time.Millisecond, false},
+		{"third-click-starts-over", []string{"a", "a"}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var c clickTracker
+			for _, id := range tt.clicks {
+				c.click(id, now)
+			}
+			if got := c.click("a", now.Add(tt.after)); got != tt.want {
+				t.Errorf("click() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTableMouse is the only test scanning the shared zones, so the positions
+// it reads are not replaced under it.
+func TestTableMouse(t *testing.T) {
+	t.Parallel()
+
+	m := NewAvailableNetworksModel(availableNetworksKeyMap{}, nil)
+	m.Resize(40, 10)
+	m.Focus()
+	m.setAvailable([]AvailableNetwork{
+		{SSID: "Home", BSSID: "01"},
+		{SSID: "Cafe", BSSID: "02"},
+		{SSID: "Airport", BSSID: "03"},
+	}, nil)
+	zones.Scan(m.View())
+
+	row, ok := zones.Get(itemZoneID(availableNetworksZoneID+".row", 1))
+	if !ok {
+		t.Fatal("second row is not drawn")
+	}
+	click := tea.MouseClickMsg{X: row.X, Y: row.Y, Button: tea.MouseLeft}
+
+	if _, cmd := m.Update(click); cmd != nil {
+		t.Error("single click returned a command")
+	}
+	if got, _ := m.selected(); got.SSID != "Cafe" {
+		t.Errorf("clicked network = %q, want %q", got.SSID, "Cafe")
+	}
+
+	_, cmd := m.Update(click)
+	if cmd == nil {
+		t.Fatal("double click returned no command")
+	}
+	if msg, ok := cmd().(openConnectorMsg); !ok || string(msg) != "Cafe" {
+		t.Errorf("double click produced %#v, want the connector for Cafe", msg)
+	}
+
+	m.Update(tea.MouseWheelMsg{X: row.X, Y: row.Y, Button: tea.MouseWheelDown})
+	if got, _ := m.selected(); got.SSID != "Airport" {
+		t.Errorf("network after scrolling = %q, want %q", got.SSID, "Airport")
+	}
+
+	m.Update(tea.MouseClickMsg{X: row.X, Y: row.Y + 100, Button: tea.MouseLeft})
+	if got, _ := m.selected(); got.SSID != "Airport" {
+		t.Errorf("click outside the table selected %q", got.SSID)
+	}
+}
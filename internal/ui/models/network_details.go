@@ -0,0 +1,198 @@
+package models
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/alphameo/nm-tui/internal/ui/styles"
+	"github.com/alphameo/nm-tui/internal/ui/tools/compositor"
+	"github.com/alphameo/nm-tui/internal/ui/tools/graph"
+	"github.com/alphameo/nm-tui/internal/ui/tools/renderer"
+)
+
+type networkDetailsConfig struct {
+	title       string
+	graphHeight int
+	// siblingSparkWidth is the width of the sparklines of the other access
+	// points of the network.
+	siblingSparkWidth int
+}
+
+var networkDetailsCfg = networkDetailsConfig{
+	title:             "Network details",
+	graphHeight:       6,
+	siblingSparkWidth: 24,
+}
+
+// NetworkDetailsModel graphs the signal of an access point over the recent
+// scans, next to the other access points of its network. It follows the
+// rescans while it is open.
+type NetworkDetailsModel struct {
+	id string
+	// last is the access point as last scanned, shown once it is out of
+	// range.
+	last AvailableNetwork
+
+	available *AvailableNetworksModel
+
+	Style lipgloss.Style
+}
+
+func NewNetworkDetailsModel(available *AvailableNetworksModel) *NetworkDetailsModel {
+	return &NetworkDetailsModel{
+		available: available,
+		Style:     lipgloss.NewStyle(),
+	}
+}
+
+// setNetworkCmd opens the popup on the access point id.
+func (m *NetworkDetailsModel) setNetworkCmd(id string) tea.Cmd {
+	network, ok := m.available.network(id)
+	if !ok {
+		return NotifyErrorCmd("The network is out of range")
+	}
+	m.id, m.last = id, network
+	return OpenPopupCmd(m)
+}
+
+func (m *NetworkDetailsModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *NetworkDetailsModel) Update(tea.Msg) (*NetworkDetailsModel, tea.Cmd) {
+	return m, nil
+}
+
+func (m *NetworkDetailsModel) UpdateAsPopup(msg tea.Msg) (PopupModel, tea.Cmd) {
+	return m.Update(msg)
+}
+
+func (m *NetworkDetailsModel) View() string {
+	network, inRange := m.available.network(m.id)
+	if inRange {
+		m.last = network
+	} else {
+		network = m.last
+	}
+	values := m.available.history.values(m.id)
+	graphWidth := signalHistoryCfg.samples / 2
+
+	symbol := styles.SymbolAvailable
+	if network.Active {
+		symbol = styles.SymbolCheck
+	} else if network.ProfileExists {
+		symbol = styles.SymbolSaved
+	}
+	security := network.Security
+	if security == "" {
+		security = "open"
+	}
+	lines := []string{
+		fmt.Sprintf("%s %s", symbol, styles.BoldStyle.Render(network.SSID)),
+		styles.MutedStyle.Render(fmt.Sprintf("BSSID %s, %s", cmp.Or(network.BSSID, "unknown"), security)),
+		"",
+		signalSummary(values, network.Signal, inRange),
+		signalGraph(values, graphWidth, networkDetailsCfg.graphHeight),
+		styles.MutedStyle.Render(historySpan(len(values))),
+	}
+
+	siblings := m.siblings(network)
+	if len(siblings) > 0 {
+		lines = append(lines, "", styles.AccentStyle.Render("Other access points of "+network.SSID))
+		for _, s := range siblings {
+			spark := graph.Sparkline(m.available.history.values(networkID(s)), networkDetailsCfg.siblingSparkWidth, 100)
+			lines = append(lines, fmt.Sprintf(
+				"%-17s %3d %s",
+				s.BSSID, s.Signal, styles.SignalStyle(s.Signal).Render(spark),
+			))
+		}
+	}
+
+	view := m.Style.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	title := styles.DefaultStyle.Render(renderer.RenderTitle(networkDetailsCfg.title))
+	return compositor.Compose(
+		title,
+		view,
+		compositor.Center,
+		compositor.Begin,
+		0,
+		0,
+	)
+}
+
+// siblings returns the other access points in range with the SSID of
+// network, the strongest first.
+func (m *NetworkDetailsModel) siblings(network AvailableNetwork) []AvailableNetwork {
+	var res []AvailableNetwork
+	for _, n := range m.available.networks {
+		if n.SSID == network.SSID && networkID(n) != networkID(network) {
+			res = append(res, n)
+		}
+	}
+	slices.SortStableFunc(res, func(a, b AvailableNetwork) int { return cmp.Compare(b.Signal, a.Signal) })
+	return res
+}
+
+// signalSummary describes the current signal and its range over values.
+func signalSummary(values []float64, current int, inRange bool) string {
+	low, mean, high, ok := signalStats(values)
+	now := styles.SignalStyle(current).Render(strconv.Itoa(current))
+	if !inRange {
+		now = styles.MutedStyle.Render("out of range")
+	}
+	if !ok {
+		return "Signal " + now
+	}
+	return fmt.Sprintf("Signal %s, min %.0f, avg %.0f, max %.0f", now, low, mean, high)
+}
+
+// signalStats returns the lowest, average and highest of values, skipping
+// the gaps, ok is false without any sample.
+func signalStats(values []float64) (low, mean, high float64, ok bool) {
+	low, high = math.Inf(1), math.Inf(-1)
+	var sum float64
+	var n int
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		low, high = min(low, v), max(high, v)
+		sum += v
+		n++
+	}
+	if n == 0 {
+		return 0, 0, 0, false
+	}
+	return low, sum / float64(n), high, true
+}
+
+// signalGraph draws values on the scale of the signal, labelled on the left.
+func signalGraph(values []float64, width, height int) string {
+	labels := make([]string, height)
+	labels[0] = "100"
+	labels[height-1] = "  0"
+	for i := 1; i < height-1; i++ {
+		labels[i] = "   "
+	}
+	labelView := styles.MutedStyle.Render(strings.Join(labels, "\n"))
+	graphView := styles.DefaultStyle.Foreground(styles.AccentColor).Render(graph.Braille(values, width, height, 100))
+	return lipgloss.JoinHorizontal(lipgloss.Top, labelView, " ", graphView)
+}
+
+// historySpan tells how far back the graph goes.
+func historySpan(scans int) string {
+	span := fmt.Sprintf("Last %d scans", scans)
+	if scans == 1 {
+		span = "Last scan"
+	}
+	if mainCfg.rescanInterval <= 0 {
+		return span
+	}
+	return fmt.Sprintf("%s, rescanning every %s", span, mainCfg.rescanInterval)
+}
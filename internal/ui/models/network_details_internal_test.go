@@ -0,0 +1,55 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSignalStats(t *testing.T) {
+	t.Parallel()
+
+	nan := math.NaN()
+	tests := []struct {
+		name                        string
+		values                      []float64
+		wantLow, wantMean, wantHigh float64
+		wantOK                      bool
+	}{
+		{"samples", []float64{40, 80, 60}, 40, 60, 80, true},
+		{"gaps skipped", []float64{nan, 50, nan, 30}, 30, 40, 50, true},
+		{"only gaps", []float64{nan, nan}, 0, 0, 0, false},
+		{"empty", nil, 0, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			low, mean, high, ok := signalStats(tt.values)
+			if low != tt.wantLow || mean != tt.wantMean || high != tt.wantHigh || ok != tt.wantOK {
+				t.Errorf(
+					"signalStats(%v) = %v, %v, %v, %v, want %v, %v, %v, %v",
+					tt.values, low, mean, high, ok, tt.wantLow, tt.wantMean, tt.wantHigh, tt.wantOK,
+				)
+			}
+		})
+	}
+}
+
+func TestDetailsOfStrongestAccessPoint(t *testing.T) {
+	t.Parallel()
+
+	available := NewAvailableNetworksModel(availableNetworksKeyMap{}, nil)
+	available.setAvailable([]AvailableNetwork{
+		{SSID: "Cafe", BSSID: "01", Signal: 30},
+		{SSID: "Cafe", BSSID: "02", Signal: 75},
+		{SSID: "Home", BSSID: "03", Signal: 90},
+	}, nil)
+
+	if got := available.detailsCmd("Cafe")(); got != openNetworkDetailsMsg("02") {
+		t.Errorf("detailsCmd(Cafe) = %#v, want the details of 02", got)
+	}
+	if _, ok := available.detailsCmd("Airport")().(openNetworkDetailsMsg); ok {
+		t.Error("detailsCmd() of a network out of range opens the details")
+	}
+}
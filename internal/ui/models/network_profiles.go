@@ -1,26 +1,51 @@
 package models
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/table"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/alphameo/nm-tui/internal/state"
 	"github.com/alphameo/nm-tui/internal/ui/styles"
 	"github.com/alphameo/nm-tui/internal/ui/tools/renderer"
 )
 
 type networkProfilesKeyMap struct {
-	edit       key.Binding
-	activate   key.Binding
-	deactivate key.Binding
-	delete     key.Binding
+	edit         key.Binding
+	activate     key.Binding
+	deactivate   key.Binding
+	delete       key.Binding
+	share        key.Binding
+	mark         key.Binding
+	markMatching key.Binding
+	invertMarks  key.Binding
+	clearMarks   key.Binding
+	bulkActions  key.Binding
+	filter       tableFilterKeyMap
+	sort         tableSortKeyMap
 }
 
 type NetworkProfilesModel struct {
+	profiles []NetworkProfileShort
+	// visible holds the profiles shown in the table, in row order.
+	visible []NetworkProfileShort
+	filter  tableFilter
+	sort    tableSort[NetworkProfileShort]
+	cues    rowCues[NetworkProfileShort]
+	clicks  clickTracker
+	// store persists the sort preference, nil disables it.
+	store *state.Store
+	// marked holds names of profiles selected for bulk actions.
+	marked map[string]bool
+
 	dataTable          table.Model
 	focusedTableStyles table.Styles
 	bluredTableStyles  table.Styles
@@ -36,11 +61,13 @@ type NetworkProfilesModel struct {
 }
 
 type networkProfilesConfig struct {
+	markColIdx int
 	connColIdx int
 	modeColIdx int
 	ssidColIdx int
 	nameColIdx int
 
+	connColTitle string
 	modeColTitle string
 	ssidColTitle string
 	nameColTitle string
@@ -48,12 +75,16 @@ type networkProfilesConfig struct {
 	ssidWidthProportion float32
 }
 
+const networkProfilesZoneID = "profiles"
+
 var networkProfilesCfg = networkProfilesConfig{
-	connColIdx: 0,
-	modeColIdx: 1,
-	ssidColIdx: 2,
-	nameColIdx: 3,
+	markColIdx: 0,
+	connColIdx: 1,
+	modeColIdx: 2,
+	ssidColIdx: 3,
+	nameColIdx: 4,
 
+	connColTitle: "State",
 	modeColTitle: "Mode",
 	ssidColTitle: "SSID",
 	nameColTitle: "Name",
@@ -62,10 +93,13 @@ var networkProfilesCfg = networkProfilesConfig{
 }
 
 func NewNetworkProfilesModel(keys networkProfilesKeyMap, networksManager infra.NetworksManager) *NetworkProfilesModel {
-	cols := make([]table.Column, 4)
+	cols := make([]table.Column, 5)
+	cols[networkProfilesCfg.markColIdx] = table.Column{
+		Width: lipgloss.Width(styles.SymbolMarked),
+	}
 	cols[networkProfilesCfg.connColIdx] = table.Column{
-		Title: "State",
-		Width: len("State"),
+		Title: networkProfilesCfg.connColTitle,
+		Width: len(networkProfilesCfg.connColTitle),
 	}
 	cols[networkProfilesCfg.modeColIdx] = table.Column{
 		Title: networkProfilesCfg.modeColTitle,
@@ -86,6 +120,10 @@ func NewNetworkProfilesModel(keys networkProfilesKeyMap, networksManager infra.N
 	)
 
 	model := &NetworkProfilesModel{
+		filter:             newTableFilter(keys.filter),
+		sort:               tableSort[NetworkProfileShort]{options: networkProfilesSortOptions()},
+		cues:               newRowCues(profileID, nil),
+		marked:             make(map[string]bool),
 		dataTable:          t,
 		focusedTableStyles: table.DefaultStyles(),
 		bluredTableStyles:  table.DefaultStyles(),
@@ -99,6 +137,67 @@ func NewNetworkProfilesModel(keys networkProfilesKeyMap, networksManager infra.N
 	return model
 }
 
+// restyle applies the current styles and symbols to the filter and to the
+// rendered rows.
+func (m *NetworkProfilesModel) restyle() {
+	restyleInputs(&m.filter.input)
+	m.updateHeaders()
+	m.updateRows()
+}
+
+func networkProfilesSortOptions() []sortOption[NetworkProfileShort] {
+	return []sortOption[NetworkProfileShort]{
+		{
+			name:    "name",
+			colIdx:  networkProfilesCfg.nameColIdx,
+			compare: func(a, b NetworkProfileShort) int { return compareFold(a.Name, b.Name) },
+		},
+		{
+			name:    "ssid",
+			colIdx:  networkProfilesCfg.ssidColIdx,
+			compare: func(a, b NetworkProfileShort) int { return compareFold(a.SSID, b.SSID) },
+		},
+		{
+			name:    "mode",
+			colIdx:  networkProfilesCfg.modeColIdx,
+			compare: func(a, b NetworkProfileShort) int { return cmp.Compare(a.Mode, b.Mode) },
+		},
+		{
+			name:   "active",
+			colIdx: networkProfilesCfg.connColIdx,
+			compare: func(a, b NetworkProfileShort) int {
+				rank := func(p NetworkProfileShort) int {
+					switch {
+					case p.Active:
+						return 2
+					case p.Available:
+						return 1
+					}
+					return 0
+				}
+				return cmp.Compare(rank(a), rank(b))
+			},
+			descending: true,
+		},
+		{
+			name:   "priority",
+			title:  "priority",
+			colIdx: -1,
+			compare: func(a, b NetworkProfileShort) int {
+				return cmp.Compare(a.AutoconnectPriority, b.AutoconnectPriority)
+			},
+			descending: true,
+		},
+		{
+			name:       "last_used",
+			title:      "last used",
+			colIdx:     -1,
+			compare:    func(a, b NetworkProfileShort) int { return a.LastUsed.Compare(b.LastUsed) },
+			descending: true,
+		},
+	}
+}
+
 func (m *NetworkProfilesModel) Resize(width, height int) {
 	m.focusedStyle = m.focusedStyle.Width(width).Height(height)
 	m.bluredStyle = m.bluredStyle.Width(width).Height(height)
@@ -111,10 +210,11 @@ func (m *NetworkProfilesModel) Resize(width, height int) {
 	m.dataTable.SetHeight(height)
 
 	tableUtilityOffset := len(m.dataTable.Columns()) * 2
+	markWidth := m.dataTable.Columns()[networkProfilesCfg.markColIdx].Width
 	connWidth := m.dataTable.Columns()[networkProfilesCfg.connColIdx].Width
 	modeWidth := m.dataTable.Columns()[networkProfilesCfg.modeColIdx].Width
 
-	computedWidth := width - tableUtilityOffset - connWidth - modeWidth
+	computedWidth := width - tableUtilityOffset - markWidth - connWidth - modeWidth
 	possibleNameWidth := int(float32(computedWidth) * networkProfilesCfg.ssidWidthProportion)
 	ssidWidth := computedWidth - possibleNameWidth
 	nameWidth := computedWidth - ssidWidth
@@ -171,24 +271,103 @@ func (m *NetworkProfilesModel) Update(msg tea.Msg) (*NetworkProfilesModel, tea.C
 		if !m.focus {
 			return m, nil
 		}
+		if key.Matches(msg, m.keys.sort.cycle) && !m.filter.Editing() {
+			m.sort.cycle()
+			m.updateHeaders()
+			m.updateRows()
+			return m, m.saveSortCmd()
+		}
+		if key.Matches(msg, m.keys.sort.reverse) && !m.filter.Editing() {
+			m.sort.reverse()
+			m.updateHeaders()
+			m.updateRows()
+			return m, m.saveSortCmd()
+		}
+		if m.filter.Editing() || key.Matches(msg, m.keys.filter.open) {
+			cmd, changed := m.filter.Update(msg)
+			if changed {
+				m.updateRows()
+			}
+			return m, cmd
+		}
 		switch {
 		case key.Matches(msg, m.keys.edit):
-			row := m.dataTable.SelectedRow()
-			if row == nil {
+			profile, ok := m.selected()
+			if !ok {
 				return m, nil
 			}
-			name := row[networkProfilesCfg.nameColIdx]
-
-			return m, OpenProfileEditorCmd(name)
+			return m, OpenProfileEditorCmd(profile.Name)
 
 		case key.Matches(msg, m.keys.activate):
-			return m, m.activateConnToSelectedCmd()
+			profile, ok := m.selected()
+			if !ok {
+				return m, nil
+			}
+			return m, m.activateConnCmd(profile.Name)
 
 		case key.Matches(msg, m.keys.deactivate):
-			return m, m.deactivateConnToSelectedCmd()
+			profile, ok := m.selected()
+			if !ok {
+				return m, nil
+			}
+			return m, m.deactivateConnCmd(profile.Name)
 		case key.Matches(msg, m.keys.delete):
-			return m, m.deleteSelectedCmd()
+			profile, ok := m.selected()
+			if !ok {
+				return m, nil
+			}
+			return m, m.deleteCmd(profile.Name)
+		case key.Matches(msg, m.keys.share):
+			profile, ok := m.selected()
+			if !ok {
+				return m, nil
+			}
+			return m, OpenWifiShareCmd(profile.Name)
+		case key.Matches(msg, m.keys.mark):
+			profile, ok := m.selected()
+			if !ok {
+				return m, nil
+			}
+			m.toggleMark(profile.Name)
+			m.dataTable.MoveDown(1)
+			return m, nil
+		case key.Matches(msg, m.keys.markMatching):
+			return m, OpenMarkMatchingCmd()
+		case key.Matches(msg, m.keys.invertMarks):
+			m.invertMarks()
+			return m, nil
+		case key.Matches(msg, m.keys.clearMarks):
+			clear(m.marked)
+			m.updateRows()
+			return m, nil
+		case key.Matches(msg, m.keys.bulkActions):
+			names := m.bulkTargets()
+			if len(names) == 0 {
+				return m, nil
+			}
+			return m, OpenBulkActionsCmd(names)
+		}
+	}
+	switch msg := msg.(type) {
+	case markMatchingMsg:
+		m.markMatching(string(msg))
+		return m, nil
+	case clearMarksMsg:
+		clear(m.marked)
+		m.updateRows()
+		return m, nil
+	case rowCuesExpiredMsg:
+		m.cues.prune(time.Now())
+		m.updateRows()
+		return m, nil
+	case tea.MouseClickMsg, tea.MouseWheelMsg:
+		if !clickTableRow(&m.dataTable, networkProfilesZoneID, msg) {
+			return m, nil
+		}
+		if profile, ok := m.selected(); ok && m.clicks.click(profileID(profile), time.Now()) {
+			return m, m.activateConnCmd(profile.Name)
 		}
+		return m, nil
 	}
 
 	var cmd tea.Cmd
@@ -197,98 +376,265 @@ func (m *NetworkProfilesModel) Update(msg tea.Msg) (*NetworkProfilesModel, tea.C
 	m.dataTable, cmd = m.dataTable.Update(msg)
 	cmds = append(cmds, cmd)
 
+	if m.filter.Editing() {
+		cmd, _ = m.filter.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
 	return m, tea.Batch(cmds...)
 }
 
 func (m *NetworkProfilesModel) View() string {
 	view := m.dataTable.View()
 
+	title := m.filter.Title("Network profiles" + m.sort.titleSuffix())
+	if len(m.marked) > 0 {
+		title = fmt.Sprintf("%s (%d marked)", title, len(m.marked))
+	}
 	style := m.activeStyle()
 	view = renderer.RenderWithTitleAndKeybind(
 		view,
-		"Network profiles",
+		title,
 		"2",
 		*style,
 		styles.AccentColor,
 	)
-	return view
+	return zones.Mark(networkProfilesZoneID, view)
 }
 
 func (m *NetworkProfilesModel) setProfiles(list []NetworkProfileShort, err error) tea.Cmd {
+	m.profiles = list
+
+	present := make(map[string]bool, len(list))
+	for _, p := range list {
+		present[p.Name] = true
+	}
+	for name := range m.marked {
+		if !present[name] {
+			delete(m.marked, name)
+		}
+	}
+	cuesCmd := m.cues.update(list, time.Now())
+	m.updateRows()
+
+	cmds := []tea.Cmd{SetNetworksStateCmd(NetsDone), cuesCmd}
+	if err != nil {
+		cmds = append(cmds, NotifyErrorCmd("Cannot get network profiles"))
+	}
+	return tea.Batch(cmds...)
+}
+
+// updateRows fills the table with the profiles matching the filter query,
+// highlighting the matched runes of SSID and name. Profiles gone since recent
+// rescans stay below as struck out rows until their cues expire. The cursor
+// follows the selected profile wherever it moved.
+func (m *NetworkProfilesModel) updateRows() {
+	selected, hadSelection := m.selected()
+	now := time.Now()
+
+	m.visible = nil
 	rows := []table.Row{}
-	for _, wifiSaved := range list {
+	for _, wifiSaved := range m.sort.apply(m.profiles) {
+		matches, ok := filterMatch(m.filter.Query(), wifiSaved.SSID, wifiSaved.Name)
+		if !ok {
+			continue
+		}
+		var markFlag string
+		if m.marked[wifiSaved.Name] {
+			markFlag = styles.SymbolMarked
+		}
 		var connectionFlag string
 		if wifiSaved.Active {
 			connectionFlag = styles.SymbolCheck
 		} else if wifiSaved.Available {
 			connectionFlag = styles.SymbolAvailable
 		}
+		base := lipgloss.NewStyle()
+		if m.cues.change(profileID(wifiSaved), now) == rowAppeared {
+			base = styles.CueAppearedStyle
+		}
+		m.visible = append(m.visible, wifiSaved)
 		rows = append(rows, table.Row{
+			markRow(networkProfilesZoneID, len(rows), markFlag),
 			connectionFlag,
 			wifiSaved.Mode,
-			wifiSaved.SSID,
-			wifiSaved.Name,
+			highlightMatches(wifiSaved.SSID, matches[0], base),
+			highlightMatches(wifiSaved.Name, matches[1], base),
+		})
+	}
+	for _, gone := range m.cues.vanished(now) {
+		if _, ok := filterMatch(m.filter.Query(), gone.SSID, gone.Name); !ok {
+			continue
+		}
+		rows = append(rows, table.Row{
+			markRow(networkProfilesZoneID, len(rows), ""),
+			"",
+			styles.CueVanishedStyle.Render(gone.Mode),
+			styles.CueVanishedStyle.Render(gone.SSID),
+			styles.CueVanishedStyle.Render(gone.Name),
 		})
 	}
 
 	m.dataTable.SetRows(rows)
+	if hadSelection {
+		id := profileID(selected)
+		if idx := slices.IndexFunc(m.visible, func(p NetworkProfileShort) bool { return profileID(p) == id }); idx >= 0 {
+			m.dataTable.SetCursor(idx)
+		}
+	}
+}
 
-	cmds := []tea.Cmd{SetNetworksStateCmd(NetsDone)}
-	if err != nil {
-		cmds = append(cmds, NotifyCmd("Cannot get network profiles"))
+// profileID identifies a profile across rescans, surviving renames.
+func profileID(p NetworkProfileShort) string {
+	if p.UUID != "" {
+		return p.UUID
 	}
-	return tea.Batch(cmds...)
+	return p.Name
+}
+
+// updateHeaders shows the sort indicator and fits the fixed width columns to
+// their titles.
+func (m *NetworkProfilesModel) updateHeaders() {
+	cols := m.dataTable.Columns()
+	titles := make([]string, len(cols))
+	titles[networkProfilesCfg.connColIdx] = networkProfilesCfg.connColTitle
+	titles[networkProfilesCfg.modeColIdx] = networkProfilesCfg.modeColTitle
+	titles[networkProfilesCfg.ssidColIdx] = networkProfilesCfg.ssidColTitle
+	titles[networkProfilesCfg.nameColIdx] = networkProfilesCfg.nameColTitle
+	m.sort.setHeaders(cols, titles)
+
+	for _, idx := range []int{networkProfilesCfg.connColIdx, networkProfilesCfg.modeColIdx} {
+		cols[idx].Width = lipgloss.Width(cols[idx].Title)
+	}
+	cols[networkProfilesCfg.markColIdx].Width = lipgloss.Width(styles.SymbolMarked)
+	if m.Width() > 0 {
+		m.Resize(m.Width(), m.Height())
+	}
+}
+
+func (m *NetworkProfilesModel) restoreSort(pref state.Sort) {
+	m.sort.restore(pref)
+	m.updateHeaders()
+	m.updateRows()
+}
+
+func (m *NetworkProfilesModel) saveSortCmd() tea.Cmd {
+	if m.store == nil {
+		return nil
+	}
+	pref := m.sort.pref()
+	return func() tea.Msg {
+		err := m.store.Update(func(s *state.State) { s.ProfilesSort = pref })
+		if err != nil {
+			return NotifyErrorCmd(fmt.Sprintf("Cannot save sort preference:\n%v", err))
+		}
+		return NilMsg{}
+	}
+}
+
+func (m *NetworkProfilesModel) selected() (NetworkProfileShort, bool) {
+	cursor := m.dataTable.Cursor()
+	if cursor < 0 || cursor >= len(m.visible) {
+		return NetworkProfileShort{}, false
+	}
+	return m.visible[cursor], true
 }
 
-func (m *NetworkProfilesModel) activateConnToSelectedCmd() tea.Cmd {
+func (m *NetworkProfilesModel) toggleMark(name string) {
+	if m.marked[name] {
+		delete(m.marked, name)
+	} else {
+		m.marked[name] = true
+	}
+	m.updateRows()
+}
+
+// invertMarks inverts marks of the profiles passing the filter.
+func (m *NetworkProfilesModel) invertMarks() {
+	for _, p := range m.visible {
+		m.marked[p.Name] = !m.marked[p.Name]
+		if !m.marked[p.Name] {
+			delete(m.marked, p.Name)
+		}
+	}
+	m.updateRows()
+}
+
+// markMatching marks profiles whose name or SSID contains pattern, ignoring
+// case. Already marked profiles stay marked.
+func (m *NetworkProfilesModel) markMatching(pattern string) {
+	pattern = strings.ToLower(pattern)
+	for _, p := range m.profiles {
+		if strings.Contains(strings.ToLower(p.Name), pattern) ||
+			strings.Contains(strings.ToLower(p.SSID), pattern) {
+			m.marked[p.Name] = true
+		}
+	}
+	m.updateRows()
+}
+
+// bulkTargets returns the marked profiles in table order, including the ones
+// the filter hides, or the selected one when nothing is marked.
+func (m *NetworkProfilesModel) bulkTargets() []string {
+	var names []string
+	for _, p := range m.sort.apply(m.profiles) {
+		if m.marked[p.Name] {
+			names = append(names, p.Name)
+		}
+	}
+	if len(names) > 0 {
+		return names
+	}
+	if profile, ok := m.selected(); ok {
+		return []string{profile.Name}
+	}
+	return nil
+}
+
+func (m *NetworkProfilesModel) activateConnCmd(name string) tea.Cmd {
 	return tea.Sequence(
 		SetNetworksStateCmd(NetsActivating),
 		func() tea.Msg {
-			name := m.dataTable.SelectedRow()[networkProfilesCfg.nameColIdx]
 			err := m.netMngr.ActivateProfile(context.Background(), name)
 			if err != nil {
 				return tea.Batch(
 					SetNetworksStateCmd(NetsDone),
-					NotifyCmd(fmt.Sprintf("Cannot connect to %q", name)),
+					NotifyErrorCmd(fmt.Sprintf("Cannot connect to %q", name)),
 				)
 			}
 			return tea.Batch(
 				SetNetworksStateCmd(NetsDone),
-				m.gotoTop(),
 				RescanNetworksCmd(),
+				networkActivatedCmd(name),
 			)
 		},
 	)
 }
 
-func (m *NetworkProfilesModel) deactivateConnToSelectedCmd() tea.Cmd {
+func (m *NetworkProfilesModel) deactivateConnCmd(name string) tea.Cmd {
 	return tea.Sequence(SetNetworksStateCmd(NetsDeactivating),
 		func() tea.Msg {
-			name := m.dataTable.SelectedRow()[networkProfilesCfg.nameColIdx]
 			err := m.netMngr.DeactivateProfile(context.Background(), name)
 			if err != nil {
 				return tea.Batch(
 					SetNetworksStateCmd(NetsDone),
-					NotifyCmd(
+					NotifyErrorCmd(
 						fmt.Sprintf("Error while deactivating connection with %q", name),
 					),
 				)
 			}
 			return tea.Batch(
 				SetNetworksStateCmd(NetsDone),
-				m.gotoTop(),
 				RescanNetworksCmd(),
 			)
 		})
 }
 
-func (m *NetworkProfilesModel) deleteSelectedCmd() tea.Cmd {
-	row := m.dataTable.SelectedRow()
+func (m *NetworkProfilesModel) deleteCmd(name string) tea.Cmd {
 	return func() tea.Msg {
-		name := row[networkProfilesCfg.nameColIdx]
 		err := m.netMngr.DeleteProfile(context.Background(), name)
 		if err != nil {
-			return NotifyCmd(fmt.Sprintf("Error while deleting profile %q", name))
+			return NotifyErrorCmd(fmt.Sprintf("Error while deleting profile %q", name))
 		}
 		cursor := m.dataTable.Cursor()
 		if cursor == len(m.dataTable.Rows())-1 {
@@ -298,9 +644,12 @@ func (m *NetworkProfilesModel) deleteSelectedCmd() tea.Cmd {
 	}
 }
 
-func (m *NetworkProfilesModel) gotoTop() tea.Cmd {
-	return func() tea.Msg {
-		m.dataTable.GotoTop()
-		return NilCmd()
+// activeHotspot returns the name of the active access point profile.
+func (m *NetworkProfilesModel) activeHotspot() (string, bool) {
+	for _, profile := range m.profiles {
+		if profile.Hotspot && profile.Active {
+			return profile.Name, true
+		}
 	}
+	return "", false
 }
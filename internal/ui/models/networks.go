@@ -3,6 +3,7 @@ package models
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/spinner"
@@ -24,6 +25,9 @@ type networksKeyMap struct {
 	openCaptivePortal key.Binding
 	quickHotspot      key.Binding
 	createHotspot     key.Binding
+	shareHotspot      key.Binding
+	exportProfiles    key.Binding
+	importProfiles    key.Binding
 }
 
 type networksState int
@@ -35,6 +39,10 @@ const (
 	NetsDeactivating
 	NetsConnecting
 	NetsCreating
+	NetsExporting
+	NetsRestoring
+	NetsDeleting
+	NetsUpdating
 	NetsDone
 )
 
@@ -50,6 +58,14 @@ func (s *networksState) String() string {
 		return "Connecting"
 	case NetsCreating:
 		return "Creating Connection Profile"
+	case NetsExporting:
+		return "Exporting Profiles"
+	case NetsRestoring:
+		return "Restoring Profiles"
+	case NetsDeleting:
+		return "Deleting Profiles"
+	case NetsUpdating:
+		return "Updating Profiles"
 	case NetsDone:
 		return styles.SymbolCheck
 	default:
@@ -63,7 +79,9 @@ type NetworksModel struct {
 
 	indicatorSpinner spinner.Model
 	indicatorState   networksState
-	IndicatorStyle   lipgloss.Style
+	// indicatorProgress counts processed items of a long running operation.
+	indicatorProgress NetworksProgressMsg
+	IndicatorStyle    lipgloss.Style
 
 	focus bool
 
@@ -103,6 +121,13 @@ func NewNetworksModel(
 	return w
 }
 
+// restyle applies the current styles to the indicator and both tables.
+func (m *NetworksModel) restyle() {
+	restyleSpinner(&m.indicatorSpinner)
+	m.available.restyle()
+	m.profiles.restyle()
+}
+
 func (m *NetworksModel) Resize(width, height int) {
 	m.Style = m.Style.Width(width).Height(height)
 
@@ -146,6 +171,9 @@ func (m *NetworksModel) Update(msg tea.Msg) (*NetworksModel, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
+		if m.capturesInput() {
+			break
+		}
 		switch {
 		case key.Matches(msg, m.keys.winNext):
 			return m, m.focuses.FocusCycleNextCmd()
@@ -165,20 +193,38 @@ func (m *NetworksModel) Update(msg tea.Msg) (*NetworksModel, tea.Cmd) {
 		case key.Matches(msg, m.keys.createHotspot):
 			return m, OpenHotspotCreatorCmd()
 		case key.Matches(msg, m.keys.openCaptivePortal):
-			return m, func() tea.Msg {
-				err := m.portal.OpenCaptivePortal(context.Background())
-				if err != nil {
-					return NotifyCmd("Failed open captive portal")
-				}
-				return NotifyCmd("Opening captive portal")
-			}
+			return m, m.openCaptivePortalCmd()
 		case key.Matches(msg, m.keys.quickHotspot):
 			return m, m.quickHotspot()
+		case key.Matches(msg, m.keys.shareHotspot):
+			name, ok := m.profiles.activeHotspot()
+			if !ok {
+				return m, NotifyWarningCmd("No active hotspot to share")
+			}
+			return m, OpenWifiShareCmd(name)
+		case key.Matches(msg, m.keys.exportProfiles):
+			return m, OpenBackupExportCmd()
+		case key.Matches(msg, m.keys.importProfiles):
+			return m, OpenBackupImportCmd()
 		}
 	case RescanNetworksMsg:
 		return m, m.rescanCmd()
 	case NetworksStateMsg:
 		return m, m.setStateCmd(networksState(msg))
+	case NetworksProgressMsg:
+		m.indicatorProgress = msg
+		return m, nil
+	case tea.MouseClickMsg:
+		var focusCmd, cmd tea.Cmd
+		switch {
+		case zones.Contains(availableNetworksZoneID, msg.X, msg.Y):
+			focusCmd = m.focuses.SetFocusIdx(0)
+			m.available, cmd = m.available.Update(msg)
+		case zones.Contains(networkProfilesZoneID, msg.X, msg.Y):
+			focusCmd = m.focuses.SetFocusIdx(1)
+			m.profiles, cmd = m.profiles.Update(msg)
+		}
+		return m, tea.Batch(focusCmd, cmd)
 	}
 
 	var cmds []tea.Cmd
@@ -198,6 +244,12 @@ func (m *NetworksModel) Update(msg tea.Msg) (*NetworksModel, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// capturesInput reports whether a table is editing its filter query and needs
+// every key press, including the global ones.
+func (m *NetworksModel) capturesInput() bool {
+	return m.focus && (m.available.filter.Editing() || m.profiles.filter.Editing())
+}
+
 func (m *NetworksModel) UpdateAsTab(msg tea.Msg) (tabview.TabModel, tea.Cmd) {
 	return m.Update(msg)
 }
@@ -219,9 +271,13 @@ func (m *NetworksModel) View() string {
 func (m *NetworksModel) indicatorView() string {
 	var view string
 	if m.indicatorState != NetsDone {
+		state := m.indicatorState.String()
+		if p := m.indicatorProgress; p.Total > 0 {
+			state = fmt.Sprintf("%s %d/%d", state, p.Done, p.Total)
+		}
 		view = fmt.Sprintf(
 			"%s %s",
-			m.indicatorState.String(),
+			state,
 			m.indicatorSpinner.View(),
 		)
 	} else {
@@ -235,6 +291,7 @@ type NetworksStateMsg networksState
 func (m *NetworksModel) setStateCmd(state networksState) tea.Cmd {
 	updCmd := func() tea.Msg {
 		m.indicatorState = state
+		m.indicatorProgress = NetworksProgressMsg{}
 		return NilMsg{}
 	}
 
@@ -250,6 +307,19 @@ func SetNetworksStateCmd(state networksState) tea.Cmd {
 	}
 }
 
+// NetworksProgressMsg reports how many of Total items the current operation
+// has processed.
+type NetworksProgressMsg struct {
+	Done  int
+	Total int
+}
+
+func SetNetworksProgressCmd(done, total int) tea.Cmd {
+	return func() tea.Msg {
+		return NetworksProgressMsg{Done: done, Total: total}
+	}
+}
+
 type RescanNetworksMsg struct{}
 
 func RescanNetworksCmd() tea.Cmd {
@@ -309,7 +379,7 @@ func (m *NetworksModel) quickHotspot() tea.Cmd {
 	return func() tea.Msg {
 		err := m.netMngr.QuickHotspot(context.Background())
 		if err != nil {
-			return NotifyCmd(fmt.Sprintf("Failed enabling quick wifi hotspot:\n%v", err))
+			return NotifyErrorCmd(fmt.Sprintf("Failed enabling quick wifi hotspot:\n%v", err))
 		}
 		return RescanNetworksCmd()
 	}
@@ -320,15 +390,20 @@ type AvailableNetwork struct {
 	Active        bool
 	Security      string
 	Signal        int
+	BSSID         string
 	ProfileExists bool
 }
 
 type NetworkProfileShort struct {
-	Name      string
-	SSID      string
-	Active    bool
-	Mode      string
-	Available bool
+	Name                string
+	UUID                string
+	SSID                string
+	Active              bool
+	Mode                string
+	Hotspot             bool
+	Available           bool
+	AutoconnectPriority int
+	LastUsed            time.Time
 }
 
 // CrossReferenceNetworks matches available networks and saved profiles by SSID:
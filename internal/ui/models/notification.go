@@ -7,17 +7,33 @@ import (
 	"charm.land/lipgloss/v2"
 )
 
+type notificationLevel int
+
+const (
+	notificationInfo notificationLevel = iota
+	notificationWarning
+	notificationError
+	notificationLevelCount
+)
+
 type Notification struct {
 	message   string
+	level     notificationLevel
 	active    bool
 	title     string
 	closeTime time.Duration
-	style     lipgloss.Style
+	// styles holds a style per level.
+	styles [notificationLevelCount]lipgloss.Style
+}
+
+func (n *Notification) style() lipgloss.Style {
+	return n.styles[n.level]
 }
 
 type (
 	NotificationTextMsg     string
 	NotificationActivityMsg bool
+	notificationLevelMsg    notificationLevel
 )
 
 func SetNotificationTextCmd(text string) tea.Cmd {
@@ -33,7 +49,20 @@ func SetNotificationActivityCmd(isActive bool) tea.Cmd {
 }
 
 func NotifyCmd(text string) tea.Cmd {
+	return notifyCmd(notificationInfo, text)
+}
+
+func NotifyWarningCmd(text string) tea.Cmd {
+	return notifyCmd(notificationWarning, text)
+}
+
+func NotifyErrorCmd(text string) tea.Cmd {
+	return notifyCmd(notificationError, text)
+}
+
+func notifyCmd(level notificationLevel, text string) tea.Cmd {
 	return tea.Sequence(
+		func() tea.Msg { return notificationLevelMsg(level) },
 		SetNotificationTextCmd(text),
 		SetNotificationActivityCmd(true),
 	)
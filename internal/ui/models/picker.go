@@ -0,0 +1,454 @@
+package models
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/spinner"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/alphameo/nm-tui/internal/config"
+	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/alphameo/nm-tui/internal/ui/styles"
+	"github.com/alphameo/nm-tui/internal/ui/tools/fuzzy"
+	"github.com/charmbracelet/x/ansi"
+)
+
+type pickerConfig struct {
+	// inlineRows is the number of rows shown without the alt screen.
+	inlineRows int
+	maxWidth   int
+}
+
+var pickerCfg = pickerConfig{
+	inlineRows: 8,
+	maxWidth:   80,
+}
+
+type pickerKeyMap struct {
+	prev               key.Binding
+	next               key.Binding
+	pick               key.Binding
+	cancel             key.Binding
+	togglePWVisibility key.Binding
+}
+
+type pickerState int
+
+const (
+	pickerLoading pickerState = iota
+	pickerPicking
+	pickerPassword
+	pickerConnecting
+	pickerDone
+)
+
+// pickerEntry is a network in range, merged with its saved profile, or a
+// saved profile out of range.
+type pickerEntry struct {
+	label string
+	ssid  string
+	// profile is the profile to activate, empty to connect by SSID.
+	profile   string
+	signal    int
+	security  string
+	available bool
+	saved     bool
+	active    bool
+	hotspot   bool
+}
+
+// needsPassword reports whether connecting asks for a password: the network
+// is secured and no saved profile holds its secret.
+func (e *pickerEntry) needsPassword() bool {
+	return e.profile == "" && !e.saved && e.security != "" && e.security != "--"
+}
+
+// pickerEntries lists the networks in range, strongest first, then the
+// saved profiles they don't cover, most recently used first.
+func pickerEntries(available []AvailableNetwork, profiles []NetworkProfileShort) []pickerEntry {
+	available, profiles = CrossReferenceNetworks(available, profiles)
+
+	var entries []pickerEntry
+	seen := make(map[string]int, len(available))
+	for _, n := range available {
+		if n.SSID == "" {
+			continue
+		}
+		// Access points of one network are listed once, at their best.
+		if i, ok := seen[n.SSID]; ok {
+			e := &entries[i]
+			e.signal = max(e.signal, n.Signal)
+			e.active = e.active || n.Active
+			continue
+		}
+		seen[n.SSID] = len(entries)
+		entries = append(entries, pickerEntry{
+			label:     n.SSID,
+			ssid:      n.SSID,
+			signal:    n.Signal,
+			security:  n.Security,
+			available: true,
+			saved:     n.ProfileExists,
+			active:    n.Active,
+		})
+	}
+	slices.SortStableFunc(entries, func(a, b pickerEntry) int {
+		return cmp.Compare(b.signal, a.signal)
+	})
+
+	profiles = slices.Clone(profiles)
+	slices.SortStableFunc(profiles, func(a, b NetworkProfileShort) int {
+		return b.LastUsed.Compare(a.LastUsed)
+	})
+	for _, p := range profiles {
+		if p.Available && !p.Hotspot {
+			continue
+		}
+		entries = append(entries, pickerEntry{
+			label:   p.Name,
+			ssid:    p.SSID,
+			profile: p.Name,
+			saved:   true,
+			active:  p.Active,
+			hotspot: p.Hotspot,
+		})
+	}
+	return entries
+}
+
+type pickerRow struct {
+	entry     *pickerEntry
+	positions []int
+	score     int
+}
+
+type pickerLoadedMsg struct {
+	available []infra.AvailableNetwork
+	profiles  []infra.NetworkProfileShort
+	err       error
+}
+
+type pickerConnectedMsg struct {
+	err error
+}
+
+// PickerResult is the outcome of the picker.
+type PickerResult struct {
+	// Picked is the network or profile chosen, empty when cancelled.
+	Picked string
+	Err    error
+}
+
+// PickerModel is a single fuzzy-filtered list of networks and profiles.
+// Picking one connects to it, asking for the password inline when needed,
+// and quits.
+type PickerModel struct {
+	state    pickerState
+	entries  []pickerEntry
+	rows     []pickerRow
+	cursor   int
+	offset   int
+	picked   *pickerEntry
+	result   PickerResult
+	inline   bool
+	width    int
+	height   int
+	netMngr  infra.NetworksManager
+	query    textinput.Model
+	password textinput.Model
+	spinner  spinner.Model
+
+	keys pickerKeyMap
+}
+
+// NewPickerModel returns the picker. inline renders it below the prompt
+// instead of on the alt screen, for small terminal popups.
+func NewPickerModel(networksManager infra.NetworksManager, cfg config.Config, inline bool) (*PickerModel, error) {
+	if err := styles.Init(cfg); err != nil {
+		return nil, fmt.Errorf("style initialization: %w", err)
+	}
+	query := newDefaultInput()
+	query.Prompt = "> "
+	query.Placeholder = "Network or profile"
+
+	return &PickerModel{
+		inline:   inline,
+		netMngr:  networksManager,
+		query:    query,
+		password: newDefaultPasswordInput(),
+		spinner:  newDefaultSpinner(),
+		keys:     initKeys(*cfg.Keys).picker,
+	}, nil
+}
+
+// Result returns the outcome once the picker quit.
+func (m *PickerModel) Result() PickerResult {
+	return m.result
+}
+
+func (m *PickerModel) Init() tea.Cmd {
+	return tea.Batch(m.query.Focus(), m.spinner.Tick, m.loadCmd())
+}
+
+func (m *PickerModel) loadCmd() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		available, err := m.netMngr.ListNetworks(ctx)
+		if err != nil {
+			return pickerLoadedMsg{err: err}
+		}
+		profiles, err := m.netMngr.ListProfiles(ctx)
+		return pickerLoadedMsg{available: available, profiles: profiles, err: err}
+	}
+}
+
+func (m *PickerModel) connectCmd(e *pickerEntry, password string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		var err error
+		switch {
+		case e.profile != "":
+			err = m.netMngr.ActivateProfile(ctx, e.profile)
+		case password != "":
+			err = m.netMngr.ConnectToNetwork(ctx, e.ssid, password)
+		default:
+			err = m.netMngr.TryActivateNetwork(ctx, e.ssid)
+		}
+		return pickerConnectedMsg{err: err}
+	}
+}
+
+func (m *PickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.query.SetWidth(max(min(m.width, pickerCfg.maxWidth)-4, 10))
+		return m, nil
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	case pickerLoadedMsg:
+		if msg.err != nil {
+			return m.quit(PickerResult{Err: msg.err})
+		}
+		m.entries = pickerEntries(
+			convertAvailableNetworks(msg.available),
+			convertNetworkProfileShorts(msg.profiles),
+		)
+		m.state = pickerPicking
+		m.matchRows()
+		return m, nil
+	case pickerConnectedMsg:
+		return m.quit(PickerResult{Picked: m.picked.label, Err: msg.err})
+	case tea.KeyPressMsg:
+		if msg.String() == "ctrl+c" {
+			return m.quit(PickerResult{})
+		}
+		switch m.state {
+		case pickerPicking:
+			return m.updatePicking(msg)
+		case pickerPassword:
+			return m.updatePassword(msg)
+		case pickerLoading:
+			if key.Matches(msg, m.keys.cancel) {
+				return m.quit(PickerResult{})
+			}
+		}
+		return m, nil
+	}
+
+	// The focused input still needs its cursor blinks.
+	var cmd tea.Cmd
+	if m.state == pickerPassword {
+		m.password, cmd = m.password.Update(msg)
+	} else {
+		m.query, cmd = m.query.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m *PickerModel) updatePicking(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.cancel):
+		return m.quit(PickerResult{})
+	case key.Matches(msg, m.keys.prev):
+		m.moveCursor(-1)
+		return m, nil
+	case key.Matches(msg, m.keys.next):
+		m.moveCursor(1)
+		return m, nil
+	case key.Matches(msg, m.keys.pick):
+		if len(m.rows) == 0 {
+			return m, nil
+		}
+		m.picked = m.rows[m.cursor].entry
+		if m.picked.needsPassword() {
+			m.state = pickerPassword
+			m.password.Reset()
+			m.password.Err = passwordValidator("")
+			m.query.Blur()
+			return m, m.password.Focus()
+		}
+		m.state = pickerConnecting
+		return m, m.connectCmd(m.picked, "")
+	}
+
+	query := m.query.Value()
+	var cmd tea.Cmd
+	m.query, cmd = m.query.Update(msg)
+	if m.query.Value() != query {
+		m.matchRows()
+	}
+	return m, cmd
+}
+
+func (m *PickerModel) updatePassword(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.cancel):
+		m.state = pickerPicking
+		m.password.Blur()
+		return m, m.query.Focus()
+	case key.Matches(msg, m.keys.togglePWVisibility):
+		if m.password.EchoMode == textinput.EchoPassword {
+			m.password.EchoMode = textinput.EchoNormal
+		} else {
+			m.password.EchoMode = textinput.EchoPassword
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.pick):
+		if m.password.Err != nil {
+			return m, nil
+		}
+		m.state = pickerConnecting
+		m.password.Blur()
+		return m, m.connectCmd(m.picked, m.password.Value())
+	}
+
+	var cmd tea.Cmd
+	m.password, cmd = m.password.Update(msg)
+	return m, cmd
+}
+
+func (m *PickerModel) quit(result PickerResult) (tea.Model, tea.Cmd) {
+	m.state = pickerDone
+	m.result = result
+	return m, tea.Quit
+}
+
+// matchRows lists the entries matching the query, best matches first.
+func (m *PickerModel) matchRows() {
+	query := strings.TrimSpace(m.query.Value())
+	var rows []pickerRow
+	for i := range m.entries {
+		e := &m.entries[i]
+		if positions, score, ok := fuzzy.Match(query, e.label); ok {
+			rows = append(rows, pickerRow{entry: e, positions: positions, score: score})
+		}
+	}
+	slices.SortStableFunc(rows, func(a, b pickerRow) int {
+		return cmp.Compare(b.score, a.score)
+	})
+	m.rows = rows
+	m.cursor = 0
+	m.offset = 0
+}
+
+// maxRows is the number of rows fitting under the query.
+func (m *PickerModel) maxRows() int {
+	if m.inline || m.height == 0 {
+		return pickerCfg.inlineRows
+	}
+	return max(m.height-2, 1)
+}
+
+func (m *PickerModel) moveCursor(delta int) {
+	if len(m.rows) == 0 {
+		return
+	}
+	m.cursor = max(min(m.cursor+delta, len(m.rows)-1), 0)
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+m.maxRows() {
+		m.offset = m.cursor - m.maxRows() + 1
+	}
+}
+
+func (m *PickerModel) View() tea.View {
+	v := tea.NewView(m.content())
+	v.AltScreen = !m.inline
+	return v
+}
+
+func (m *PickerModel) content() string {
+	switch m.state {
+	case pickerDone:
+		// An empty frame leaves no trace of an inline picker.
+		return ""
+	case pickerLoading:
+		return m.spinner.View() + " " + styles.MutedStyle.Render("Loading networks")
+	case pickerConnecting:
+		return m.spinner.View() + " " + styles.DefaultStyle.Render("Connecting to "+m.picked.label)
+	case pickerPassword:
+		prompt := styles.DefaultStyle.Render("Password for " + m.picked.label + " ")
+		return lipgloss.JoinHorizontal(lipgloss.Center, prompt, m.password.View())
+	}
+
+	width := pickerCfg.maxWidth
+	if m.width > 0 {
+		width = min(m.width, width)
+	}
+	lines := []string{m.query.View()}
+	end := min(m.offset+m.maxRows(), len(m.rows))
+	visible := m.rows[m.offset:end]
+	var labelWidth int
+	for _, r := range visible {
+		labelWidth = max(labelWidth, ansi.StringWidth(r.entry.label))
+	}
+	for i, r := range visible {
+		row := m.rowView(r, m.offset+i == m.cursor, labelWidth)
+		lines = append(lines, ansi.Truncate(row, width, styles.SymbolEllipsis))
+	}
+	if len(m.rows) == 0 {
+		lines = append(lines, styles.MutedStyle.Render("  No matching networks"))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func (m *PickerModel) rowView(r pickerRow, selected bool, labelWidth int) string {
+	e := r.entry
+	base := styles.DefaultStyle
+	cursor := "  "
+	if selected {
+		base = styles.BoldStyle
+		cursor = styles.AccentStyle.Render("> ")
+	}
+	var marks []string
+	if e.active {
+		marks = append(marks, styles.SymbolConnection)
+	}
+	switch {
+	case e.hotspot:
+		marks = append(marks, styles.SymbolAccessPoint)
+	case e.saved:
+		marks = append(marks, styles.SymbolSaved)
+	}
+	label := highlightMatches(e.label, r.positions, base)
+	label += strings.Repeat(" ", labelWidth-ansi.StringWidth(e.label))
+
+	details := "out of range"
+	if e.available {
+		details = strconv.Itoa(e.signal) + "% " + e.security
+	}
+	if e.hotspot {
+		details = "hotspot"
+	}
+	return cursor + label + "  " + styles.MutedStyle.Render(details) + "  " + strings.Join(marks, " ")
+}
@@ -0,0 +1,181 @@
+package models
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/alphameo/nm-tui/internal/infra"
+)
+
+func TestPickerEntries(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	available := []AvailableNetwork{
+		{SSID: "Cafe", Signal: 40, Security: "WPA2"},
+		{SSID: "Home", Signal: 60, Security: "WPA2", Active: true},
+		{SSID: "Home", Signal: 80, Security: "WPA2"},
+		{SSID: "", Signal: 90, Security: "WPA2"},
+		{SSID: "Open", Signal: 20, Security: "--"},
+	}
+	profiles := []NetworkProfileShort{
+		{Name: "Home", SSID: "Home", Active: true},
+		{Name: "Office", SSID: "Office", LastUsed: now.Add(-time.Hour)},
+		{Name: "Airport", SSID: "Airport", LastUsed: now},
+		{Name: "Hotspot", SSID: "nm-tui", Hotspot: true},
+	}
+
+	got := pickerEntries(available, profiles)
+	want := []pickerEntry{
+		{label: "Home", ssid: "Home", signal: 80, security: "WPA2", available: true, saved: true, active: true},
+		{label: "Cafe", ssid: "Cafe", signal: 40, security: "WPA2", available: true},
+		{label: "Open", ssid: "Open", signal: 20, security: "--", available: true},
+		{label: "Airport", ssid: "Airport", profile: "Airport", saved: true},
+		{label: "Office", ssid: "Office", profile: "Office", saved: true},
+		{label: "Hotspot", ssid: "nm-tui", profile: "Hotspot", saved: true, hotspot: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("pickerEntries() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	needsPassword := make(map[string]bool)
+	for _, e := range got {
+		needsPassword[e.label] = e.needsPassword()
+	}
+	wantPassword := map[string]bool{
+		"Home": false, "Cafe": true, "Open": false, "Airport": false, "Office": false, "Hotspot": false,
+	}
+	if !reflect.DeepEqual(needsPassword, wantPassword) {
+		t.Errorf("needsPassword = %v, want %v", needsPassword, wantPassword)
+	}
+}
+
+type fakePickerNetworks struct {
+	infra.NetworksManager
+
+	calls []string
+}
+
+func (f *fakePickerNetworks) ConnectToNetwork(_ context.Context, ssid, password string) error {
+	f.calls = append(f.calls, "connect "+ssid+" "+password)
+	return nil
+}
+
+func (f *fakePickerNetworks) TryActivateNetwork(_ context.Context, ssid string) error {
+	f.calls = append(f.calls, "activate network "+ssid)
+	return nil
+}
+
+func (f *fakePickerNetworks) ActivateProfile(_ context.Context, name string) error {
+	f.calls = append(f.calls, "activate profile "+name)
+	return nil
+}
+
+func newTestPicker(netMngr infra.NetworksManager) *PickerModel {
+	keys := pickerKeyMap{
+		prev:   NewKey([]string{"up"}, "prev"),
+		next:   NewKey([]string{"down"}, "next"),
+		pick:   NewKey([]string{"enter"}, "connect"),
+		cancel: NewKey([]string{"esc"}, "cancel"),
+	}
+	m := &PickerModel{
+		netMngr:  netMngr,
+		query:    newDefaultInput(),
+		password: newDefaultPasswordInput(),
+		spinner:  newDefaultSpinner(),
+		keys:     keys,
+	}
+	m.query.Focus()
+	m.Update(pickerLoadedMsg{
+		available: []infra.AvailableNetwork{
+			{SSID: "Home", Signal: 80, Security: "WPA2"},
+			{SSID: "Cafe", Signal: 40, Security: "WPA2"},
+		},
+		profiles: []infra.NetworkProfileShort{
+			{Name: "Home", SSID: "Home"},
+			{Name: "Office", SSID: "Office"},
+		},
+	})
+	return m
+}
+
+func pressKeys(m *PickerModel, keys ...tea.KeyPressMsg) tea.Cmd {
+	var cmd tea.Cmd
+	for _, k := range keys {
+		_, cmd = m.Update(k)
+	}
+	return cmd
+}
+
+func typeText(text string) []tea.KeyPressMsg {
+	presses := make([]tea.KeyPressMsg, 0, len(text))
+	for _, r := range text {
+		presses = append(presses, tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	return presses
+}
+
+func TestPickerPick(t *testing.T) {
+	t.Parallel()
+
+	enter := tea.KeyPressMsg{Code: tea.KeyEnter}
+	tests := []struct {
+		name  string
+		query string
+		// password is typed once the picker asks for it.
+		password string
+		want     string
+	}{
+		{"saved-network", "home", "", "activate network Home"},
+		{"password-prompt", "cafe", "secret123", "connect Cafe secret123"},
+		{"profile-out-of-range", "off", "", "activate profile Office"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			netMngr := &fakePickerNetworks{}
+			m := newTestPicker(netMngr)
+			cmd := pressKeys(m, append(typeText(tt.query), enter)...)
+			if tt.password != "" {
+				if m.state != pickerPassword {
+					t.Fatalf("state = %v, want the password prompt", m.state)
+				}
+				cmd = pressKeys(m, append(typeText(tt.password), enter)...)
+			}
+			if m.state != pickerConnecting || cmd == nil {
+				t.Fatalf("state = %v, want connecting", m.state)
+			}
+
+			m.Update(cmd())
+			if !reflect.DeepEqual(netMngr.calls, []string{tt.want}) {
+				t.Errorf("calls = %q, want %q", netMngr.calls, tt.want)
+			}
+			if res := m.Result(); res.Err != nil || res.Picked == "" {
+				t.Errorf("Result() = %+v, want a successful pick", res)
+			}
+		})
+	}
+}
+
+func TestPickerCancel(t *testing.T) {
+	t.Parallel()
+
+	netMngr := &fakePickerNetworks{}
+	m := newTestPicker(netMngr)
+	esc := tea.KeyPressMsg{Code: tea.KeyEscape}
+
+	pressKeys(m, append(typeText("cafe"), tea.KeyPressMsg{Code: tea.KeyEnter})...)
+	pressKeys(m, esc)
+	if m.state != pickerPicking {
+		t.Fatalf("state after cancelling the password = %v, want picking", m.state)
+	}
+	pressKeys(m, esc)
+	if m.state != pickerDone || m.Result() != (PickerResult{}) || len(netMngr.calls) != 0 {
+		t.Errorf("state = %v, result = %+v, calls = %q, want a cancelled pick", m.state, m.Result(), netMngr.calls)
+	}
+}
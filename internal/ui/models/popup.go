@@ -2,6 +2,7 @@ package models
 
 import (
 	tea "charm.land/bubbletea/v2"
+	"github.com/alphameo/nm-tui/internal/backup"
 )
 
 type PopupModel interface {
@@ -57,6 +58,13 @@ type (
 	openHotspotCreatorMsg struct{}
 	openProfileCreatorMsg struct{}
 	openProfileEditorMsg  string
+	openWifiShareMsg      string
+	openNetworkDetailsMsg string
+	openBackupExportMsg   struct{}
+	openBackupImportMsg   struct{}
+	openRestorePreviewMsg []backup.Entry
+	openMarkMatchingMsg   struct{}
+	openBulkActionsMsg    []string
 )
 
 func OpenConnectorCmd(ssid string) tea.Cmd {
@@ -82,3 +90,47 @@ func OpenProfileEditorCmd(name string) tea.Cmd {
 		return openProfileEditorMsg(name)
 	}
 }
+
+func OpenWifiShareCmd(name string) tea.Cmd {
+	return func() tea.Msg {
+		return openWifiShareMsg(name)
+	}
+}
+
+// OpenNetworkDetailsCmd opens the details of the access point id, see
+// networkID.
+func OpenNetworkDetailsCmd(id string) tea.Cmd {
+	return func() tea.Msg {
+		return openNetworkDetailsMsg(id)
+	}
+}
+
+func OpenBackupExportCmd() tea.Cmd {
+	return func() tea.Msg {
+		return openBackupExportMsg{}
+	}
+}
+
+func OpenBackupImportCmd() tea.Cmd {
+	return func() tea.Msg {
+		return openBackupImportMsg{}
+	}
+}
+
+func OpenRestorePreviewCmd(entries []backup.Entry) tea.Cmd {
+	return func() tea.Msg {
+		return openRestorePreviewMsg(entries)
+	}
+}
+
+func OpenMarkMatchingCmd() tea.Cmd {
+	return func() tea.Msg {
+		return openMarkMatchingMsg{}
+	}
+}
+
+func OpenBulkActionsCmd(names []string) tea.Cmd {
+	return func() tea.Msg {
+		return openBulkActionsMsg(names)
+	}
+}
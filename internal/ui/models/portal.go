@@ -0,0 +1,215 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/alphameo/nm-tui/internal/config"
+	"github.com/alphameo/nm-tui/internal/infra"
+)
+
+type portalConfig struct {
+	settings infra.PortalSettings
+	autoOpen bool
+}
+
+var portalCfg = portalConfig{}
+
+func applyPortalConfig(cfg *config.PortalConfig) {
+	portalCfg.settings = infra.PortalSettings{
+		CheckURL:        *cfg.CheckURL,
+		CheckResponse:   *cfg.CheckResponse,
+		Browser:         *cfg.Browser,
+		TerminalBrowser: *cfg.TerminalBrowser,
+	}
+	portalCfg.autoOpen = *cfg.AutoOpen
+}
+
+// openCaptivePortalCmd finds the login page of the captive portal and opens
+// it. A terminal browser takes over the terminal until it exits.
+func (m *NetworksModel) openCaptivePortalCmd() tea.Cmd {
+	settings := portalCfg.settings
+	return func() tea.Msg {
+		url, err := m.portal.FindCaptivePortal(context.Background(), settings)
+		if errors.Is(err, infra.ErrNoCaptivePortal) {
+			return NotifyCmd("No captive portal, the network is online")
+		}
+		if err != nil {
+			return NotifyErrorCmd("Failed to find captive portal")
+		}
+
+		cmd, terminal, err := m.portal.BrowserCommand(url, settings)
+		if err != nil {
+			slog.Error("no browser for the captive portal", "url", url, "error", err.Error())
+			return NotifyErrorCmd(fmt.Sprintf("No browser to open %s", url))
+		}
+		if terminal {
+			return tea.ExecProcess(cmd, func(err error) tea.Msg {
+				if err != nil {
+					slog.Error("terminal browser failed", "command", cmd.String(), "error", err.Error())
+					return NotifyErrorCmd("Terminal browser failed")
+				}
+				return RescanDeviceCmd()
+			})
+		}
+		if err := cmd.Start(); err != nil {
+			slog.Error("browser failed", "command", cmd.String(), "error", err.Error())
+			return NotifyErrorCmd("Failed open captive portal")
+		}
+		go func() {
+			_ = cmd.Wait()
+		}()
+		return NotifyCmd(fmt.Sprintf("Opening captive portal %s", url))
+	}
+}
+
+// connectivityMsg reports the connectivity checked by NetworkManager.
+type connectivityMsg infra.ConnectivityStatus
+
+func checkConnectivityCmd(devMngr infra.DeviceManager) tea.Cmd {
+	return func() tea.Msg {
+		status, err := devMngr.GetConnectivityStatus(context.Background())
+		if err != nil {
+			return nil
+		}
+		return connectivityMsg(status)
+	}
+}
+
+// autoOpenPortal logs in to or opens the captive portal when connectivity
+// turns to portal and auto_open is set.
+func (m *MainModel) autoOpenPortal(status infra.ConnectivityStatus) tea.Cmd {
+	prev := m.connectivity
+	m.connectivity = status
+	if !portalCfg.autoOpen || status != infra.ConnectivityPortal || prev == infra.ConnectivityPortal {
+		return nil
+	}
+	slog.Info("captive portal detected")
+	return m.portalCmd()
+}
+
+// portalCmd logs in to the captive portal of the active network with its
+// recipe, or opens the portal when there is none.
+func (m *MainModel) portalCmd() tea.Cmd {
+	var names []string
+	for _, p := range m.networks.profiles.profiles {
+		if p.Active && !p.Hotspot {
+			names = m.loginNames(p.Name)
+			break
+		}
+	}
+	return m.portalLoginCmd(names, m.networks.openCaptivePortalCmd())
+}
+
+// networkActivatedMsg reports a network activated by nm-tui, named by its
+// profile or SSID.
+type networkActivatedMsg string
+
+func networkActivatedCmd(name string) tea.Cmd {
+	return func() tea.Msg {
+		return networkActivatedMsg(name)
+	}
+}
+
+// loginNames returns the names the portal login recipe of the network name,
+// a profile or an SSID, may be saved under: name first, then the SSID of the
+// profile or the profiles of the SSID.
+func (m *MainModel) loginNames(name string) []string {
+	names := []string{name}
+	for _, p := range m.networks.profiles.profiles {
+		switch {
+		case p.Name == name && p.SSID != "" && p.SSID != name:
+			names = append(names, p.SSID)
+		case p.SSID == name && p.Name != name:
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+// portalLoginCmd runs the first portal login recipe saved under names when
+// connectivity is behind a captive portal, then checks connectivity again.
+// Without a matching recipe, fallback runs instead.
+func (m *MainModel) portalLoginCmd(names []string, fallback tea.Cmd) tea.Cmd {
+	settings := portalCfg.settings
+	portal := m.networks.portal
+	devMngr := m.device.connMngr
+	return func() tea.Msg {
+		var (
+			name  string
+			login *config.PortalLogin
+		)
+		for _, n := range names {
+			l, err := config.LoadPortalLogin(n)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return NotifyErrorCmd(fmt.Sprintf("Portal login not run:\n%s", err))
+			}
+			name, login = n, l
+			break
+		}
+		if login == nil {
+			if fallback == nil {
+				return nil
+			}
+			return fallback
+		}
+
+		ctx := context.Background()
+		status, err := devMngr.GetConnectivityStatus(ctx)
+		if err != nil || status != infra.ConnectivityPortal {
+			return nil
+		}
+		portalURL, err := portal.FindCaptivePortal(ctx, settings)
+		if errors.Is(err, infra.ErrNoCaptivePortal) {
+			return nil
+		}
+		if err != nil {
+			return NotifyErrorCmd(fmt.Sprintf("Portal login for %q: cannot find the portal", name))
+		}
+		if !login.Matches(portalURL) {
+			slog.Info("portal login skipped", "recipe", name, "url", portalURL)
+			return tea.Batch(
+				NotifyWarningCmd(fmt.Sprintf("Portal login for %q skipped:\n%s doesn't match url_match", name, portalURL)),
+				fallback,
+			)
+		}
+		fields, err := login.Form()
+		if err != nil {
+			return NotifyErrorCmd(fmt.Sprintf("Portal login for %q: %s", name, err))
+		}
+		form := infra.PortalForm{Fields: fields}
+		if login.Action != nil {
+			form.Action = *login.Action
+		}
+		if login.Method != nil {
+			form.Method = *login.Method
+		}
+
+		slog.Info("running portal login", "recipe", name, "url", portalURL)
+		if err := portal.LoginCaptivePortal(ctx, portalURL, form); err != nil {
+			return NotifyErrorCmd(fmt.Sprintf("Portal login for %q failed", name))
+		}
+		status, err = devMngr.GetConnectivityStatus(ctx)
+		if err != nil {
+			return NotifyWarningCmd(fmt.Sprintf("Portal login for %q sent, connectivity unknown", name))
+		}
+		slog.Info("portal login sent", "recipe", name, "connectivity", status.String())
+		if status != infra.ConnectivityFull {
+			return NotifyWarningCmd(fmt.Sprintf(
+				"Portal login for %q sent, connectivity is %s", name, strings.ToLower(status.String()),
+			))
+		}
+		return tea.Batch(
+			NotifyCmd(fmt.Sprintf("Logged in to the portal of %q", name)),
+			RescanDeviceCmd(),
+		)
+	}
+}
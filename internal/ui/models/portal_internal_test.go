@@ -0,0 +1,34 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoginNames(t *testing.T) {
+	t.Parallel()
+
+	m := &MainModel{networks: &NetworksModel{profiles: &NetworkProfilesModel{
+		profiles: []NetworkProfileShort{
+			{Name: "Gym", SSID: "GymFree"},
+			{Name: "GymFree", SSID: "GymFree"},
+			{Name: "Gym 5G", SSID: "GymFree"},
+			{Name: "Office", SSID: "Office"},
+		},
+	}}}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"Gym", []string{"Gym", "GymFree"}},
+		{"GymFree", []string{"GymFree", "Gym", "Gym 5G"}},
+		{"Office", []string{"Office"}},
+		{"Cafe", []string{"Cafe"}},
+	}
+	for _, tt := range tests {
+		if got := m.loginNames(tt.name); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("loginNames(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -2,28 +2,40 @@ package models
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"image"
+	_ "image/png" // registers the decoder for QR code images
+	"os"
+	"strings"
 
 	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/alphameo/nm-tui/internal/config"
 	"github.com/alphameo/nm-tui/internal/infra"
 	"github.com/alphameo/nm-tui/internal/ui/models/focus"
 	"github.com/alphameo/nm-tui/internal/ui/models/toggle"
 	"github.com/alphameo/nm-tui/internal/ui/styles"
 	"github.com/alphameo/nm-tui/internal/ui/tools/compositor"
+	"github.com/alphameo/nm-tui/internal/ui/tools/qr"
 	"github.com/alphameo/nm-tui/internal/ui/tools/renderer"
+	"github.com/alphameo/nm-tui/internal/wifiuri"
 )
 
 type profileCreatorConfig struct {
 	title string
 }
 
+const profileCreatorZoneID = "profile_creator"
+
 var profileCreatorCfg = profileCreatorConfig{
 	title: "Create Network profile",
 }
 
+var ErrUnsupportedSecurity = errors.New("unsupported security type")
+
 type profileCreatorKeyMap struct {
 	togglePWVisibility key.Binding
 	prev               key.Binding
@@ -36,6 +48,7 @@ type ProfileCreatorModel struct {
 	name     textinput.Model
 	password textinput.Model
 	hidden   toggle.Model
+	source   textinput.Model
 
 	focuses focus.Group
 
@@ -51,6 +64,7 @@ func NewProfileCreatorModel(keys profileCreatorKeyMap, networksManager infra.Net
 		name:     newDefaultNameInput(),
 		password: newDefaultPasswordInput(),
 		hidden:   newDefaultToggle(),
+		source:   newDefaultImportInput(),
 
 		keys: keys,
 
@@ -63,12 +77,19 @@ func NewProfileCreatorModel(keys profileCreatorKeyMap, networksManager infra.Net
 		&model.name,
 		&model.password,
 		&model.hidden,
+		&model.source,
 	}
 	model.focuses = *focus.NewGroup(inp)
 
 	return model
 }
 
+// restyle applies the current styles to the fields.
+func (m *ProfileCreatorModel) restyle() {
+	restyleInputs(&m.ssid, &m.name, &m.password, &m.source)
+	restyleToggles(&m.hidden)
+}
+
 func (m *ProfileCreatorModel) Reset() tea.Cmd {
 	m.ssid.Reset()
 
@@ -81,6 +102,9 @@ func (m *ProfileCreatorModel) Reset() tea.Cmd {
 	m.hidden.SetValue(false)
 	m.hidden.Blur()
 
+	m.source.Reset()
+	m.source.Blur()
+
 	return m.focuses.SetFocusIdx(0)
 }
 
@@ -90,6 +114,13 @@ func (m *ProfileCreatorModel) Init() tea.Cmd {
 
 //nolint:dupl // intentionally similar to profile_editor for now; will diverge
 func (m *ProfileCreatorModel) Update(msg tea.Msg) (*ProfileCreatorModel, tea.Cmd) {
+	if msg, ok := msg.(tea.MouseClickMsg); ok {
+		cmd, ok := focusClicked(&m.focuses, profileCreatorZoneID, msg)
+		if ok && m.hidden.Focused() {
+			m.hidden.SetValue(!m.hidden.Value())
+		}
+		return m, cmd
+	}
 	if msg, ok := msg.(tea.KeyPressMsg); ok {
 		switch {
 		case key.Matches(msg, m.keys.next):
@@ -103,6 +134,8 @@ func (m *ProfileCreatorModel) Update(msg tea.Msg) (*ProfileCreatorModel, tea.Cmd
 				m.password.EchoMode = textinput.EchoPassword
 			}
 			return m, nil
+		case key.Matches(msg, m.keys.create) && m.source.Focused():
+			return m, m.importCredentials()
 		case key.Matches(msg, m.keys.create):
 			if m.password.Err != nil {
 				return m, nil
@@ -129,6 +162,9 @@ func (m *ProfileCreatorModel) Update(msg tea.Msg) (*ProfileCreatorModel, tea.Cmd
 	m.hidden, cmd = m.hidden.Update(msg)
 	cmds = append(cmds, cmd)
 
+	m.source, cmd = m.source.Update(msg)
+	cmds = append(cmds, cmd)
+
 	return m, tea.Batch(cmds...)
 }
 
@@ -139,21 +175,30 @@ func (m *ProfileCreatorModel) UpdateAsPopup(msg tea.Msg) (PopupModel, tea.Cmd) {
 func (m *ProfileCreatorModel) View() string {
 	ssid := styles.ViewBorderedFocusable(&m.ssid)
 	ssid = lipgloss.JoinHorizontal(lipgloss.Center, "SSID     ", ssid)
+	ssid = markField(profileCreatorZoneID, 0, ssid)
 
 	name := styles.ViewBorderedFocusable(&m.name)
 	name = lipgloss.JoinHorizontal(lipgloss.Center, "Name     ", name)
+	name = markField(profileCreatorZoneID, 1, name)
 
 	password := styles.ViewBorderedFocusable(&m.password)
 	password = lipgloss.JoinHorizontal(lipgloss.Center, "Password ", password)
+	password = markField(profileCreatorZoneID, 2, password)
 
 	hidden := m.hidden.View()
 	hidden = lipgloss.JoinHorizontal(lipgloss.Center, "Hidden ", hidden)
+	hidden = markField(profileCreatorZoneID, 3, hidden)
+
+	source := styles.ViewBorderedFocusable(&m.source)
+	source = lipgloss.JoinHorizontal(lipgloss.Center, "Import   ", source)
+	source = markField(profileCreatorZoneID, 4, source)
 
 	fields := []string{
 		ssid,
 		name,
 		password,
 		hidden,
+		source,
 	}
 
 	view := lipgloss.JoinVertical(
@@ -191,7 +236,7 @@ func (m *ProfileCreatorModel) createProfileCmd() tea.Cmd {
 				}
 				return tea.Batch(
 					SetAvailableNetworksStateCmd(NetsDone),
-					NotifyCmd(fmt.Sprintf(
+					NotifyErrorCmd(fmt.Sprintf(
 						"Cannot create connection to %s%s:\n%v",
 						hidden, m.ssid.Value(), err,
 					)),
@@ -205,3 +250,51 @@ func (m *ProfileCreatorModel) createProfileCmd() tea.Cmd {
 		},
 	)
 }
+
+// importCredentials prefills the form from the WIFI: URI or the path to a QR
+// code image entered in the import field.
+func (m *ProfileCreatorModel) importCredentials() tea.Cmd {
+	creds, err := readWifiCredentials(m.source.Value())
+	if err == nil && creds.Security == wifiuri.SecurityWEP {
+		err = fmt.Errorf("%w: %s", ErrUnsupportedSecurity, creds.Security)
+	}
+	if err != nil {
+		return NotifyErrorCmd(fmt.Sprintf("Cannot import Wi-Fi credentials:\n%v", err))
+	}
+
+	m.ssid.SetValue(creds.SSID)
+	if m.name.Value() == "" {
+		m.name.SetValue(creds.SSID)
+	}
+	m.password.SetValue(creds.Password)
+	m.hidden.SetValue(creds.Hidden)
+	m.source.Reset()
+	m.source.Blur()
+
+	return m.focuses.SetFocusIdx(0)
+}
+
+// readWifiCredentials parses input as a WIFI: URI, or otherwise as the path
+// to a PNG image with a WIFI: QR code, which is decoded locally.
+func readWifiCredentials(input string) (wifiuri.Credentials, error) {
+	input = strings.TrimSpace(input)
+	if len(input) >= len(wifiuri.Scheme) && strings.EqualFold(input[:len(wifiuri.Scheme)], wifiuri.Scheme) {
+		return wifiuri.Parse(input)
+	}
+
+	f, err := os.Open(config.ExpandPath(input))
+	if err != nil {
+		return wifiuri.Credentials{}, err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return wifiuri.Credentials{}, fmt.Errorf("%s: %w", input, err)
+	}
+	payload, err := qr.Decode(img)
+	if err != nil {
+		return wifiuri.Credentials{}, fmt.Errorf("%s: %w", input, err)
+	}
+	return wifiuri.Parse(string(payload))
+}
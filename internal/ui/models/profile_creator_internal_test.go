@@ -0,0 +1,79 @@
+package models
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/ui/tools/qr"
+	"github.com/alphameo/nm-tui/internal/wifiuri"
+)
+
+func writeQRImage(t *testing.T, payload string) string {
+	t.Helper()
+
+	code, err := qr.Encode([]byte(payload), qr.ECMedium)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const scale, quietZone = 4, 4
+	side := (code.Size + 2*quietZone) * scale
+	img := image.NewGray(image.Rect(0, 0, side, side))
+	for y := range side {
+		for x := range side {
+			img.Set(x, y, color.White)
+			if code.Dark(x/scale-quietZone, y/scale-quietZone) {
+				img.Set(x, y, color.Black)
+			}
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "wifi.png")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadWifiCredentials(t *testing.T) {
+	t.Parallel()
+
+	want := wifiuri.Credentials{
+		SSID:     "home; sweet home",
+		Security: wifiuri.SecurityWPA,
+		Password: "correct horse",
+		Hidden:   true,
+	}
+
+	got, err := readWifiCredentials("  " + wifiuri.Format(want) + "\n")
+	if err != nil {
+		t.Fatalf("readWifiCredentials(uri) error: %v", err)
+	}
+	if got != want {
+		t.Errorf("readWifiCredentials(uri) = %+v, want %+v", got, want)
+	}
+
+	path := writeQRImage(t, wifiuri.Format(want))
+	got, err = readWifiCredentials(path)
+	if err != nil {
+		t.Fatalf("readWifiCredentials(png) error: %v", err)
+	}
+	if got != want {
+		t.Errorf("readWifiCredentials(png) = %+v, want %+v", got, want)
+	}
+
+	if _, err := readWifiCredentials(writeQRImage(t, "https://example.com")); err == nil {
+		t.Error("readWifiCredentials(png without WIFI: uri) error = nil")
+	}
+	if _, err := readWifiCredentials(filepath.Join(t.TempDir(), "missing.png")); err == nil {
+		t.Error("readWifiCredentials(missing file) error = nil")
+	}
+}
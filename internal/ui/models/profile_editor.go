@@ -21,6 +21,8 @@ type profileEditorConfig struct {
 	title string
 }
 
+const profileEditorZoneID = "profile_editor"
+
 var profileEditorCfg = profileEditorConfig{
 	title: "Saved network info",
 }
@@ -81,10 +83,16 @@ func NewProfileEditorModel(keys profileEditorKeyMap, networksManager infra.Netwo
 	return model
 }
 
+// restyle applies the current styles to the fields.
+func (m *ProfileEditorModel) restyle() {
+	restyleInputs(&m.name, &m.password, &m.autoconnPriority)
+	restyleToggles(&m.autoconnect)
+}
+
 func (m *ProfileEditorModel) setNewProfile(name string) tea.Cmd {
 	info, err := m.netMngr.GetProfile(context.Background(), name)
 	if err != nil {
-		return NotifyCmd(
+		return NotifyErrorCmd(
 			fmt.Sprintf("Cannot get information about %s", name),
 		)
 	}
@@ -121,6 +129,13 @@ func (m *ProfileEditorModel) Init() tea.Cmd {
 
 //nolint:dupl // intentionally similar to profile_creator for now; will diverge
 func (m *ProfileEditorModel) Update(msg tea.Msg) (*ProfileEditorModel, tea.Cmd) {
+	if msg, ok := msg.(tea.MouseClickMsg); ok {
+		cmd, ok := focusClicked(&m.focuses, profileEditorZoneID, msg)
+		if ok && m.autoconnect.Focused() {
+			m.autoconnect.SetValue(!m.autoconnect.Value())
+		}
+		return m, cmd
+	}
 	if msg, ok := msg.(tea.KeyPressMsg); ok {
 		switch {
 		case key.Matches(msg, m.keys.next):
@@ -178,18 +193,22 @@ func (m *ProfileEditorModel) View() string {
 
 	name := styles.ViewBorderedFocusable(&m.name)
 	name = lipgloss.JoinHorizontal(lipgloss.Center, "Name     ", name)
+	name = markField(profileEditorZoneID, 0, name)
 
 	password := styles.ViewInputWithValidation(&m.password)
 	password = lipgloss.JoinHorizontal(lipgloss.Center, "Password ", password)
+	password = markField(profileEditorZoneID, 1, password)
 
 	mode := styles.BoldStyle.Render(m.mode)
 	mode = lipgloss.JoinHorizontal(lipgloss.Center, "Mode     ", mode)
 
 	autoconn := m.autoconnect.View()
 	autoconn = lipgloss.JoinHorizontal(lipgloss.Center, "Autoconnect          ", autoconn)
+	autoconn = markField(profileEditorZoneID, 2, autoconn)
 
 	autoconnPrior := styles.ViewInputWithValidation(&m.autoconnPriority)
 	autoconnPrior = lipgloss.JoinHorizontal(lipgloss.Center, "Autoconnect priority ", autoconnPrior)
+	autoconnPrior = markField(profileEditorZoneID, 3, autoconnPrior)
 
 	view := lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -225,7 +244,7 @@ func (m *ProfileEditorModel) saveProfileInfoCmd() tea.Cmd {
 	return func() tea.Msg {
 		ap, err := strconv.Atoi(m.autoconnPriority.Value())
 		if err != nil {
-			return NotifyCmd(
+			return NotifyErrorCmd(
 				fmt.Sprintf(
 					"Error while updating info about %s: %s",
 					m.nameBak,
@@ -241,7 +260,7 @@ func (m *ProfileEditorModel) saveProfileInfoCmd() tea.Cmd {
 		}
 		err = m.netMngr.UpdateProfile(context.Background(), m.nameBak, info)
 		if err != nil {
-			return NotifyCmd(fmt.Sprintf(
+			return NotifyErrorCmd(fmt.Sprintf(
 				"Cannot update information about %s",
 				m.nameBak,
 			))
@@ -0,0 +1,215 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/table"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/alphameo/nm-tui/internal/backup"
+	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/alphameo/nm-tui/internal/ui/styles"
+	"github.com/alphameo/nm-tui/internal/ui/tools/compositor"
+	"github.com/alphameo/nm-tui/internal/ui/tools/renderer"
+)
+
+type restorePreviewConfig struct {
+	title string
+
+	nameColIdx     int
+	ssidColIdx     int
+	conflictColIdx int
+	actionColIdx   int
+
+	nameWidth     int
+	ssidWidth     int
+	conflictWidth int
+	actionWidth   int
+
+	maxHeight int
+}
+
+const restorePreviewZoneID = "restore_preview"
+
+var restorePreviewCfg = restorePreviewConfig{
+	title: "Restore preview",
+
+	nameColIdx:     0,
+	ssidColIdx:     1,
+	conflictColIdx: 2,
+	actionColIdx:   3,
+
+	nameWidth:     20,
+	ssidWidth:     20,
+	conflictWidth: 10,
+	actionWidth:   24,
+
+	maxHeight: 12,
+}
+
+type restorePreviewKeyMap struct {
+	cycleAction key.Binding
+	restore     key.Binding
+}
+
+// RestorePreviewModel lists the archived profiles with their conflicts and
+// lets the user pick skip, overwrite or rename for each before restoring.
+type RestorePreviewModel struct {
+	entries []backup.Entry
+
+	dataTable table.Model
+	clicks    clickTracker
+
+	keys restorePreviewKeyMap
+
+	netMngr infra.NetworksManager
+	Style   lipgloss.Style
+}
+
+func NewRestorePreviewModel(keys restorePreviewKeyMap, networksManager infra.NetworksManager) *RestorePreviewModel {
+	cols := make([]table.Column, 4)
+	cols[restorePreviewCfg.nameColIdx] = table.Column{Title: "Name", Width: restorePreviewCfg.nameWidth}
+	cols[restorePreviewCfg.ssidColIdx] = table.Column{Title: "SSID", Width: restorePreviewCfg.ssidWidth}
+	cols[restorePreviewCfg.conflictColIdx] = table.Column{Title: "Conflict", Width: restorePreviewCfg.conflictWidth}
+	cols[restorePreviewCfg.actionColIdx] = table.Column{Title: "Action", Width: restorePreviewCfg.actionWidth}
+
+	t := table.New(
+		table.WithColumns(cols),
+		table.WithFocused(true),
+	)
+
+	return &RestorePreviewModel{
+		dataTable: t,
+		keys:      keys,
+		netMngr:   networksManager,
+		Style:     lipgloss.NewStyle(),
+	}
+}
+
+// SetTableStyles sets the styles of the preview table.
+func (m *RestorePreviewModel) SetTableStyles(s table.Styles) {
+	m.dataTable.SetStyles(s)
+}
+
+func (m *RestorePreviewModel) setEntries(entries []backup.Entry) {
+	m.entries = entries
+	m.dataTable.SetHeight(min(len(entries), restorePreviewCfg.maxHeight) + 1)
+	m.dataTable.SetCursor(0)
+	m.updateRows()
+}
+
+func (m *RestorePreviewModel) updateRows() {
+	rows := make([]table.Row, len(m.entries))
+	for i, e := range m.entries {
+		row := make(table.Row, 4)
+		row[restorePreviewCfg.nameColIdx] = markRow(restorePreviewZoneID, i, e.Profile.Name)
+		row[restorePreviewCfg.ssidColIdx] = e.Profile.SSID
+		row[restorePreviewCfg.conflictColIdx] = ""
+		if e.Conflict != backup.ConflictNone {
+			row[restorePreviewCfg.conflictColIdx] = e.Conflict.String()
+		}
+		row[restorePreviewCfg.actionColIdx] = e.Action.String()
+		if e.Action == backup.ActionRename {
+			row[restorePreviewCfg.actionColIdx] += " to " + e.NewName
+		}
+		rows[i] = row
+	}
+	m.dataTable.SetRows(rows)
+}
+
+func (m *RestorePreviewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *RestorePreviewModel) Update(msg tea.Msg) (*RestorePreviewModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.cycleAction):
+			if i := m.dataTable.Cursor(); i >= 0 && i < len(m.entries) {
+				m.entries[i].CycleAction()
+				m.updateRows()
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.restore):
+			return m, tea.Sequence(
+				ClosePopupCmd(),
+				m.restoreCmd(m.entries),
+			)
+		}
+	}
+
+	switch msg.(type) {
+	case tea.MouseClickMsg, tea.MouseWheelMsg:
+		if clickTableRow(&m.dataTable, restorePreviewZoneID, msg) {
+			if i := m.dataTable.Cursor(); m.clicks.click(m.entries[i].Profile.Name, time.Now()) {
+				m.entries[i].CycleAction()
+				m.updateRows()
+			}
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.dataTable, cmd = m.dataTable.Update(msg)
+	return m, cmd
+}
+
+func (m *RestorePreviewModel) UpdateAsPopup(msg tea.Msg) (PopupModel, tea.Cmd) {
+	return m.Update(msg)
+}
+
+func (m *RestorePreviewModel) View() string {
+	view := lipgloss.JoinVertical(
+		lipgloss.Left,
+		zones.Mark(restorePreviewZoneID, m.dataTable.View()),
+		styles.MutedStyle.Render(m.conflictDetails()),
+	)
+
+	view = m.Style.Render(view)
+	title := styles.DefaultStyle.Render(renderer.RenderTitle(restorePreviewCfg.title))
+	return compositor.Compose(
+		title,
+		view,
+		compositor.Center,
+		compositor.Begin,
+		0,
+		0,
+	)
+}
+
+// conflictDetails names the saved profiles the selected entry clashes with.
+func (m *RestorePreviewModel) conflictDetails() string {
+	i := m.dataTable.Cursor()
+	if i < 0 || i >= len(m.entries) {
+		return ""
+	}
+	e := m.entries[i]
+	if e.Conflict == backup.ConflictNone {
+		return "No conflicts"
+	}
+	return fmt.Sprintf("Conflicts by %s with: %s", e.Conflict, strings.Join(e.Existing, ", "))
+}
+
+func (m *RestorePreviewModel) restoreCmd(entries []backup.Entry) tea.Cmd {
+	return tea.Sequence(
+		SetNetworksStateCmd(NetsRestoring),
+		func() tea.Msg {
+			report := backup.Restore(context.Background(), m.netMngr, entries)
+			text := "Restored profiles: " + report.String()
+			notify := NotifyCmd
+			if err := report.Err(); err != nil {
+				text += "\n" + err.Error()
+				notify = NotifyWarningCmd
+			}
+			return tea.Batch(
+				SetNetworksStateCmd(NetsDone),
+				notify(text),
+				RescanNetworksCmd(),
+			)
+		},
+	)
+}
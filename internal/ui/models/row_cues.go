@@ -0,0 +1,153 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+type rowCuesConfig struct {
+	duration time.Duration
+	// signalThreshold is the smallest signal change worth pointing out, so
+	// the usual jitter of a few percent stays quiet.
+	signalThreshold int
+}
+
+var rowCuesCfg = rowCuesConfig{
+	duration:        3 * time.Second,
+	signalThreshold: 5,
+}
+
+type rowChange int
+
+const (
+	rowUnchanged rowChange = iota
+	rowAppeared
+	rowVanished
+	rowStronger
+	rowWeaker
+)
+
+type rowCue[T any] struct {
+	change rowChange
+	until  time.Time
+	// item is the last known state of a vanished row.
+	item T
+}
+
+// rowCues remembers recent changes of table rows by identity, so the table
+// can point them out until the cues expire.
+type rowCues[T any] struct {
+	id func(T) string
+	// compare reports how a row still present changed, nil when only
+	// appearing and vanishing rows matter.
+	compare func(old, cur T) rowChange
+
+	cues   map[string]rowCue[T]
+	known  map[string]T
+	loaded bool
+}
+
+func newRowCues[T any](id func(T) string, compare func(old, cur T) rowChange) rowCues[T] {
+	return rowCues[T]{
+		id:      id,
+		compare: compare,
+		cues:    make(map[string]rowCue[T]),
+	}
+}
+
+// update diffs items with the previous refresh and returns a command that
+// expires the new cues. The first refresh has nothing to compare with and
+// gets no cues.
+func (c *rowCues[T]) update(items []T, now time.Time) tea.Cmd {
+	c.prune(now)
+
+	next := make(map[string]T, len(items))
+	for _, item := range items {
+		next[c.id(item)] = item
+	}
+	if !c.loaded {
+		c.loaded = true
+		c.known = next
+		return nil
+	}
+
+	until := now.Add(rowCuesCfg.duration)
+	added := false
+	for id, item := range next {
+		old, ok := c.known[id]
+		change := rowAppeared
+		if ok {
+			change = rowUnchanged
+			if c.compare != nil {
+				change = c.compare(old, item)
+			}
+		}
+		if change != rowUnchanged {
+			c.cues[id] = rowCue[T]{change: change, until: until}
+			added = true
+		}
+	}
+	for id, item := range c.known {
+		if _, ok := next[id]; !ok {
+			c.cues[id] = rowCue[T]{change: rowVanished, until: until, item: item}
+			added = true
+		}
+	}
+	c.known = next
+
+	if !added {
+		return nil
+	}
+	return tea.Tick(rowCuesCfg.duration, func(time.Time) tea.Msg {
+		return rowCuesExpiredMsg{}
+	})
+}
+
+func (c *rowCues[T]) change(id string, now time.Time) rowChange {
+	cue, ok := c.cues[id]
+	if !ok || !cue.until.After(now) {
+		return rowUnchanged
+	}
+	return cue.change
+}
+
+// vanished returns the rows gone since recent refreshes, ordered by identity.
+func (c *rowCues[T]) vanished(now time.Time) []T {
+	var ids []string
+	for id, cue := range c.cues {
+		if cue.change == rowVanished && cue.until.After(now) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+
+	items := make([]T, len(ids))
+	for i, id := range ids {
+		items[i] = c.cues[id].item
+	}
+	return items
+}
+
+func (c *rowCues[T]) prune(now time.Time) {
+	for id, cue := range c.cues {
+		if !cue.until.After(now) {
+			delete(c.cues, id)
+		}
+	}
+}
+
+// rowCuesExpiredMsg asks the tables to redraw once cues time out.
+type rowCuesExpiredMsg struct{}
+
+func compareSignal(old, cur AvailableNetwork) rowChange {
+	diff := cur.Signal - old.Signal
+	if max(diff, -diff) < rowCuesCfg.signalThreshold {
+		return rowUnchanged
+	}
+	if diff > 0 {
+		return rowStronger
+	}
+	return rowWeaker
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRowCues(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	cues := newRowCues(networkID, compareSignal)
+
+	if cmd := cues.update([]AvailableNetwork{
+		{SSID: "Home", BSSID: "01", Signal: 50},
+		{SSID: "Cafe", BSSID: "02", Signal: 50},
+		{SSID: "Office", BSSID: "03", Signal: 50},
+	}, now); cmd != nil {
+		t.Error("first refresh produced cues")
+	}
+
+	cmd := cues.update([]AvailableNetwork{
+		{SSID: "Home", BSSID: "01", Signal: 52},
+		{SSID: "Cafe", BSSID: "02", Signal: 80},
+		{SSID: "Airport", BSSID: "04", Signal: 30},
+	}, now)
+	if cmd == nil {
+		t.Error("changes produced no expiry command")
+	}
+
+	tests := []struct {
+		id   string
+		want rowChange
+	}{
+		{"01", rowUnchanged},
+		{"02", rowStronger},
+		{"03", rowVanished},
+		{"04", rowAppeared},
+	}
+	for _, tt := range tests {
+		if got := cues.change(tt.id, now); got != tt.want {
+			t.Errorf("change(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+	if gone := cues.vanished(now); len(gone) != 1 || gone[0].SSID != "Office" {
+		t.Errorf("vanished() = %+v, want the Office network", gone)
+	}
+
+	later := now.Add(rowCuesCfg.duration)
+	cues.prune(later)
+	if got := cues.change("04", later); got != rowUnchanged {
+		t.Errorf("change() after expiry = %v, want %v", got, rowUnchanged)
+	}
+	if gone := cues.vanished(later); len(gone) != 0 {
+		t.Errorf("vanished() after expiry = %+v, want none", gone)
+	}
+}
+
+func TestSelectionFollowsRow(t *testing.T) {
+	t.Parallel()
+
+	available := NewAvailableNetworksModel(availableNetworksKeyMap{}, nil)
+	available.setAvailable([]AvailableNetwork{
+		{SSID: "Home", BSSID: "01"},
+		{SSID: "Cafe", BSSID: "02"},
+		{SSID: "Cafe", BSSID: "03"},
+	}, nil)
+	available.dataTable.SetCursor(2)
+	available.setAvailable([]AvailableNetwork{
+		{SSID: "Cafe", BSSID: "03"},
+		{SSID: "Airport", BSSID: "04"},
+		{SSID: "Cafe", BSSID: "02"},
+	}, nil)
+	if got, _ := available.selected(); got.BSSID != "03" {
+		t.Errorf("selected access point = %q, want %q", got.BSSID, "03")
+	}
+
+	profiles := NewNetworkProfilesModel(networkProfilesKeyMap{}, nil)
+	profiles.setProfiles([]NetworkProfileShort{
+		{Name: "home", UUID: "u1"},
+		{Name: "cafe", UUID: "u2"},
+	}, nil)
+	profiles.dataTable.SetCursor(1)
+	profiles.setProfiles([]NetworkProfileShort{
+		{Name: "new", UUID: "u3"},
+		{Name: "home", UUID: "u1"},
+		{Name: "cafe-renamed", UUID: "u2"},
+	}, nil)
+	if got, _ := profiles.selected(); got.UUID != "u2" {
+		t.Errorf("selected profile = %q, want the renamed one", got.Name)
+	}
+
+	profiles.setProfiles([]NetworkProfileShort{{Name: "home", UUID: "u1"}}, nil)
+	if got, want := len(profiles.dataTable.Rows()), 3; got != want {
+		t.Errorf("table has %d rows, want %d including vanished ones", got, want)
+	}
+	profiles.dataTable.SetCursor(2)
+	if got, ok := profiles.selected(); ok {
+		t.Errorf("vanished row selected as %q", got.Name)
+	}
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"math"
+
+	"github.com/alphameo/nm-tui/internal/ui/tools/graph"
+)
+
+type signalHistoryConfig struct {
+	// samples is how many scans the history of a network holds.
+	samples int
+	// sparkWidth is the width of the sparkline column, the latest scans.
+	sparkWidth int
+}
+
+var signalHistoryCfg = signalHistoryConfig{
+	samples:    120,
+	sparkWidth: 8,
+}
+
+// signalHistory keeps the signal of every access point over the recent scans,
+// by [networkID]. A scan missing a known access point leaves a gap, one
+// missing for the whole history is forgotten, so the history only grows with
+// the access points around.
+type signalHistory struct {
+	series map[string]*graph.Series
+}
+
+func newSignalHistory() signalHistory {
+	return signalHistory{series: make(map[string]*graph.Series)}
+}
+
+// record adds the signals of a scan.
+func (h *signalHistory) record(networks []AvailableNetwork) {
+	seen := make(map[string]bool, len(networks))
+	for _, n := range networks {
+		id := networkID(n)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		s, ok := h.series[id]
+		if !ok {
+			s = graph.NewSeries(signalHistoryCfg.samples)
+			h.series[id] = s
+		}
+		s.Push(float64(n.Signal))
+	}
+
+	for id, s := range h.series {
+		if seen[id] {
+			continue
+		}
+		s.Push(math.NaN())
+		if !hasSample(s.Values()) {
+			delete(h.series, id)
+		}
+	}
+}
+
+// values returns the signals of the access point id, the oldest first.
+func (h *signalHistory) values(id string) []float64 {
+	s, ok := h.series[id]
+	if !ok {
+		return nil
+	}
+	return s.Values()
+}
+
+func hasSample(values []float64) bool {
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			return true
+		}
+	}
+	return false
+}
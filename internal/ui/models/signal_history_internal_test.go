@@ -0,0 +1,35 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSignalHistoryRecord(t *testing.T) {
+	t.Parallel()
+
+	home := AvailableNetwork{SSID: "Home", BSSID: "01", Signal: 70}
+	cafe := AvailableNetwork{SSID: "Cafe", BSSID: "02", Signal: 40}
+
+	h := newSignalHistory()
+	h.record([]AvailableNetwork{home, cafe, cafe})
+	home.Signal = 60
+	h.record([]AvailableNetwork{home})
+
+	if got := h.values("01"); len(got) != 2 || got[0] != 70 || got[1] != 60 {
+		t.Errorf("values of Home = %v, want [70 60]", got)
+	}
+	if got := h.values("02"); len(got) != 2 || got[0] != 40 || !math.IsNaN(got[1]) {
+		t.Errorf("values of Cafe = %v, want [40 NaN]", got)
+	}
+
+	for range signalHistoryCfg.samples - 1 {
+		h.record([]AvailableNetwork{home})
+	}
+	if got := h.values("02"); got != nil {
+		t.Errorf("values of Cafe gone for the whole history = %v, want it forgotten", got)
+	}
+	if got := len(h.values("01")); got != signalHistoryCfg.samples {
+		t.Errorf("Home has %d samples, want %d", got, signalHistoryCfg.samples)
+	}
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/table"
+	"github.com/alphameo/nm-tui/internal/state"
+	"github.com/alphameo/nm-tui/internal/ui/styles"
+)
+
+type tableSortKeyMap struct {
+	cycle   key.Binding
+	reverse key.Binding
+}
+
+// sortOption orders table rows by one criterion, compare defining the
+// ascending order.
+type sortOption[T any] struct {
+	// name identifies the option in the state file.
+	name string
+	// title names the option in the table title when it has no column.
+	title string
+	// colIdx is the column whose header shows the indicator, -1 for none.
+	colIdx  int
+	compare func(a, b T) int
+	// descending is the direction the option starts with, e.g. strongest
+	// signal first.
+	descending bool
+}
+
+// tableSort is the active sort of a table. Cycling passes through every
+// option and then back to the order reported by NetworkManager.
+type tableSort[T any] struct {
+	options []sortOption[T]
+	// current is 1 + the index of the active option, 0 keeps the order as is.
+	current    int
+	descending bool
+}
+
+func (s *tableSort[T]) cycle() {
+	s.current = (s.current + 1) % (len(s.options) + 1)
+	s.descending = false
+	if opt, ok := s.active(); ok {
+		s.descending = opt.descending
+	}
+}
+
+func (s *tableSort[T]) reverse() {
+	if s.current > 0 {
+		s.descending = !s.descending
+	}
+}
+
+func (s *tableSort[T]) active() (sortOption[T], bool) {
+	if s.current == 0 {
+		return sortOption[T]{}, false
+	}
+	return s.options[s.current-1], true
+}
+
+// apply returns items in the sort order. Equal items keep their relative
+// order, so ties stay in the order reported by NetworkManager.
+func (s *tableSort[T]) apply(items []T) []T {
+	opt, ok := s.active()
+	if !ok {
+		return items
+	}
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, func(a, b T) int {
+		if s.descending {
+			return opt.compare(b, a)
+		}
+		return opt.compare(a, b)
+	})
+	return sorted
+}
+
+func (s *tableSort[T]) indicator() string {
+	if s.descending {
+		return styles.SymbolSortDescending
+	}
+	return styles.SymbolSortAscending
+}
+
+// setHeaders resets the column titles and marks the sorted column.
+func (s *tableSort[T]) setHeaders(cols []table.Column, titles []string) {
+	for i, title := range titles {
+		cols[i].Title = title
+	}
+	if opt, ok := s.active(); ok && opt.colIdx >= 0 {
+		cols[opt.colIdx].Title += " " + s.indicator()
+	}
+}
+
+// titleSuffix names the sort in the table title when it has no column.
+func (s *tableSort[T]) titleSuffix() string {
+	if opt, ok := s.active(); ok && opt.colIdx < 0 {
+		return " (by " + opt.title + " " + s.indicator() + ")"
+	}
+	return ""
+}
+
+func (s *tableSort[T]) pref() state.Sort {
+	opt, ok := s.active()
+	if !ok {
+		return state.Sort{}
+	}
+	return state.Sort{Column: opt.name, Descending: s.descending}
+}
+
+// restore activates a saved preference. Unknown options leave the table
+// unsorted.
+func (s *tableSort[T]) restore(pref state.Sort) {
+	s.current, s.descending = 0, false
+	for i, opt := range s.options {
+		if opt.name == pref.Column {
+			s.current, s.descending = i+1, pref.Descending
+			return
+		}
+	}
+}
+
+func compareFold(a, b string) int {
+	return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/alphameo/nm-tui/internal/state"
+)
+
+func TestTableSortCycle(t *testing.T) {
+	t.Parallel()
+
+	s := tableSort[NetworkProfileShort]{options: networkProfilesSortOptions()}
+	var names []string
+	for range len(s.options) + 1 {
+		s.cycle()
+		names = append(names, s.pref().Column)
+	}
+	want := []string{"name", "ssid", "mode", "active", "priority", "last_used", ""}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("cycle order = %v, want %v", names, want)
+	}
+
+	s.reverse()
+	if s.descending {
+		t.Error("reverse() changed direction of the unsorted table")
+	}
+
+	s.restore(state.Sort{Column: "priority", Descending: false})
+	if got := s.titleSuffix(); !strings.Contains(got, "priority") {
+		t.Errorf("titleSuffix() = %q, want it to name the sort without a column", got)
+	}
+	s.restore(state.Sort{Column: "removed"})
+	if _, ok := s.active(); ok {
+		t.Error("restore() of unknown option activated a sort")
+	}
+}
+
+func TestTableSortApply(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	profiles := []NetworkProfileShort{
+		{Name: "b", AutoconnectPriority: 1},
+		{Name: "a", AutoconnectPriority: 5, LastUsed: now},
+		{Name: "c", AutoconnectPriority: 1, LastUsed: now.Add(-time.Hour)},
+	}
+
+	tests := []struct {
+		name string
+		pref state.Sort
+		want []string
+	}{
+		{"unsorted-keeps-order", state.Sort{}, []string{"b", "a", "c"}},
+		{"name", state.Sort{Column: "name"}, []string{"a", "b", "c"}},
+		{"priority-ties-keep-order", state.Sort{Column: "priority", Descending: true}, []string{"a", "b", "c"}},
+		{"never-used-last", state.Sort{Column: "last_used", Descending: true}, []string{"a", "c", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := tableSort[NetworkProfileShort]{options: networkProfilesSortOptions()}
+			s.restore(tt.pref)
+			var got []string
+			for _, p := range s.apply(profiles) {
+				got = append(got, p.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAvailableNetworksSortPersists(t *testing.T) {
+	t.Parallel()
+
+	store, err := state.Open(filepath.Join(t.TempDir(), state.FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := availableNetworksKeyMap{sort: tableSortKeyMap{
+		cycle:   key.NewBinding(key.WithKeys("o")),
+		reverse: key.NewBinding(key.WithKeys("O")),
+	}}
+	m := NewAvailableNetworksModel(keys, nil)
+	m.store = store
+	m.Focus()
+	m.setAvailable([]AvailableNetwork{{SSID: "weak", Signal: 20}, {SSID: "strong", Signal: 90}}, nil)
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: 'o', Text: "o"})
+	if cmd == nil {
+		t.Fatal("sorting returned no save command")
+	}
+	cmd()
+
+	if got := m.visible[0].SSID; got != "strong" {
+		t.Errorf("first network = %q, want the strongest", got)
+	}
+	if title := m.dataTable.Columns()[availableNetworksCfg.signalColIdx].Title; !strings.HasSuffix(title, " "+m.sort.indicator()) {
+		t.Errorf("signal header = %q, want the sort indicator", title)
+	}
+	if got, want := store.State().AvailableSort, (state.Sort{Column: "signal", Descending: true}); got != want {
+		t.Errorf("saved sort = %+v, want %+v", got, want)
+	}
+
+	restored := NewAvailableNetworksModel(keys, nil)
+	restored.restoreSort(store.State().AvailableSort)
+	restored.setAvailable([]AvailableNetwork{{SSID: "weak", Signal: 20}, {SSID: "strong", Signal: 90}}, nil)
+	if got := restored.visible[0].SSID; got != "strong" {
+		t.Errorf("first network after restart = %q, want the strongest", got)
+	}
+}
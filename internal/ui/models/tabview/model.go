@@ -2,9 +2,12 @@
 package tabview
 
 import (
+	"strconv"
+
 	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/alphameo/nm-tui/internal/ui/tools/zone"
 )
 
 type Model struct {
@@ -20,6 +23,8 @@ type Model struct {
 	tabBarHeight int
 
 	Keys KeyMap
+	// Zones, when set, marks the tabs so that clicks on them switch tabs.
+	Zones *zone.Manager
 }
 
 type Tab struct {
@@ -73,20 +78,22 @@ func (m *Model) Init() tea.Cmd {
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
-	if msg, ok := msg.(tea.KeyPressMsg); ok {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
 		switch {
 		case key.Matches(msg, m.Keys.Next):
-			m.tabContents[m.activeTab].Blur()
-			m.activeTab = min(m.activeTab+1, len(m.tabContents)-1)
-			m.tabContents[m.activeTab].Focus()
-			m.renderTabBar()
-			return m, m.tabContents[m.activeTab].Init()
+			return m, m.switchTab(m.activeTab + 1)
 		case key.Matches(msg, m.Keys.Prev):
-			m.tabContents[m.activeTab].Blur()
-			m.activeTab = max(m.activeTab-1, 0)
-			m.tabContents[m.activeTab].Focus()
-			m.renderTabBar()
-			return m, m.tabContents[m.activeTab].Init()
+			return m, m.switchTab(m.activeTab - 1)
+		}
+	case tea.MouseClickMsg:
+		if msg.Button != tea.MouseLeft || m.Zones == nil {
+			break
+		}
+		for i := range m.tabTitles {
+			if m.Zones.Contains(tabZoneID(i), msg.X, msg.Y) {
+				return m, m.switchTab(i)
+			}
 		}
 	}
 
@@ -110,13 +117,42 @@ func (m Model) View() string {
 
 func (m *Model) ActiveTabIndex() int { return m.activeTab }
 
+// SetActiveTab activates the tab idx, clamped to the existing tabs, unless it
+// is active already.
+func (m *Model) SetActiveTab(idx int) tea.Cmd {
+	if idx == m.activeTab {
+		return nil
+	}
+	return m.switchTab(idx)
+}
+
+// switchTab activates the tab idx, clamped to the existing tabs.
+func (m *Model) switchTab(idx int) tea.Cmd {
+	idx = max(min(idx, len(m.tabContents)-1), 0)
+	m.tabContents[m.activeTab].Blur()
+	m.activeTab = idx
+	m.tabContents[m.activeTab].Focus()
+	m.renderTabBar()
+	return m.tabContents[m.activeTab].Init()
+}
+
 func (m *Model) renderTabBar() {
 	width := m.tabContents[m.activeTab].Width()
-	m.cachedTabBarView = RenderTabBar(
+	tabs := renderTabs(
 		m.tabTitles,
 		m.styles.ActiveTabStyle,
 		m.styles.InactiveTabStyle,
 		width,
 		m.activeTab,
 	)
+	if m.Zones != nil {
+		for i := range tabs {
+			tabs[i] = m.Zones.Mark(tabZoneID(i), tabs[i])
+		}
+	}
+	m.cachedTabBarView = lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+}
+
+func tabZoneID(idx int) string {
+	return "tabview.tab." + strconv.Itoa(idx)
 }
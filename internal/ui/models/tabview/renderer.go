@@ -9,6 +9,19 @@ func RenderTabBar(
 	fullWidth int,
 	active int,
 ) string {
+	renderedTabs := renderTabs(titles, activeStyle, inactiveStyle, fullWidth, active)
+	return lipgloss.JoinHorizontal(lipgloss.Top, renderedTabs...)
+}
+
+// renderTabs renders each tab of the bar separately, so they can be told apart
+// by position.
+func renderTabs(
+	titles []string,
+	activeStyle,
+	inactiveStyle lipgloss.Style,
+	fullWidth int,
+	active int,
+) []string {
 	tabCount := len(titles)
 	tabWidth := fullWidth / tabCount
 	tail := fullWidth % tabCount
@@ -44,6 +57,5 @@ func RenderTabBar(
 		tabView := style.Render(t)
 		renderedTabs = append(renderedTabs, tabView)
 	}
-
-	return lipgloss.JoinHorizontal(lipgloss.Top, renderedTabs...)
+	return renderedTabs
 }
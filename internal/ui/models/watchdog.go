@@ -0,0 +1,260 @@
+package models
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/alphameo/nm-tui/internal/config"
+	"github.com/alphameo/nm-tui/internal/infra"
+)
+
+type watchdogConfig struct {
+	enabled       bool
+	gracePeriod   time.Duration
+	checkInterval time.Duration
+	backoff       time.Duration
+	maxBackoff    time.Duration
+}
+
+var watchdogCfg = watchdogConfig{
+	enabled:       false,
+	gracePeriod:   30 * time.Second,
+	checkInterval: 5 * time.Second,
+	backoff:       time.Minute,
+	maxBackoff:    10 * time.Minute,
+}
+
+func applyWatchdogConfig(cfg *config.WatchdogConfig) {
+	watchdogCfg.enabled = *cfg.Enabled
+	watchdogCfg.gracePeriod = time.Duration(*cfg.GracePeriod) * time.Second
+	watchdogCfg.checkInterval = time.Duration(*cfg.CheckInterval) * time.Second
+	watchdogCfg.backoff = time.Duration(*cfg.Backoff) * time.Second
+	watchdogCfg.maxBackoff = time.Duration(*cfg.MaxBackoff) * time.Second
+}
+
+type watchdogAction int
+
+const (
+	watchdogWait watchdogAction = iota
+	watchdogReconnect
+	watchdogOpenPortal
+)
+
+// watchdog decides when to leave a Wi-Fi network without internet access.
+// It is fed with the connectivity on every check and never acts more often
+// than its backoff allows, so two bad networks in range are not switched
+// back and forth.
+type watchdog struct {
+	cfg watchdogConfig
+
+	// badSince is when connectivity dropped to none or limited, zero while
+	// it is fine.
+	badSince time.Time
+	// goodSince is when connectivity became full, zero while it is not.
+	goodSince time.Time
+	// portalOpened is set once the portal is opened, until connectivity is
+	// full again.
+	portalOpened bool
+	// attempts counts the reconnects since connectivity was last stable.
+	attempts int
+	// nextTry is the earliest time of the next reconnect.
+	nextTry time.Time
+	// tried are the profiles activated during the current outage.
+	tried []string
+}
+
+// observe records the connectivity of the network at now and returns what
+// to do about it. onWifi tells whether a Wi-Fi device is connected, other
+// devices are left alone.
+func (w *watchdog) observe(status infra.ConnectivityStatus, onWifi bool, now time.Time) watchdogAction {
+	if !onWifi {
+		w.badSince = time.Time{}
+		return watchdogWait
+	}
+
+	switch status {
+	case infra.ConnectivityFull:
+		w.badSince = time.Time{}
+		w.portalOpened = false
+		w.tried = nil
+		if w.goodSince.IsZero() {
+			w.goodSince = now
+		}
+		// A connection is stable once it outlasts the longest backoff.
+		if now.Sub(w.goodSince) >= max(w.cfg.backoff, w.cfg.maxBackoff) {
+			w.attempts = 0
+		}
+		return watchdogWait
+	case infra.ConnectivityPortal:
+		w.goodSince = time.Time{}
+		w.badSince = time.Time{}
+		if w.portalOpened {
+			return watchdogWait
+		}
+		w.portalOpened = true
+		return watchdogOpenPortal
+	case infra.ConnectivityNone, infra.ConnectivityLimited:
+		w.goodSince = time.Time{}
+		if w.badSince.IsZero() {
+			w.badSince = now
+		}
+		if now.Sub(w.badSince) < w.cfg.gracePeriod || now.Before(w.nextTry) {
+			return watchdogWait
+		}
+		w.nextTry = now.Add(w.delay())
+		w.attempts++
+		return watchdogReconnect
+	default:
+		// NetworkManager doesn't know, so there is nothing to act on.
+		return watchdogWait
+	}
+}
+
+// delay returns the backoff after the next reconnect: doubled after every
+// attempt, up to the max backoff.
+func (w *watchdog) delay() time.Duration {
+	d := w.cfg.backoff
+	for range w.attempts {
+		if d >= w.cfg.maxBackoff {
+			break
+		}
+		d *= 2
+	}
+	return max(min(d, w.cfg.maxBackoff), w.cfg.backoff)
+}
+
+// nextProfile returns the saved Wi-Fi profile in range to try next: the one
+// with the highest autoconnect priority, not active and not tried during
+// this outage. Once all are tried, they are tried again.
+func (w *watchdog) nextProfile(profiles []NetworkProfileShort) (string, bool) {
+	var candidates []NetworkProfileShort
+	for _, p := range profiles {
+		if p.Available && !p.Active && !p.Hotspot {
+			candidates = append(candidates, p)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+	slices.SortStableFunc(candidates, func(a, b NetworkProfileShort) int {
+		return cmp.Compare(b.AutoconnectPriority, a.AutoconnectPriority)
+	})
+
+	for _, p := range candidates {
+		if !slices.Contains(w.tried, p.Name) {
+			w.tried = append(w.tried, p.Name)
+			return p.Name, true
+		}
+	}
+	w.tried = []string{candidates[0].Name}
+	return candidates[0].Name, true
+}
+
+type watchdogTickMsg struct{}
+
+type watchdogCheckedMsg struct {
+	status infra.ConnectivityStatus
+	onWifi bool
+	err    error
+}
+
+func watchdogTickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return watchdogTickMsg{}
+	})
+}
+
+func watchdogCheckCmd(devMngr infra.DeviceManager) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		status, err := devMngr.GetConnectivityStatus(ctx)
+		if err != nil {
+			return watchdogCheckedMsg{err: err}
+		}
+		devices, err := devMngr.ListNetworkDevices(ctx)
+		if err != nil {
+			return watchdogCheckedMsg{err: err}
+		}
+		onWifi := slices.ContainsFunc(devices, func(d infra.NetworkDevice) bool {
+			return d.Type == "wifi" && d.State == "connected"
+		})
+		return watchdogCheckedMsg{status: status, onWifi: onWifi}
+	}
+}
+
+// startWatchdog starts the checks when the watchdog is enabled and not
+// running yet.
+func (m *MainModel) startWatchdog() tea.Cmd {
+	if !watchdogCfg.enabled || m.watchdogRunning {
+		return nil
+	}
+	m.watchdogRunning = true
+	slog.Info("watchdog started")
+	return watchdogTickCmd(watchdogCfg.checkInterval)
+}
+
+func (m *MainModel) updateWatchdog(msg tea.Msg) tea.Cmd {
+	if !watchdogCfg.enabled {
+		if m.watchdogRunning {
+			m.watchdogRunning = false
+			slog.Info("watchdog stopped")
+		}
+		return nil
+	}
+
+	switch msg := msg.(type) {
+	case watchdogTickMsg:
+		return watchdogCheckCmd(m.device.connMngr)
+	case watchdogCheckedMsg:
+		next := watchdogTickCmd(watchdogCfg.checkInterval)
+		if msg.err != nil {
+			slog.Warn("watchdog check failed", "error", msg.err.Error())
+			return next
+		}
+		// Another activation may be on the way already.
+		if m.networks.indicatorState != NetsDone {
+			return next
+		}
+		m.watchdog.cfg = watchdogCfg
+		return tea.Batch(next, m.autoOpenPortal(msg.status), m.watchdogAct(msg.status, msg.onWifi))
+	}
+	return nil
+}
+
+func (m *MainModel) watchdogAct(status infra.ConnectivityStatus, onWifi bool) tea.Cmd {
+	switch m.watchdog.observe(status, onWifi, time.Now()) {
+	case watchdogOpenPortal:
+		if portalCfg.autoOpen {
+			// autoOpenPortal has opened it already.
+			return nil
+		}
+		slog.Info("watchdog handles the captive portal")
+		return m.portalCmd()
+	case watchdogReconnect:
+		connectivity := strings.ToLower(status.String())
+		name, ok := m.watchdog.nextProfile(m.networks.profiles.profiles)
+		if !ok {
+			slog.Info("watchdog found no profile to switch to", "connectivity", connectivity)
+			return NotifyWarningCmd(fmt.Sprintf(
+				"Watchdog: connectivity is %s, no other saved network in range", connectivity,
+			))
+		}
+		slog.Info("watchdog switches profile",
+			"profile", name,
+			"connectivity", connectivity,
+			"attempt", m.watchdog.attempts,
+			"next_try", m.watchdog.nextTry.Format(time.TimeOnly),
+		)
+		return tea.Batch(
+			NotifyWarningCmd(fmt.Sprintf("Watchdog: connectivity is %s, switching to %q", connectivity, name)),
+			m.networks.profiles.activateConnCmd(name),
+		)
+	}
+	return nil
+}
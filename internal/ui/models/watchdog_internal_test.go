@@ -0,0 +1,144 @@
+package models
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/alphameo/nm-tui/internal/infra"
+)
+
+func TestWatchdogObserve(t *testing.T) {
+	t.Parallel()
+
+	const (
+		none    = infra.ConnectivityNone
+		limited = infra.ConnectivityLimited
+		portal  = infra.ConnectivityPortal
+		full    = infra.ConnectivityFull
+		unknown = infra.ConnectivityUnknown
+	)
+	type step struct {
+		at     time.Duration
+		status infra.ConnectivityStatus
+		onWifi bool
+		want   watchdogAction
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"waits for the grace period", []step{
+			{0, none, true, watchdogWait},
+			{20 * time.Second, limited, true, watchdogWait},
+			{30 * time.Second, limited, true, watchdogReconnect},
+		}},
+		{"backs off after a reconnect", []step{
+			{0, none, true, watchdogWait},
+			{30 * time.Second, none, true, watchdogReconnect},
+			{80 * time.Second, none, true, watchdogWait},
+			{90 * time.Second, none, true, watchdogReconnect},
+			// The backoff doubled to 2 minutes.
+			{200 * time.Second, none, true, watchdogWait},
+			{210 * time.Second, none, true, watchdogReconnect},
+		}},
+		{"a short recovery keeps the backoff", []step{
+			{0, none, true, watchdogWait},
+			{30 * time.Second, none, true, watchdogReconnect},
+			{35 * time.Second, full, true, watchdogWait},
+			{40 * time.Second, none, true, watchdogWait},
+			{80 * time.Second, none, true, watchdogWait},
+			{90 * time.Second, none, true, watchdogReconnect},
+		}},
+		{"the outage restarts on full connectivity", []step{
+			{0, none, true, watchdogWait},
+			{20 * time.Second, full, true, watchdogWait},
+			{25 * time.Second, none, true, watchdogWait},
+			{50 * time.Second, none, true, watchdogWait},
+			{55 * time.Second, none, true, watchdogReconnect},
+		}},
+		{"other devices are left alone", []step{
+			{0, none, false, watchdogWait},
+			{time.Minute, none, false, watchdogWait},
+			{2 * time.Minute, portal, false, watchdogWait},
+		}},
+		{"unknown connectivity is ignored", []step{
+			{0, unknown, true, watchdogWait},
+			{time.Minute, unknown, true, watchdogWait},
+		}},
+		{"the portal opens once per outage", []step{
+			{0, portal, true, watchdogOpenPortal},
+			{5 * time.Second, portal, true, watchdogWait},
+			{10 * time.Second, limited, true, watchdogWait},
+			{15 * time.Second, portal, true, watchdogWait},
+			{20 * time.Second, full, true, watchdogWait},
+			{25 * time.Second, portal, true, watchdogOpenPortal},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := watchdog{cfg: watchdogConfig{
+				gracePeriod: 30 * time.Second,
+				backoff:     time.Minute,
+				maxBackoff:  10 * time.Minute,
+			}}
+			start := time.Now()
+			for i, s := range tt.steps {
+				if got := w.observe(s.status, s.onWifi, start.Add(s.at)); got != s.want {
+					t.Fatalf("step %d at %v: observe(%v) = %v, want %v", i, s.at, s.status, got, s.want)
+				}
+			}
+		})
+	}
+}
+
+func TestWatchdogDelay(t *testing.T) {
+	t.Parallel()
+
+	w := watchdog{cfg: watchdogConfig{backoff: time.Minute, maxBackoff: 5 * time.Minute}}
+	want := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}
+	for i, d := range want {
+		w.attempts = i
+		if got := w.delay(); got != d {
+			t.Errorf("delay() after %d attempts = %v, want %v", i, got, d)
+		}
+	}
+
+	w.cfg.maxBackoff = 30 * time.Second
+	if got := w.delay(); got != time.Minute {
+		t.Errorf("delay() with max_backoff < backoff = %v, want the backoff", got)
+	}
+}
+
+func TestWatchdogNextProfile(t *testing.T) {
+	t.Parallel()
+
+	profiles := []NetworkProfileShort{
+		{Name: "Home", Available: true, Active: true, AutoconnectPriority: 10},
+		{Name: "Cafe", Available: true},
+		{Name: "Office", Available: true, AutoconnectPriority: 5},
+		{Name: "Airport", AutoconnectPriority: 20},
+		{Name: "Hotspot", Available: true, Hotspot: true, AutoconnectPriority: 30},
+	}
+
+	var w watchdog
+	var got []string
+	for range 3 {
+		name, ok := w.nextProfile(profiles)
+		if !ok {
+			t.Fatal("nextProfile() found no profile")
+		}
+		got = append(got, name)
+	}
+	want := []string{"Office", "Cafe", "Office"}
+	if !slices.Equal(got, want) {
+		t.Errorf("nextProfile() = %q, want %q", got, want)
+	}
+
+	if name, ok := w.nextProfile(profiles[:1]); ok {
+		t.Errorf("nextProfile() with only the active profile = %q, want none", name)
+	}
+}
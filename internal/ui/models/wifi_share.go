@@ -0,0 +1,160 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/alphameo/nm-tui/internal/ui/styles"
+	"github.com/alphameo/nm-tui/internal/ui/tools/compositor"
+	"github.com/alphameo/nm-tui/internal/ui/tools/qr"
+	"github.com/alphameo/nm-tui/internal/ui/tools/renderer"
+	"github.com/alphameo/nm-tui/internal/wifiuri"
+)
+
+type wifiShareConfig struct {
+	title   string
+	ecLevel qr.ECLevel
+}
+
+var wifiShareCfg = wifiShareConfig{
+	title:   "Share Wi-Fi",
+	ecLevel: qr.ECMedium,
+}
+
+// WifiShareModel shows the credentials of a saved profile as a WIFI: QR code,
+// ready to be scanned by a phone camera.
+type WifiShareModel struct {
+	ssid    string
+	hotspot bool
+	code    string
+
+	netMngr infra.NetworksManager
+	Style   lipgloss.Style
+}
+
+// wifiShareMsg carries the QR code of a profile, built off the Update loop.
+type wifiShareMsg struct {
+	ssid    string
+	hotspot bool
+	code    string
+}
+
+// errShareEnterprise tells that a profile authenticates with EAP, so it has
+// no password a WIFI: URI could carry.
+var errShareEnterprise = errors.New("cannot share enterprise networks")
+
+func NewWifiShareModel(networksManager infra.NetworksManager) *WifiShareModel {
+	return &WifiShareModel{
+		netMngr: networksManager,
+		Style:   lipgloss.NewStyle(),
+	}
+}
+
+// setProfileCmd loads the profile with the given name and opens the popup
+// with its QR code, or notifies when the code cannot be built.
+func (m *WifiShareModel) setProfileCmd(name string) tea.Cmd {
+	return func() tea.Msg {
+		info, err := m.netMngr.GetProfile(context.Background(), name)
+		if err != nil {
+			return NotifyErrorCmd(fmt.Sprintf("Cannot get information about %s", name))
+		}
+
+		creds, err := shareCredentials(info)
+		if err != nil {
+			return NotifyErrorCmd(fmt.Sprintf("Cannot share %s:\n%v", name, err))
+		}
+		code, err := qr.Encode([]byte(wifiuri.Format(creds)), wifiShareCfg.ecLevel)
+		if err != nil {
+			return NotifyErrorCmd(fmt.Sprintf("Cannot build QR code for %s:\n%v", name, err))
+		}
+
+		return wifiShareMsg{
+			ssid:    info.SSID,
+			hotspot: info.Mode == infra.NetworkAccessPoint,
+			code:    qr.Render(code, qr.DefaultQuietZone, qr.HalfBlocks()),
+		}
+	}
+}
+
+// shareCredentials returns what a phone needs to join the network of info.
+func shareCredentials(info infra.NetworkProfile) (wifiuri.Credentials, error) {
+	security, err := shareSecurity(info.KeyMgmt, info.Password)
+	if err != nil {
+		return wifiuri.Credentials{}, err
+	}
+	return wifiuri.Credentials{
+		SSID:     info.SSID,
+		Security: security,
+		Password: info.Password,
+		Hidden:   info.Hidden,
+	}, nil
+}
+
+// shareSecurity maps the key management of NetworkManager to the WIFI: URI
+// types. Unknown ones are left empty for wifiuri.Format to guess from the
+// password.
+func shareSecurity(keyMgmt, password string) (wifiuri.Security, error) {
+	switch keyMgmt {
+	case "", "owe":
+		return wifiuri.SecurityNone, nil
+	case "none":
+		// NetworkManager calls static WEP "none", but nm-tui saves every
+		// open profile that way too.
+		if password == "" {
+			return wifiuri.SecurityNone, nil
+		}
+		return wifiuri.SecurityWEP, nil
+	case "wpa-psk", "sae":
+		return wifiuri.SecurityWPA, nil
+	case "ieee8021x", "wpa-eap", "wpa-eap-suite-b-192":
+		return "", errShareEnterprise
+	default:
+		return "", nil
+	}
+}
+
+func (m *WifiShareModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *WifiShareModel) Update(msg tea.Msg) (*WifiShareModel, tea.Cmd) {
+	if msg, ok := msg.(wifiShareMsg); ok {
+		m.ssid, m.hotspot, m.code = msg.ssid, msg.hotspot, msg.code
+		return m, OpenPopupCmd(m)
+	}
+	return m, nil
+}
+
+func (m *WifiShareModel) UpdateAsPopup(msg tea.Msg) (PopupModel, tea.Cmd) {
+	return m.Update(msg)
+}
+
+func (m *WifiShareModel) View() string {
+	symbol := styles.SymbolSaved
+	if m.hotspot {
+		symbol = styles.SymbolAccessPoint
+	}
+	caption := fmt.Sprintf("%s %s", symbol, styles.BoldStyle.Render(m.ssid))
+
+	view := lipgloss.JoinVertical(
+		lipgloss.Center,
+		styles.QRStyle.Render(m.code),
+		"",
+		caption,
+	)
+
+	view = m.Style.Render(view)
+	title := styles.DefaultStyle.Render(renderer.RenderTitle(wifiShareCfg.title))
+	return compositor.Compose(
+		title,
+		view,
+		compositor.Center,
+		compositor.Begin,
+		0,
+		0,
+	)
+}
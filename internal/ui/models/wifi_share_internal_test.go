@@ -0,0 +1,73 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/infra"
+	"github.com/alphameo/nm-tui/internal/wifiuri"
+)
+
+func TestShareCredentials(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		info    infra.NetworkProfile
+		want    string
+		wantErr error
+	}{
+		{
+			name: "wpa",
+			info: infra.NetworkProfile{SSID: "home", Password: "secret", KeyMgmt: "wpa-psk"},
+			want: "WIFI:T:WPA;S:home;P:secret;;",
+		},
+		{
+			name: "wpa3 hidden",
+			info: infra.NetworkProfile{SSID: "lab", Password: "secret", KeyMgmt: "sae", Hidden: true},
+			want: "WIFI:T:WPA;S:lab;P:secret;H:true;;",
+		},
+		{
+			name: "wep",
+			info: infra.NetworkProfile{SSID: "old", Password: "abcde", KeyMgmt: "none"},
+			want: "WIFI:T:WEP;S:old;P:abcde;;",
+		},
+		{
+			name: "open saved by nm-tui",
+			info: infra.NetworkProfile{SSID: "cafe", KeyMgmt: "none"},
+			want: "WIFI:T:nopass;S:cafe;;",
+		},
+		{
+			name: "open",
+			info: infra.NetworkProfile{SSID: "cafe"},
+			want: "WIFI:T:nopass;S:cafe;;",
+		},
+		{
+			name:    "enterprise",
+			info:    infra.NetworkProfile{SSID: "corp", KeyMgmt: "wpa-eap"},
+			wantErr: errShareEnterprise,
+		},
+		{
+			name:    "enterprise suite b",
+			info:    infra.NetworkProfile{SSID: "corp", KeyMgmt: "wpa-eap-suite-b-192"},
+			wantErr: errShareEnterprise,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			creds, err := shareCredentials(tt.info)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("shareCredentials(%+v) error = %v, want %v", tt.info, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := wifiuri.Format(creds); got != tt.want {
+				t.Errorf("Format(shareCredentials(%+v)) = %q, want %q", tt.info, got, tt.want)
+			}
+		})
+	}
+}
@@ -8,17 +8,22 @@ import (
 )
 
 var (
-	SymbolPwHiddenChar rune
-	SymbolError        string
-	SymbolCheck        string
-	SymbolConnection   string
-	SymbolSignal       string
-	SymbolSaved        string
-	SymbolAvailable    string
-	SymbolAccessPoint  string
-	SymbolInfra        string
-	SymbolMesh         string
-	SymbolAdHoc        string
+	SymbolPwHiddenChar   rune
+	SymbolError          string
+	SymbolCheck          string
+	SymbolConnection     string
+	SymbolSignal         string
+	SymbolSaved          string
+	SymbolAvailable      string
+	SymbolAccessPoint    string
+	SymbolInfra          string
+	SymbolMesh           string
+	SymbolAdHoc          string
+	SymbolMarked         string
+	SymbolSortAscending  string
+	SymbolSortDescending string
+	SymbolSignalRising   string
+	SymbolSignalFalling  string
 )
 
 var (
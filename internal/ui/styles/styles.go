@@ -18,7 +18,15 @@ import (
 )
 
 var (
-	userTermFG string
+	// userTermFG and userTermBG are queried from the terminal once, on the
+	// first Init. Later calls reuse them, since the running program owns the
+	// terminal input by then.
+	userTermFG     string
+	userTermBG     string
+	termColorsRead bool
+
+	// theme styles single elements on top of the palette, nil until Init.
+	theme *config.ThemeVariant
 
 	TextColor   color.Color
 	BgColor     color.Color
@@ -32,6 +40,11 @@ var (
 	MutedStyle   lipgloss.Style
 	BoldStyle    lipgloss.Style
 
+	// CueAppearedStyle and CueVanishedStyle point out table rows changed by
+	// the latest rescan.
+	CueAppearedStyle lipgloss.Style
+	CueVanishedStyle lipgloss.Style
+
 	Border               lipgloss.Border = lipgloss.RoundedBorder()
 	BorderedStyle        lipgloss.Style
 	BorderedFocusedStyle lipgloss.Style
@@ -50,16 +63,55 @@ var (
 
 	OverlayStyle       lipgloss.Style
 	NotifBorderedStyle lipgloss.Style
+	NotifWarningStyle  lipgloss.Style
+	NotifErrorStyle    lipgloss.Style
+
+	// signalGradient colors signal strength from 0 to 100 in equal steps.
+	signalGradient []color.Color
+
+	// QRStyle keeps QR codes light-on-dark regardless of the colorscheme, so
+	// they stay scannable.
+	QRStyle lipgloss.Style
 
 	Spinner spinner.Spinner = spinner.Line
 )
 
+// Init builds all styles from cfg. It may be called again to apply a changed
+// config.
 func Init(cfg config.Config) error {
-	err := initIcons(*cfg.Icons)
+	if !termColorsRead {
+		fg, bg, err := queryTerminalColors()
+		if err != nil {
+			return err
+		}
+		userTermFG, userTermBG = fg, bg
+		termColorsRead = true
+	}
+
+	t, err := config.LoadTheme(*cfg.Theme.Name)
+	if err != nil {
+		return err
+	}
+
+	err = initIcons(*cfg.Icons)
+	if err != nil {
+		return err
+	}
+
+	dark := darkBackground(userTermFG, userTermBG)
+	switch *cfg.Theme.Variant {
+	case config.ThemeVariantLight:
+		dark = false
+	case config.ThemeVariantDark:
+		dark = true
+	}
+	theme = t.Variant(dark)
+
+	err = initColors(themePalette(*cfg.Colors, theme))
 	if err != nil {
 		return err
 	}
-	err = initColors(*cfg.Colors)
+	err = initSignalGradient(theme.SignalGradient)
 	if err != nil {
 		return err
 	}
@@ -78,10 +130,17 @@ func UpdateColorscheme() {
 	AccentStyle = DefaultStyle.Foreground(AccentColor).Bold(true)
 	MutedStyle = DefaultStyle.Foreground(MutedColor)
 	BoldStyle = DefaultStyle.Bold(true)
+	CueAppearedStyle = DefaultStyle.Foreground(NotifColor)
+	CueVanishedStyle = MutedStyle.Strikethrough(true)
 
 	BorderedStyle = DefaultStyle.Border(Border).BorderForeground(TextColor).BorderBackground(BgColor)
 	BorderedFocusedStyle = BorderedStyle.BorderForeground(AccentColor)
 
+	var elements config.ThemeVariant
+	if theme != nil {
+		elements = *theme
+	}
+
 	TableStyles = tableStyles()
 	DataTableStyles = dataTableStyles()
 
@@ -91,7 +150,13 @@ func UpdateColorscheme() {
 
 	HelpStyles = helpStyles()
 
+	TableStyles.Header = withElement(TableStyles.Header, elements.TableHeader)
+	DataTableStyles.Header = TableStyles.Header
+	TableStyles.Selected = withElement(TableStyles.Selected, elements.SelectedRow)
+
 	TabViewStyles = tabview.GenerateStyles(BorderedStyle)
+	TabViewStyles.ActiveTabStyle = withElement(TabViewStyles.ActiveTabStyle, elements.TabActive)
+	TabViewStyles.InactiveTabStyle = withElement(TabViewStyles.InactiveTabStyle, elements.TabInactive)
 
 	OverlayStyle = DefaultStyle.
 		Border(Border).
@@ -99,12 +164,78 @@ func UpdateColorscheme() {
 		Padding(2, 4).
 		BorderForeground(AccentColor).
 		BorderBackground(BgColor)
+	OverlayStyle = withBorderElement(OverlayStyle, elements.PopupBorder)
+
+	notifStyle := OverlayStyle.BorderForeground(NotifColor)
+	NotifBorderedStyle = withBorderElement(notifStyle, elements.NotifInfo)
+	NotifWarningStyle = withBorderElement(notifStyle, elements.NotifWarning)
+	NotifErrorStyle = withBorderElement(notifStyle.BorderForeground(ErrorColor), elements.NotifError)
 
-	NotifBorderedStyle = OverlayStyle.BorderForeground(NotifColor)
+	QRStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#ffffff")).
+		Background(lipgloss.Color("#000000"))
 
 	SymbolColoredError = DefaultStyle.Foreground(ErrorColor).Render(SymbolError)
 }
 
+// withElement layers the theme style of an element over style.
+func withElement(style lipgloss.Style, e *config.ElementStyle) lipgloss.Style {
+	if e == nil {
+		return style
+	}
+	if c, ok := elementColor(e.Fg); ok {
+		style = style.Foreground(c)
+	}
+	if c, ok := elementColor(e.Bg); ok {
+		style = style.Background(c)
+	}
+	if e.Bold != nil {
+		style = style.Bold(*e.Bold)
+	}
+	return style
+}
+
+// withBorderElement layers the theme style of a bordered element over style,
+// its colors going to the border.
+func withBorderElement(style lipgloss.Style, e *config.ElementStyle) lipgloss.Style {
+	if e == nil {
+		return style
+	}
+	if c, ok := elementColor(e.Fg); ok {
+		style = style.BorderForeground(c)
+	}
+	if c, ok := elementColor(e.Bg); ok {
+		style = style.BorderBackground(c)
+	}
+	if e.Bold != nil {
+		style = style.Bold(*e.Bold)
+	}
+	return style
+}
+
+// elementColor resolves a theme color, which was validated when the theme was
+// loaded. Unset and "default" colors report false.
+func elementColor(cfgColor *string) (color.Color, bool) {
+	if cfgColor == nil || *cfgColor == config.DefaultKeyword {
+		return nil, false
+	}
+	c, err := resolveCfgColor(*cfgColor)
+	if err != nil {
+		return nil, false
+	}
+	return lipgloss.Color(c), true
+}
+
+// SignalStyle colors a signal strength from 0 to 100 along the gradient of
+// the theme.
+func SignalStyle(signal int) lipgloss.Style {
+	if len(signalGradient) == 0 {
+		return DefaultStyle
+	}
+	signal = min(max(signal, 0), 100)
+	return DefaultStyle.Foreground(signalGradient[signal*(len(signalGradient)-1)/100])
+}
+
 func tableStyles() table.Styles {
 	return table.Styles{
 		Selected: lipgloss.NewStyle().
@@ -198,19 +329,65 @@ func initIcons(icons config.IconConfig) error {
 	SymbolInfra = *icons.Infra
 	SymbolMesh = *icons.Mesh
 	SymbolAdHoc = *icons.AdHoc
+	SymbolMarked = *icons.Marked
+	SymbolSortAscending = *icons.SortAscending
+	SymbolSortDescending = *icons.SortDescending
+	SymbolSignalRising = *icons.SignalRising
+	SymbolSignalFalling = *icons.SignalFalling
 	SymbolEllipsis = *icons.Ellipsis
 	SymbolSeparator = *icons.Separator
 
 	return nil
 }
 
-func initColors(colors config.ColorConfig) error {
-	var err error
-	userTermFG, err = queryTerminalForegroundColor()
-	if err != nil {
-		return err
+// themePalette starts from the palette of the theme and keeps the user colors
+// that differ from the defaults.
+func themePalette(user config.ColorConfig, theme *config.ThemeVariant) config.ColorConfig {
+	palette := *config.DefaultColorConfig()
+	if theme.Colors != nil {
+		// Theme colors are validated when the theme is loaded.
+		_ = palette.Merge(theme.Colors)
 	}
 
+	defaults := config.DefaultColorConfig()
+	keep := func(dst **string, user, def *string) {
+		if *user != *def {
+			*dst = user
+		}
+	}
+	keep(&palette.Text, user.Text, defaults.Text)
+	keep(&palette.Accent, user.Accent, defaults.Accent)
+	keep(&palette.Muted, user.Muted, defaults.Muted)
+	keep(&palette.Error, user.Error, defaults.Error)
+	keep(&palette.Notif, user.Notif, defaults.Notif)
+	return palette
+}
+
+// initSignalGradient blends the stops of the theme gradient. Terminal colors
+// have no fixed value to blend, so a gradient using them keeps its stops as
+// they are.
+func initSignalGradient(stops []string) error {
+	const steps = 11
+
+	colors := make([]color.Color, len(stops))
+	blend := true
+	for i, stop := range stops {
+		c, err := resolveCfgColor(stop)
+		if err != nil {
+			return err
+		}
+		colors[i] = lipgloss.Color(c)
+		blend = blend && config.ValidHex(strings.ToLower(stop))
+	}
+
+	if blend && len(colors) > 1 {
+		colors = lipgloss.Blend1D(steps, colors...)
+	}
+	signalGradient = colors
+	return nil
+}
+
+func initColors(colors config.ColorConfig) error {
 	color, err := resolveCfgColor(*colors.Text)
 	if err != nil {
 		return err
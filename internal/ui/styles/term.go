@@ -7,18 +7,20 @@ import (
 	"os"
 	"time"
 
+	"github.com/alphameo/nm-tui/internal/config"
 	"github.com/charmbracelet/x/ansi"
 	"github.com/charmbracelet/x/term"
 )
 
-// queryTerminalForegroundColor sends an OSC 10 query to the controlling
-// terminal and reads back the reply, returning the color as a "#rrggbb" hex
-// string. It fails gracefully when the process has no terminal or the terminal
-// does not answer within the deadline.
-func queryTerminalForegroundColor() (string, error) {
+// queryTerminalColors sends OSC 10 and OSC 11 queries to the controlling
+// terminal and reads back the replies, returning the foreground and background
+// colors as "#rrggbb" hex strings. The background is empty when the terminal
+// answers only the first query. It fails gracefully when the process has no
+// terminal or the terminal does not answer within the deadline.
+func queryTerminalColors() (fg, bg string, err error) {
 	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	defer func() { _ = tty.Close() }()
 
@@ -27,47 +29,54 @@ func queryTerminalForegroundColor() (string, error) {
 	// arrives, then restore the previous state before bubbletea takes over.
 	state, err := term.MakeRaw(tty.Fd())
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	defer func() { _ = term.Restore(tty.Fd(), state) }()
 
-	if _, err = tty.WriteString(ansi.RequestForegroundColor); err != nil {
-		return "", err
+	if _, err = tty.WriteString(ansi.RequestForegroundColor + ansi.RequestBackgroundColor); err != nil {
+		return "", "", err
 	}
 
 	if err = tty.SetReadDeadline(time.Now().Add(150 * time.Millisecond)); err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	buf := make([]byte, 0, 64)
+	buf := make([]byte, 0, 128)
 	tmp := make([]byte, 64)
-	for {
+	for oscReplies(buf) < 2 {
 		var n int
 		n, err = tty.Read(tmp)
-		if n > 0 {
-			buf = append(buf, tmp[:n]...)
-			if bytes.Contains(buf, []byte{ansi.BEL}) || bytes.Contains(buf, []byte{ansi.ESC, '\\'}) {
-				break
-			}
-		}
+		buf = append(buf, tmp[:n]...)
 		if err != nil {
-			return "", err
+			break
 		}
 	}
 
-	hex, err := parseOSCColor(buf)
+	fg, err = parseOSCColor(buf)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
-	return hex, nil
+	bg, _ = parseOSCReply(buf, 11)
+	return fg, bg, nil
+}
+
+// oscReplies counts the terminated OSC sequences in buf.
+func oscReplies(buf []byte) int {
+	return bytes.Count(buf, []byte{ansi.BEL}) + bytes.Count(buf, []byte{ansi.ESC, '\\'})
 }
 
 // parseOSCColor extracts the color payload from an OSC 10 response such as
 // "\x1b]10;rgb:dddd/eeee/ffff\x07" or "\x1b]10;#ffffff\x07".
 func parseOSCColor(buf []byte) (string, error) {
-	_, after, ok := bytes.Cut(buf, []byte("10;"))
+	return parseOSCReply(buf, 10)
+}
+
+// parseOSCReply extracts the color payload of the OSC reply numbered ps, e.g.
+// 10 for the foreground and 11 for the background.
+func parseOSCReply(buf []byte, ps int) (string, error) {
+	_, after, ok := bytes.Cut(buf, fmt.Appendf(nil, "]%d;", ps))
 	if !ok {
-		return "", fmt.Errorf("no OSC 10 payload in %q", string(buf))
+		return "", fmt.Errorf("no OSC %d payload in %q", ps, string(buf))
 	}
 	payload := after
 
@@ -85,6 +94,32 @@ func parseOSCColor(buf []byte) (string, error) {
 	return colorHex(c), nil
 }
 
+// darkBackground tells whether the terminal has a dark background, judging by
+// a light foreground when the background is unknown. Dark is assumed when the
+// terminal reported neither.
+func darkBackground(fg, bg string) bool {
+	if c, ok := parseHex(bg); ok {
+		return luminance(c) < 0.5
+	}
+	if c, ok := parseHex(fg); ok {
+		return luminance(c) >= 0.5
+	}
+	return true
+}
+
+func parseHex(hex string) (color.Color, bool) {
+	if !config.ValidHex(hex) {
+		return nil, false
+	}
+	return ansi.XParseColor(hex), true
+}
+
+// luminance returns the relative luminance of c between 0 and 1.
+func luminance(c color.Color) float64 {
+	r, g, b, _ := c.RGBA()
+	return (0.2126*float64(r) + 0.7152*float64(g) + 0.0722*float64(b)) / 0xffff
+}
+
 // colorHex renders a [color.Color] as a "#rrggbb" string.
 func colorHex(c color.Color) string {
 	if c == nil {
@@ -43,3 +43,85 @@ func TestParseOSCColor(t *testing.T) {
 		})
 	}
 }
+
+func TestParseOSCReply(t *testing.T) {
+	t.Parallel()
+
+	both := []byte("\x1b]10;rgb:ffff/ffff/ffff\x1b\\\x1b]11;rgb:0000/0000/0000\x1b\\")
+	tests := []struct {
+		name string
+		in   []byte
+		ps   int
+		want string
+		err  bool
+	}{
+		{name: "background", in: []byte("\x1b]11;#282828\x07"), ps: 11, want: "#282828"},
+		{name: "foreground of both", in: both, ps: 10, want: "#ffffff"},
+		{name: "background of both", in: both, ps: 11, want: "#000000"},
+		{name: "background missing", in: []byte("\x1b]10;#ffffff\x07"), ps: 11, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseOSCReply(tt.in, tt.ps)
+			if tt.err {
+				if err == nil {
+					t.Errorf("parseOSCReply(%q, %d) = %q, want error", tt.in, tt.ps, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseOSCReply(%q, %d) error: %v", tt.in, tt.ps, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseOSCReply(%q, %d) = %q, want %q", tt.in, tt.ps, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOSCReplies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"", 0},
+		{"\x1b]10;#ffffff", 0},
+		{"\x1b]10;#ffffff\x07", 1},
+		{"\x1b]10;#ffffff\x07\x1b]11;#000000\x1b\\", 2},
+	}
+	for _, tt := range tests {
+		if got := oscReplies([]byte(tt.in)); got != tt.want {
+			t.Errorf("oscReplies(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDarkBackground(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		fg, bg string
+		want   bool
+	}{
+		{name: "dark background", fg: "#ffffff", bg: "#1e1e2e", want: true},
+		{name: "light background", fg: "#000000", bg: "#eff1f5", want: false},
+		{name: "background wins over foreground", fg: "#ffffff", bg: "#fafafa", want: false},
+		{name: "light foreground only", fg: "#dddddd", want: true},
+		{name: "dark foreground only", fg: "#111111", want: false},
+		{name: "nothing known", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := darkBackground(tt.fg, tt.bg); got != tt.want {
+				t.Errorf("darkBackground(%q, %q) = %v, want %v", tt.fg, tt.bg, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Package fuzzy implements case-insensitive subsequence matching used to
+// filter table rows as the user types
+package fuzzy
+
+import (
+	"unicode"
+)
+
+const (
+	scoreMatch       = 16
+	bonusBoundary    = 8
+	bonusConsecutive = 8
+	penaltyGap       = 1
+)
+
+// Match reports whether every rune of pattern occurs in text in the same
+// order, ignoring case. positions holds the rune indices of text that matched,
+// and a higher score means a tighter match: runes at word boundaries and runs
+// of consecutive runes are preferred over scattered ones. An empty pattern
+// matches everything with zero score.
+func Match(pattern, text string) (positions []int, score int, ok bool) {
+	pat := []rune(pattern)
+	if len(pat) == 0 {
+		return nil, 0, true
+	}
+	txt := []rune(text)
+
+	// Find where the leftmost occurrence ends, then walk back from there to
+	// find the shortest window that still holds the whole pattern.
+	end := -1
+	for i, p := 0, 0; i < len(txt); i++ {
+		if equalFold(txt[i], pat[p]) {
+			p++
+			if p == len(pat) {
+				end = i
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return nil, 0, false
+	}
+
+	positions = make([]int, len(pat))
+	for i, p := end, len(pat)-1; p >= 0; i-- {
+		if equalFold(txt[i], pat[p]) {
+			positions[p] = i
+			p--
+		}
+	}
+
+	for i, pos := range positions {
+		score += scoreMatch
+		if pos == 0 || isBoundary(txt[pos-1], txt[pos]) {
+			score += bonusBoundary
+		}
+		if i > 0 {
+			if gap := pos - positions[i-1] - 1; gap == 0 {
+				score += bonusConsecutive
+			} else {
+				score -= gap * penaltyGap
+			}
+		}
+	}
+	return positions, score, true
+}
+
+func equalFold(a, b rune) bool {
+	return a == b || unicode.ToLower(a) == unicode.ToLower(b)
+}
+
+// isBoundary reports whether cur starts a word: it follows a separator or is
+// an upper case letter after a lower case one.
+func isBoundary(prev, cur rune) bool {
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(cur)
+}
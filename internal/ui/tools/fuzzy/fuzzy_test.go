@@ -0,0 +1,68 @@
+package fuzzy_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/ui/tools/fuzzy"
+)
+
+func TestMatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		pattern, text string
+		wantPositions []int
+		wantOk        bool
+	}{
+		{"empty-pattern-matches", "", "Home", nil, true},
+		{"ignores-case", "HOME", "home", []int{0, 1, 2, 3}, true},
+		{"subsequence", "hn", "HomeNet", []int{0, 4}, true},
+		{"out-of-order-fails", "nh", "HomeNet", nil, false},
+		{"longer-than-text-fails", "homes", "home", nil, false},
+		{"prefers-shortest-window", "ab", "a-xab", []int{3, 4}, true},
+		{"multibyte-runes", "ü", "Grüße", []int{2}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			positions, _, ok := fuzzy.Match(tt.pattern, tt.text)
+			if ok != tt.wantOk {
+				t.Fatalf("Match(%q, %q) ok = %v, want %v", tt.pattern, tt.text, ok, tt.wantOk)
+			}
+			if !reflect.DeepEqual(positions, tt.wantPositions) {
+				t.Errorf("Match(%q, %q) positions = %v, want %v", tt.pattern, tt.text, positions, tt.wantPositions)
+			}
+		})
+	}
+}
+
+func TestMatchScore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		pattern       string
+		better, worse string
+	}{
+		{"consecutive-over-scattered", "cafe", "Cafe Central", "C-a-f-e"},
+		{"boundary-over-inner", "n", "Home Net", "Home_ant"},
+		{"camel-case-boundary", "hn", "HomeNet", "Homenet"},
+		{"short-gap-over-long-gap", "ht", "Hot", "Home_Net"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, better, _ := fuzzy.Match(tt.pattern, tt.better)
+			_, worse, _ := fuzzy.Match(tt.pattern, tt.worse)
+			if better <= worse {
+				t.Errorf("score(%q) = %d, want above score(%q) = %d", tt.better, better, tt.worse, worse)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Package graph draws rolling series of samples as sparklines and braille
+// graphs, one character per sample or two samples per character.
+package graph
+
+import (
+	"math"
+	"strings"
+)
+
+// Series is a rolling window of samples, the oldest one is dropped when it
+// is full. Missing samples are NaN and drawn as gaps.
+type Series struct {
+	values []float64
+	// start is the index of the oldest sample.
+	start int
+	n     int
+}
+
+func NewSeries(capacity int) *Series {
+	return &Series{values: make([]float64, max(capacity, 1))}
+}
+
+func (s *Series) Push(v float64) {
+	if s.n < len(s.values) {
+		s.values[(s.start+s.n)%len(s.values)] = v
+		s.n++
+		return
+	}
+	s.values[s.start] = v
+	s.start = (s.start + 1) % len(s.values)
+}
+
+// Values returns the samples, the oldest first.
+func (s *Series) Values() []float64 {
+	res := make([]float64, s.n)
+	for i := range res {
+		res[i] = s.values[(s.start+i)%len(s.values)]
+	}
+	return res
+}
+
+// Last returns the newest sample.
+func (s *Series) Last() (float64, bool) {
+	if s.n == 0 {
+		return 0, false
+	}
+	return s.values[(s.start+s.n-1)%len(s.values)], true
+}
+
+func (s *Series) Len() int { return s.n }
+
+func (s *Series) Cap() int { return len(s.values) }
+
+// Resize changes the capacity, keeping the newest samples.
+func (s *Series) Resize(capacity int) {
+	values := s.Values()
+	*s = *NewSeries(capacity)
+	for _, v := range values[max(len(values)-len(s.values), 0):] {
+		s.Push(v)
+	}
+}
+
+func (s *Series) Reset() {
+	s.start, s.n = 0, 0
+}
+
+// Max returns the largest of values, ignoring gaps, or 0 without any.
+func Max(values []float64) float64 {
+	res := 0.0
+	for _, v := range values {
+		if !math.IsNaN(v) && v > res {
+			res = v
+		}
+	}
+	return res
+}
+
+// sparkBars are the levels of a sparkline, from the lowest.
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws the last width values, one bar each, scaled to top. It is
+// right-aligned: the newest value is the last bar, missing ones are spaces.
+func Sparkline(values []float64, width int, top float64) string {
+	if width <= 0 {
+		return ""
+	}
+	values = values[max(len(values)-width, 0):]
+
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat(" ", width-len(values)))
+	for _, v := range values {
+		if math.IsNaN(v) {
+			sb.WriteByte(' ')
+			continue
+		}
+		level := scale(v, top, len(sparkBars)-1)
+		sb.WriteRune(sparkBars[level])
+	}
+	return sb.String()
+}
+
+// brailleBase is the empty braille pattern, the dots are added to it.
+const brailleBase = 0x2800
+
+// brailleDots are the bits of the dots of a braille cell, by column and by
+// row from the top.
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// Braille draws the last 2*width values as a filled area, height lines high,
+// scaled to top. Each character holds two values and four levels each, so
+// the graph is twice as dense as a sparkline and four times as tall. It is
+// right-aligned like [Sparkline].
+func Braille(values []float64, width, height int, top float64) string {
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+	cols := 2 * width
+	values = values[max(len(values)-cols, 0):]
+	offset := cols - len(values)
+	dotRows := 4 * height
+
+	// levels[x] is the number of dots lit from the bottom in column x.
+	levels := make([]int, cols)
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		level := scale(v, top, dotRows)
+		// A value above zero is never drawn flat.
+		if level == 0 && v > 0 {
+			level = 1
+		}
+		levels[offset+i] = level
+	}
+
+	lines := make([]string, height)
+	for row := range height {
+		var sb strings.Builder
+		for cell := range width {
+			r := rune(brailleBase)
+			for dx := range 2 {
+				level := levels[2*cell+dx]
+				for dy := range 4 {
+					fromBottom := (height-1-row)*4 + (3 - dy)
+					if fromBottom < level {
+						r |= brailleDots[dx][dy]
+					}
+				}
+			}
+			sb.WriteRune(r)
+		}
+		lines[row] = sb.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// scale maps v from [0, top] to [0, levels].
+func scale(v, top float64, levels int) int {
+	if top <= 0 || v <= 0 {
+		return 0
+	}
+	level := int(math.Round(v / top * float64(levels)))
+	return min(max(level, 0), levels)
+}
@@ -0,0 +1,115 @@
+package graph_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/ui/tools/graph"
+)
+
+func TestSeries(t *testing.T) {
+	t.Parallel()
+
+	s := graph.NewSeries(3)
+	if _, ok := s.Last(); ok {
+		t.Error("Last() of an empty series reports a sample")
+	}
+	for _, v := range []float64{1, 2, 3, 4} {
+		s.Push(v)
+	}
+	if got, want := s.Values(), []float64{2, 3, 4}; !slices.Equal(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+	if got, _ := s.Last(); got != 4 {
+		t.Errorf("Last() = %v, want 4", got)
+	}
+
+	s.Resize(2)
+	if got, want := s.Values(), []float64{3, 4}; !slices.Equal(got, want) {
+		t.Errorf("Values() after Resize(2) = %v, want %v", got, want)
+	}
+	s.Resize(4)
+	s.Push(5)
+	if got, want := s.Values(), []float64{3, 4, 5}; !slices.Equal(got, want) {
+		t.Errorf("Values() after Resize(4) = %v, want %v", got, want)
+	}
+
+	s.Reset()
+	if s.Len() != 0 || s.Cap() != 4 {
+		t.Errorf("after Reset() Len() = %d, Cap() = %d, want 0, 4", s.Len(), s.Cap())
+	}
+}
+
+func TestMax(t *testing.T) {
+	t.Parallel()
+
+	if got := graph.Max([]float64{1, math.NaN(), 3, 2}); got != 3 {
+		t.Errorf("Max() = %v, want 3", got)
+	}
+	if got := graph.Max(nil); got != 0 {
+		t.Errorf("Max(nil) = %v, want 0", got)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	t.Parallel()
+
+	nan := math.NaN()
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		top    float64
+		want   string
+	}{
+		{"levels", []float64{0, 1, 2, 3, 4, 5, 6, 7}, 8, 7, "▁▂▃▄▅▆▇█"},
+		{"right-aligned", []float64{7, 7}, 4, 7, "  ██"},
+		{"newest-kept", []float64{0, 7, 0}, 2, 7, "█▁"},
+		{"gaps", []float64{7, nan, 7}, 3, 7, "█ █"},
+		{"clamped", []float64{14}, 1, 7, "█"},
+		{"no-top", []float64{3}, 1, 0, "▁"},
+		{"no-width", []float64{3}, 0, 7, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := graph.Sparkline(tt.values, tt.width, tt.top); got != tt.want {
+				t.Errorf("Sparkline(%v, %d, %v) = %q, want %q", tt.values, tt.width, tt.top, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBraille(t *testing.T) {
+	t.Parallel()
+
+	nan := math.NaN()
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		height int
+		top    float64
+		want   string
+	}{
+		{"one-line", []float64{4, 2}, 1, 1, 4, "⣧"},
+		{"two-lines", []float64{8, 4, 1, 0}, 2, 2, 8, "⡇⠀\n⣿⡀"},
+		{"right-aligned", []float64{4}, 2, 1, 4, "⠀⢸"},
+		{"small-value-shown", []float64{0.1, 0}, 1, 1, 8, "⡀"},
+		{"gaps", []float64{nan, 4}, 1, 1, 4, "⢸"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := graph.Braille(tt.values, tt.width, tt.height, tt.top)
+			if got != tt.want {
+				t.Errorf("Braille(%v, %d, %d, %v) = %q, want %q", tt.values, tt.width, tt.height, tt.top, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,370 @@
+package qr
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+var (
+	ErrNotFound          = errors.New("no qr code found")
+	ErrUnreadableFormat  = errors.New("unreadable format information")
+	ErrTooManyErrors     = errors.New("too many errors to correct")
+	ErrUnsupportedMode   = errors.New("unsupported data mode")
+	ErrMalformedSegments = errors.New("malformed data segments")
+)
+
+// maxInfoDistance is the number of bit errors tolerated in the format and
+// version information, which both use codes with a minimum distance of 7 or
+// more.
+const maxInfoDistance = 3
+
+const (
+	modeTerminator       = 0b0000
+	modeNumeric          = 0b0001
+	modeAlphanumeric     = 0b0010
+	modeStructuredAppend = 0b0011
+	modeByte             = 0b0100
+	modeFNC1First        = 0b0101
+	modeECI              = 0b0111
+	modeFNC1Second       = 0b1001
+)
+
+const alphanumericCharset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// decodeModules decodes a sampled symbol, where modules[y][x] reports whether
+// the module at column x and row y is dark.
+func decodeModules(modules [][]bool) ([]byte, error) {
+	size := len(modules)
+	version := (size - 17) / 4
+	if version < MinVersion || version > MaxVersion || version*4+17 != size {
+		return nil, fmt.Errorf("%w: invalid size %d", ErrNotFound, size)
+	}
+
+	level, mask, err := readFormat(modules)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Code{
+		Size:       size,
+		Version:    version,
+		Level:      level,
+		Mask:       mask,
+		modules:    newGrid(size),
+		isFunction: newGrid(size),
+	}
+	c.drawFunctionPatterns()
+	for y, row := range modules {
+		copy(c.modules[y], row)
+	}
+	c.applyMask(mask)
+
+	data, err := correctAndDeinterleave(c.readCodewords(), version, level)
+	if err != nil {
+		return nil, err
+	}
+	return parseSegments(data, version)
+}
+
+// readFormat returns the error correction level and mask from whichever copy
+// of the format information is closest to a valid code word.
+func readFormat(modules [][]bool) (ECLevel, int, error) {
+	size := len(modules)
+	at := func(x, y int) int {
+		if modules[y][x] {
+			return 1
+		}
+		return 0
+	}
+
+	var first, second int
+	for i := range 6 {
+		first |= at(8, i) << i
+	}
+	first |= at(8, 7) << 6
+	first |= at(8, 8) << 7
+	first |= at(7, 8) << 8
+	for i := 9; i < 15; i++ {
+		first |= at(14-i, 8) << i
+	}
+	for i := range 8 {
+		second |= at(size-1-i, 8) << i
+	}
+	for i := 8; i < 15; i++ {
+		second |= at(8, size-15+i) << i
+	}
+
+	bestLevel, bestMask, bestDistance := ECLow, 0, maxInfoDistance+1
+	for level := ECLow; level <= ECHigh; level++ {
+		for mask := range 8 {
+			want := formatInformation(level, mask)
+			for _, got := range []int{first, second} {
+				if d := bits.OnesCount(uint(got ^ want)); d < bestDistance {
+					bestLevel, bestMask, bestDistance = level, mask, d
+				}
+			}
+		}
+	}
+	if bestDistance > maxInfoDistance {
+		return 0, 0, ErrUnreadableFormat
+	}
+	return bestLevel, bestMask, nil
+}
+
+// readVersion returns the version stored in the version information blocks
+// of a symbol of version 7 or higher, or 0 when neither copy is readable.
+func readVersion(modules [][]bool) int {
+	size := len(modules)
+	var first, second int
+	for i := range 18 {
+		a := size - 11 + i%3
+		b := i / 3
+		if modules[b][a] {
+			first |= 1 << i
+		}
+		if modules[a][b] {
+			second |= 1 << i
+		}
+	}
+
+	best, bestDistance := 0, maxInfoDistance+1
+	for v := 7; v <= MaxVersion; v++ {
+		want := versionInformation(v)
+		for _, got := range []int{first, second} {
+			if d := bits.OnesCount(uint(got ^ want)); d < bestDistance {
+				best, bestDistance = v, d
+			}
+		}
+	}
+	return best
+}
+
+// readCodewords collects the unmasked codewords in the same zigzag order
+// drawCodewords places them.
+func (c *Code) readCodewords() []byte {
+	res := make([]byte, numRawDataModules(c.Version)/8)
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := range c.Size {
+			for j := range 2 {
+				x := right - j
+				y := vert
+				if upward {
+					y = c.Size - 1 - vert
+				}
+				if c.isFunction[y][x] || i >= len(res)*8 {
+					continue
+				}
+				if c.modules[y][x] {
+					res[i>>3] |= 1 << (7 - i&7)
+				}
+				i++
+			}
+		}
+	}
+	return res
+}
+
+// correctAndDeinterleave reverses addECCAndInterleave, repairing each block
+// with its error correction codewords.
+func correctAndDeinterleave(raw []byte, version int, level ECLevel) ([]byte, error) {
+	numBlocks := numErrorCorrectionBlocks[level][version]
+	blockECCLen := eccCodewordsPerBlock[level][version]
+	numShortBlocks := numBlocks - len(raw)%numBlocks
+	shortBlockLen := len(raw) / numBlocks
+
+	blocks := make([][]byte, numBlocks)
+	for i := range blocks {
+		blocks[i] = make([]byte, shortBlockLen+1)
+	}
+	k := 0
+	for i := range shortBlockLen + 1 {
+		for j, block := range blocks {
+			if i != shortBlockLen-blockECCLen || j >= numShortBlocks {
+				block[i] = raw[k]
+				k++
+			}
+		}
+	}
+
+	res := make([]byte, 0, numDataCodewords(version, level))
+	for j, block := range blocks {
+		if j < numShortBlocks {
+			placeholder := shortBlockLen - blockECCLen
+			block = append(block[:placeholder], block[placeholder+1:]...)
+		}
+		if err := reedSolomonCorrect(block, blockECCLen); err != nil {
+			return nil, err
+		}
+		res = append(res, block[:len(block)-blockECCLen]...)
+	}
+	return res, nil
+}
+
+// parseSegments concatenates the payload of the numeric, alphanumeric and
+// byte segments. ECI designators are skipped and the bytes are returned as
+// stored, which is UTF-8 for every common producer.
+func parseSegments(data []byte, version int) ([]byte, error) {
+	r := bitReader{data: data}
+	var res []byte
+	for r.remaining() >= 4 {
+		mode, _ := r.read(4)
+		switch mode {
+		case modeTerminator:
+			return res, nil
+		case modeNumeric:
+			digits, err := readNumeric(&r, version)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, digits...)
+		case modeAlphanumeric:
+			chars, err := readAlphanumeric(&r, version)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, chars...)
+		case modeByte:
+			count, ok := r.read(segmentCountBits(modeByte, version))
+			if !ok {
+				return nil, ErrMalformedSegments
+			}
+			for range count {
+				b, ok := r.read(8)
+				if !ok {
+					return nil, ErrMalformedSegments
+				}
+				res = append(res, byte(b))
+			}
+		case modeECI:
+			if err := skipECIDesignator(&r); err != nil {
+				return nil, err
+			}
+		case modeStructuredAppend:
+			if _, ok := r.read(16); !ok {
+				return nil, ErrMalformedSegments
+			}
+		case modeFNC1First:
+		case modeFNC1Second:
+			if _, ok := r.read(8); !ok {
+				return nil, ErrMalformedSegments
+			}
+		default:
+			return nil, fmt.Errorf("%w: %04b", ErrUnsupportedMode, mode)
+		}
+	}
+	return res, nil
+}
+
+func readNumeric(r *bitReader, version int) ([]byte, error) {
+	count, ok := r.read(segmentCountBits(modeNumeric, version))
+	if !ok {
+		return nil, ErrMalformedSegments
+	}
+	res := make([]byte, 0, count)
+	for count > 0 {
+		digits := min(count, 3)
+		value, ok := r.read(digits*3 + 1)
+		if !ok {
+			return nil, ErrMalformedSegments
+		}
+		s := fmt.Sprintf("%0*d", digits, value)
+		if len(s) != digits {
+			return nil, ErrMalformedSegments
+		}
+		res = append(res, s...)
+		count -= digits
+	}
+	return res, nil
+}
+
+func readAlphanumeric(r *bitReader, version int) ([]byte, error) {
+	count, ok := r.read(segmentCountBits(modeAlphanumeric, version))
+	if !ok {
+		return nil, ErrMalformedSegments
+	}
+	n := len(alphanumericCharset)
+	res := make([]byte, 0, count)
+	for ; count >= 2; count -= 2 {
+		value, ok := r.read(11)
+		if !ok || value >= n*n {
+			return nil, ErrMalformedSegments
+		}
+		res = append(res, alphanumericCharset[value/n], alphanumericCharset[value%n])
+	}
+	if count == 1 {
+		value, ok := r.read(6)
+		if !ok || value >= n {
+			return nil, ErrMalformedSegments
+		}
+		res = append(res, alphanumericCharset[value])
+	}
+	return res, nil
+}
+
+// skipECIDesignator skips the one to three byte assignment number that
+// follows an ECI mode indicator.
+func skipECIDesignator(r *bitReader) error {
+	first, ok := r.read(8)
+	if !ok {
+		return ErrMalformedSegments
+	}
+	extra := 0
+	switch {
+	case first&0x80 == 0:
+	case first&0xC0 == 0x80:
+		extra = 8
+	case first&0xE0 == 0xC0:
+		extra = 16
+	default:
+		return ErrMalformedSegments
+	}
+	if _, ok := r.read(extra); !ok {
+		return ErrMalformedSegments
+	}
+	return nil
+}
+
+func segmentCountBits(mode, version int) int {
+	i := 0
+	switch {
+	case version >= 27:
+		i = 2
+	case version >= 10:
+		i = 1
+	}
+	switch mode {
+	case modeNumeric:
+		return [3]int{10, 12, 14}[i]
+	case modeAlphanumeric:
+		return [3]int{9, 11, 13}[i]
+	default:
+		return [3]int{8, 16, 16}[i]
+	}
+}
+
+type bitReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *bitReader) remaining() int {
+	return len(r.data)*8 - r.pos
+}
+
+func (r *bitReader) read(n int) (int, bool) {
+	if n > r.remaining() {
+		return 0, false
+	}
+	res := 0
+	for range n {
+		res = res<<1 | int(r.data[r.pos>>3]>>(7-r.pos&7)&1)
+		r.pos++
+	}
+	return res, true
+}
@@ -0,0 +1,367 @@
+package qr
+
+import (
+	"image"
+	"math"
+	"slices"
+)
+
+const (
+	// maxFinderCandidates bounds the number of finder pattern candidates
+	// combined into triples.
+	maxFinderCandidates = 8
+	// maxSideRatioDelta and maxCornerCosine bound how far the three finder
+	// patterns may deviate from an isosceles right triangle.
+	maxSideRatioDelta = 0.25
+	maxCornerCosine   = 0.2
+)
+
+// Decode finds a QR code in img and returns its payload. The symbol may be
+// rotated and scaled, as in screenshots or images exported by share sheets,
+// but perspective distortion is not corrected. Both dark-on-light and
+// light-on-dark symbols are recognized.
+func Decode(img image.Image) ([]byte, error) {
+	b := binarize(img)
+	data, err := b.decode()
+	if err == nil {
+		return data, nil
+	}
+	b.invert()
+	if inverted, invErr := b.decode(); invErr == nil {
+		return inverted, nil
+	}
+	return nil, err
+}
+
+// bitmap is a binarized image where true stands for a dark pixel.
+type bitmap struct {
+	width  int
+	height int
+	dark   []bool
+}
+
+// binarize thresholds img halfway between its darkest and lightest pixel.
+// Transparent pixels are treated as if drawn over white.
+func binarize(img image.Image) *bitmap {
+	bounds := img.Bounds()
+	b := &bitmap{
+		width:  bounds.Dx(),
+		height: bounds.Dy(),
+		dark:   make([]bool, bounds.Dx()*bounds.Dy()),
+	}
+
+	luma := make([]uint32, len(b.dark))
+	lo, hi := uint32(math.MaxUint32), uint32(0)
+	for y := range b.height {
+		for x := range b.width {
+			r, g, bl, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			l := (299*r+587*g+114*bl)/1000 + 0xFFFF - a
+			luma[y*b.width+x] = l
+			lo, hi = min(lo, l), max(hi, l)
+		}
+	}
+
+	threshold := lo + (hi-lo)/2
+	for i, l := range luma {
+		b.dark[i] = l <= threshold && hi > lo
+	}
+	return b
+}
+
+func (b *bitmap) at(x, y int) bool {
+	if x < 0 || y < 0 || x >= b.width || y >= b.height {
+		return false
+	}
+	return b.dark[y*b.width+x]
+}
+
+func (b *bitmap) invert() {
+	for i := range b.dark {
+		b.dark[i] = !b.dark[i]
+	}
+}
+
+type point struct {
+	x, y float64
+}
+
+func (p point) sub(q point) point    { return point{p.x - q.x, p.y - q.y} }
+func (p point) dist(q point) float64 { return math.Hypot(p.x-q.x, p.y-q.y) }
+
+// finder is the center of a candidate finder pattern and the estimated
+// module size around it. hits counts the scan lines that confirmed it.
+type finder struct {
+	center point
+	module float64
+	hits   int
+}
+
+// decode tries every plausible triple of finder patterns and returns the
+// first payload that decodes.
+func (b *bitmap) decode() ([]byte, error) {
+	triples := arrangeFinders(b.findFinders())
+	if len(triples) == 0 {
+		return nil, ErrNotFound
+	}
+
+	var err error
+	for _, t := range triples {
+		var data []byte
+		data, err = b.decodeAt(t)
+		if err == nil {
+			return data, nil
+		}
+	}
+	return nil, err
+}
+
+// decodeAt samples the symbol spanned by the top-left, top-right and
+// bottom-left finder patterns and decodes it.
+func (b *bitmap) decodeAt(t [3]finder) ([]byte, error) {
+	topLeft, topRight, bottomLeft := t[0].center, t[1].center, t[2].center
+	module := (t[0].module + t[1].module + t[2].module) / 3
+
+	modules := (topLeft.dist(topRight) + topLeft.dist(bottomLeft)) / 2 / module
+	size := int(math.Round(modules)) + 7
+	switch size % 4 {
+	case 0:
+		size++
+	case 2:
+		size--
+	case 3:
+		size -= 2
+	}
+	if size < 21 {
+		return nil, ErrNotFound
+	}
+
+	grid := b.sample(topLeft, topRight, bottomLeft, size)
+	if size >= 45 {
+		if v := readVersion(grid); v != 0 && v*4+17 != size {
+			grid = b.sample(topLeft, topRight, bottomLeft, v*4+17)
+		}
+	}
+	return decodeModules(grid)
+}
+
+// sample reads the module centers of a symbol of the given size through the
+// affine transform defined by the three finder pattern centers.
+func (b *bitmap) sample(topLeft, topRight, bottomLeft point, size int) [][]bool {
+	span := float64(size - 7)
+	dx := topRight.sub(topLeft)
+	dy := bottomLeft.sub(topLeft)
+
+	grid := newGrid(size)
+	for row := range size {
+		for col := range size {
+			u, v := float64(col-3)/span, float64(row-3)/span
+			x := topLeft.x + u*dx.x + v*dy.x
+			y := topLeft.y + u*dx.y + v*dy.y
+			grid[row][col] = b.at(int(math.Floor(x)), int(math.Floor(y)))
+		}
+	}
+	return grid
+}
+
+// findFinders scans every row for the 1:1:3:1:1 finder pattern and confirms
+// each hit vertically and horizontally through its center.
+func (b *bitmap) findFinders() []finder {
+	var res []finder
+	for y := range b.height {
+		starts, lengths := b.rowRuns(y)
+		for i := 0; i+4 < len(lengths); i++ {
+			if !b.at(starts[i], y) || !finderRatio([5]int(lengths[i:i+5])) {
+				continue
+			}
+			cx := float64(starts[i+2]) + float64(lengths[i+2])/2
+
+			column := int(cx)
+			cy, vertical, ok := crossCheck(func(t int) bool { return b.at(column, t) }, y)
+			if !ok {
+				continue
+			}
+			row := int(cy)
+			cx, horizontal, ok := crossCheck(func(t int) bool { return b.at(t, row) }, column)
+			if !ok {
+				continue
+			}
+
+			res = addFinder(res, finder{
+				center: point{cx, cy},
+				module: float64(vertical+horizontal) / 14,
+				hits:   1,
+			})
+		}
+	}
+	return res
+}
+
+// rowRuns splits row y into runs of same-colored pixels.
+func (b *bitmap) rowRuns(y int) (starts, lengths []int) {
+	for x := range b.width {
+		if x == 0 || b.at(x, y) != b.at(x-1, y) {
+			starts = append(starts, x)
+			lengths = append(lengths, 0)
+		}
+		lengths[len(lengths)-1]++
+	}
+	return starts, lengths
+}
+
+// crossCheck measures the finder pattern along a line through the dark
+// position t0 and returns the center of its middle run and its total length.
+func crossCheck(dark func(int) bool, t0 int) (float64, int, bool) {
+	if !dark(t0) {
+		return 0, 0, false
+	}
+
+	var counts [5]int
+	t := t0
+	for ; t >= 0 && dark(t); t-- {
+		counts[2]++
+	}
+	centerStart := t + 1
+	for ; t >= 0 && !dark(t); t-- {
+		counts[1]++
+	}
+	for ; t >= 0 && dark(t); t-- {
+		counts[0]++
+	}
+
+	// Positions past the image report light, which bounds the walk.
+	t = t0 + 1
+	for ; dark(t); t++ {
+		counts[2]++
+	}
+	for end := t + counts[2]; !dark(t) && t < end; t++ {
+		counts[3]++
+	}
+	for ; dark(t); t++ {
+		counts[4]++
+	}
+
+	if !finderRatio(counts) {
+		return 0, 0, false
+	}
+	total := counts[0] + counts[1] + counts[2] + counts[3] + counts[4]
+	return float64(centerStart) + float64(counts[2])/2, total, true
+}
+
+// finderRatio reports whether the run lengths match 1:1:3:1:1, allowing each
+// run to be off by half of its expected length.
+func finderRatio(counts [5]int) bool {
+	total := 0
+	for _, c := range counts {
+		if c == 0 {
+			return false
+		}
+		total += c
+	}
+	if total < 7 {
+		return false
+	}
+	unit := float64(total) / 7
+	for i, c := range counts {
+		want := unit
+		if i == 2 {
+			want = 3 * unit
+		}
+		if math.Abs(float64(c)-want) > want/2 {
+			return false
+		}
+	}
+	return true
+}
+
+// addFinder merges f into a nearby candidate of similar module size, or
+// appends it as a new candidate.
+func addFinder(finders []finder, f finder) []finder {
+	for i, c := range finders {
+		ratio := f.module / c.module
+		if c.center.dist(f.center) > 2*c.module || ratio < 0.5 || ratio > 2 {
+			continue
+		}
+		n := float64(c.hits)
+		finders[i] = finder{
+			center: point{(c.center.x*n + f.center.x) / (n + 1), (c.center.y*n + f.center.y) / (n + 1)},
+			module: (c.module*n + f.module) / (n + 1),
+			hits:   c.hits + 1,
+		}
+		return finders
+	}
+	return append(finders, f)
+}
+
+// arrangeFinders returns the triples of candidates that form an isosceles
+// right triangle, ordered as top-left, top-right and bottom-left in symbol
+// orientation and sorted from the best to the worst fit.
+func arrangeFinders(finders []finder) [][3]finder {
+	slices.SortStableFunc(finders, func(a, b finder) int { return b.hits - a.hits })
+	if len(finders) > maxFinderCandidates {
+		finders = finders[:maxFinderCandidates]
+	}
+
+	type scored struct {
+		triple [3]finder
+		score  float64
+	}
+	var candidates []scored
+	for i := range finders {
+		for j := i + 1; j < len(finders); j++ {
+			for k := j + 1; k < len(finders); k++ {
+				group := [3]finder{finders[i], finders[j], finders[k]}
+				if t, score, ok := orientTriple(group); ok {
+					candidates = append(candidates, scored{t, score})
+				}
+			}
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b scored) int {
+		switch {
+		case a.score < b.score:
+			return -1
+		case a.score > b.score:
+			return 1
+		default:
+			return 0
+		}
+	})
+	res := make([][3]finder, len(candidates))
+	for i, c := range candidates {
+		res[i] = c.triple
+	}
+	return res
+}
+
+// orientTriple picks the corner finder of the right angle and orders the
+// other two by the direction of rotation. The score grows with the
+// deviation from the ideal shape.
+func orientTriple(group [3]finder) ([3]finder, float64, bool) {
+	for corner := range group {
+		a := group[corner]
+		b, c := group[(corner+1)%3], group[(corner+2)%3]
+
+		ab, ac := b.center.sub(a.center), c.center.sub(a.center)
+		lab, lac := math.Hypot(ab.x, ab.y), math.Hypot(ac.x, ac.y)
+		if lab == 0 || lac == 0 {
+			continue
+		}
+		ratioDelta := math.Abs(lab/lac - 1)
+		cosine := math.Abs(ab.x*ac.x+ab.y*ac.y) / (lab * lac)
+		if ratioDelta > maxSideRatioDelta || cosine > maxCornerCosine {
+			continue
+		}
+
+		modules := [3]float64{a.module, b.module, c.module}
+		spread := (slices.Max(modules[:]) - slices.Min(modules[:])) / slices.Min(modules[:])
+
+		// With y pointing down, the top-right finder turns clockwise into
+		// the bottom-left one.
+		if ab.x*ac.y-ab.y*ac.x < 0 {
+			b, c = c, b
+		}
+		return [3]finder{a, b, c}, ratioDelta + cosine + spread, true
+	}
+	return [3]finder{}, 0, false
+}
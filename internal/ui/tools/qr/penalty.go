@@ -0,0 +1,98 @@
+package qr
+
+const (
+	penaltyN1 = 3
+	penaltyN2 = 3
+	penaltyN3 = 40
+	penaltyN4 = 10
+)
+
+// finderLike is the 1:1:3:1:1 dark/light pattern preceded by four light
+// modules; its reverse is checked as well.
+var finderLike = []bool{false, false, false, false, true, false, true, true, true, false, true}
+
+// penaltyScore evaluates the current module layout using the four penalty
+// rules from the specification. Lower is better.
+func (c *Code) penaltyScore() int {
+	res := 0
+
+	for y := range c.Size {
+		res += c.linePenalty(func(i int) bool { return c.modules[y][i] })
+	}
+	for x := range c.Size {
+		res += c.linePenalty(func(i int) bool { return c.modules[i][x] })
+	}
+
+	for y := range c.Size - 1 {
+		for x := range c.Size - 1 {
+			color := c.modules[y][x]
+			if color == c.modules[y][x+1] && color == c.modules[y+1][x] && color == c.modules[y+1][x+1] {
+				res += penaltyN2
+			}
+		}
+	}
+
+	dark := 0
+	for _, row := range c.modules {
+		for _, m := range row {
+			if m {
+				dark++
+			}
+		}
+	}
+	total := c.Size * c.Size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	res += k * penaltyN4
+
+	return res
+}
+
+// linePenalty scores a single row or column for runs of same-colored modules
+// (rule 1) and finder-like patterns (rule 3). Modules outside of the symbol
+// are considered light.
+func (c *Code) linePenalty(at func(int) bool) int {
+	res := 0
+
+	runColor, runLen := false, 0
+	for i := range c.Size {
+		if m := at(i); m == runColor && i > 0 {
+			runLen++
+		} else {
+			if runLen >= 5 {
+				res += penaltyN1 + runLen - 5
+			}
+			runColor, runLen = m, 1
+		}
+	}
+	if runLen >= 5 {
+		res += penaltyN1 + runLen - 5
+	}
+
+	get := func(i int) bool {
+		if i < 0 || i >= c.Size {
+			return false
+		}
+		return at(i)
+	}
+	n := len(finderLike)
+	for start := -4; start+n <= c.Size+4; start++ {
+		forward, backward := true, true
+		for j, want := range finderLike {
+			m := get(start + j)
+			if m != want {
+				forward = false
+			}
+			if m != finderLike[n-1-j] {
+				backward = false
+			}
+		}
+		if forward {
+			res += penaltyN3
+		}
+		if backward {
+			res += penaltyN3
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,207 @@
+package qr
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestReedSolomonRemainder(t *testing.T) {
+	t.Parallel()
+
+	// "HELLO WORLD" encoded as 1-M, see ISO/IEC 18004 annex I.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	got := reedSolomonRemainder(data, reedSolomonDivisor(len(want)))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("reedSolomonRemainder() = %v, want %v", got, want)
+	}
+}
+
+func TestFormatInformation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		level ECLevel
+		mask  int
+		want  int
+	}{
+		{ECLow, 0, 0b111011111000100},
+		{ECMedium, 0, 0b101010000010010},
+		{ECQuartile, 0, 0b011010101011111},
+		{ECHigh, 0, 0b001011010001001},
+		{ECLow, 7, 0b110100101110110},
+		{ECMedium, 5, 0b100000011001110},
+	}
+	for _, tt := range tests {
+		if got := formatInformation(tt.level, tt.mask); got != tt.want {
+			t.Errorf("formatInformation(%s, %d) = %015b, want %015b", tt.level, tt.mask, got, tt.want)
+		}
+	}
+}
+
+func TestVersionInformation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version int
+		want    int
+	}{
+		{7, 0b000111110010010100},
+		{8, 0b001000010110111100},
+		{40, 0b101000110001101001},
+	}
+	for _, tt := range tests {
+		if got := versionInformation(tt.version); got != tt.want {
+			t.Errorf("versionInformation(%d) = %018b, want %018b", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestAlignmentPatternPositions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version int
+		want    []int
+	}{
+		{1, nil},
+		{2, []int{6, 18}},
+		{7, []int{6, 22, 38}},
+		{32, []int{6, 34, 60, 86, 112, 138}},
+		{40, []int{6, 30, 58, 86, 114, 142, 170}},
+	}
+	for _, tt := range tests {
+		if got := alignmentPatternPositions(tt.version); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("alignmentPatternPositions(%d) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestNumDataCodewords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version int
+		level   ECLevel
+		want    int
+	}{
+		{1, ECLow, 19},
+		{1, ECMedium, 16},
+		{1, ECHigh, 9},
+		{5, ECQuartile, 62},
+		{10, ECMedium, 216},
+		{40, ECLow, 2956},
+		{40, ECHigh, 1276},
+	}
+	for _, tt := range tests {
+		if got := numDataCodewords(tt.version, tt.level); got != tt.want {
+			t.Errorf("numDataCodewords(%d, %s) = %d, want %d", tt.version, tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestReedSolomonCorrect(t *testing.T) {
+	t.Parallel()
+
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	ecc := reedSolomonRemainder(data, reedSolomonDivisor(10))
+	clean := append(append([]byte{}, data...), ecc...)
+
+	tests := []struct {
+		name    string
+		errors  map[int]byte
+		wantErr error
+	}{
+		{"intact", nil, nil},
+		{"single data error", map[int]byte{3: 0xFF}, nil},
+		{"error in ecc", map[int]byte{20: 0x01}, nil},
+		{"max errors", map[int]byte{0: 1, 5: 2, 9: 3, 14: 4, 25: 5}, nil},
+		{"too many errors", map[int]byte{0: 1, 4: 2, 8: 3, 12: 4, 16: 5, 20: 6}, ErrTooManyErrors},
+	}
+	for _, tt := range tests {
+		block := append([]byte{}, clean...)
+		for i, e := range tt.errors {
+			block[i] ^= e
+		}
+		err := reedSolomonCorrect(block, len(ecc))
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: reedSolomonCorrect() error = %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr == nil && !reflect.DeepEqual(block, clean) {
+			t.Errorf("%s: reedSolomonCorrect() = %v, want %v", tt.name, block, clean)
+		}
+	}
+}
+
+func TestParseSegments(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		bits func(bb *bitBuffer)
+		want string
+	}{
+		{
+			"numeric",
+			func(bb *bitBuffer) {
+				bb.append(modeNumeric, 4)
+				bb.append(8, 10)
+				bb.append(12, 10)
+				bb.append(345, 10)
+				bb.append(67, 7)
+			},
+			"01234567",
+		},
+		{
+			"alphanumeric",
+			func(bb *bitBuffer) {
+				bb.append(modeAlphanumeric, 4)
+				bb.append(3, 9)
+				bb.append(10*45+11, 11) // "AB"
+				bb.append(44, 6)        // ":"
+			},
+			"AB:",
+		},
+		{
+			"eci then byte",
+			func(bb *bitBuffer) {
+				bb.append(modeECI, 4)
+				bb.append(26, 8) // UTF-8
+				bb.append(modeByte, 4)
+				bb.append(2, 8)
+				bb.append('h', 8)
+				bb.append('i', 8)
+			},
+			"hi",
+		},
+		{
+			"mixed segments",
+			func(bb *bitBuffer) {
+				bb.append(modeAlphanumeric, 4)
+				bb.append(1, 9)
+				bb.append(33, 6) // "X"
+				bb.append(modeNumeric, 4)
+				bb.append(2, 10)
+				bb.append(42, 7)
+			},
+			"X42",
+		},
+	}
+	for _, tt := range tests {
+		var bb bitBuffer
+		tt.bits(&bb)
+		bb.append(modeTerminator, 4)
+
+		got, err := parseSegments(bb.bytes(), 1)
+		if err != nil {
+			t.Errorf("%s: parseSegments() error: %v", tt.name, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s: parseSegments() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package qr_test
+
+import (
+	"errors"
+	"flag"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/ui/tools/qr"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden %s: %v", path, err)
+	}
+	if got != string(want) {
+		t.Errorf("%s mismatch:\n got:\n%s\nwant:\n%s", name, got, want)
+	}
+}
+
+func TestRenderGolden(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		data  string
+		level qr.ECLevel
+	}{
+		{"hello-low", "hello", qr.ECLow},
+		{"wifi-wpa-medium", "WIFI:T:WPA;S:home;P:correct horse;;", qr.ECMedium},
+		{"wifi-escaped-quartile", `WIFI:T:WPA;S:a\;b\,c;P:p\:w\\d;H:true;;`, qr.ECQuartile},
+		{"url-version7-high", strings.Repeat("nm-tui ", 12), qr.ECHigh},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			code, err := qr.Encode([]byte(tt.data), tt.level)
+			if err != nil {
+				t.Fatalf("Encode() error: %v", err)
+			}
+			assertGolden(t, tt.name, qr.Render(code, qr.DefaultQuietZone, qr.HalfBlocks()))
+		})
+	}
+}
+
+func TestEncodeVersionSelection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		size        int
+		level       qr.ECLevel
+		wantVersion int
+	}{
+		{17, qr.ECLow, 1},
+		{18, qr.ECLow, 2},
+		{14, qr.ECMedium, 1},
+		{15, qr.ECMedium, 2},
+		{2953, qr.ECLow, 40},
+	}
+	for _, tt := range tests {
+		code, err := qr.Encode([]byte(strings.Repeat("x", tt.size)), tt.level)
+		if err != nil {
+			t.Fatalf("Encode(%d bytes, %s) error: %v", tt.size, tt.level, err)
+		}
+		if code.Version != tt.wantVersion {
+			t.Errorf("Encode(%d bytes, %s) version = %d, want %d", tt.size, tt.level, code.Version, tt.wantVersion)
+		}
+		if want := tt.wantVersion*4 + 17; code.Size != want {
+			t.Errorf("Encode(%d bytes, %s) size = %d, want %d", tt.size, tt.level, code.Size, want)
+		}
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := qr.Encode(make([]byte, 2954), qr.ECLow); !errors.Is(err, qr.ErrDataTooLong) {
+		t.Errorf("Encode(2954 bytes) error = %v, want %v", err, qr.ErrDataTooLong)
+	}
+	if _, err := qr.Encode([]byte("x"), qr.ECLevel(9)); !errors.Is(err, qr.ErrInvalidECLevel) {
+		t.Errorf("Encode(level 9) error = %v, want %v", err, qr.ErrInvalidECLevel)
+	}
+}
+
+func TestRenderQuietZone(t *testing.T) {
+	t.Parallel()
+
+	code, err := qr.Encode([]byte("x"), qr.ECLow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks := qr.HalfBlocks()
+	lines := strings.Split(qr.Render(code, 2, blocks), "\n")
+	if got, want := len(lines), (code.Size+4+1)/2; got != want {
+		t.Fatalf("line count = %d, want %d", got, want)
+	}
+	if want := strings.Repeat(blocks.Full, code.Size+4); lines[0] != want {
+		t.Errorf("first line = %q, want light quiet zone %q", lines[0], want)
+	}
+}
+
+// codeImage draws c with the given module size in pixels and quiet zone in
+// modules, rotated clockwise by quarter turns.
+func codeImage(c *qr.Code, scale, quietZone, quarterTurns int, dark, light color.Color) image.Image {
+	side := (c.Size + 2*quietZone) * scale
+	img := image.NewRGBA(image.Rect(0, 0, side, side))
+	for py := range side {
+		for px := range side {
+			x, y := px/scale-quietZone, py/scale-quietZone
+			for range quarterTurns {
+				x, y = y, c.Size-1-x
+			}
+			col := light
+			if c.Dark(x, y) {
+				col = dark
+			}
+			img.Set(px, py, col)
+		}
+	}
+	return img
+}
+
+func TestDecodeRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		data         string
+		level        qr.ECLevel
+		scale        int
+		quietZone    int
+		quarterTurns int
+		inverted     bool
+	}{
+		{"version 1 single pixel modules", "hello", qr.ECLow, 1, 4, 0, false},
+		{"wifi uri", "WIFI:T:WPA;S:home;P:correct horse;;", qr.ECMedium, 4, 4, 0, false},
+		{"no quiet zone", "WIFI:T:nopass;S:cafe;;", qr.ECQuartile, 3, 0, 0, false},
+		{"rotated", "WIFI:T:WPA;S:lab;P:12345678;H:true;;", qr.ECHigh, 5, 2, 1, false},
+		{"upside down", "upside down", qr.ECMedium, 3, 4, 2, false},
+		{"light on dark", "WIFI:T:WPA;S:night;P:owl;;", qr.ECMedium, 4, 2, 0, true},
+		{"version 7", strings.Repeat("nm-tui ", 12), qr.ECHigh, 3, 4, 3, false},
+		{"multiple blocks", strings.Repeat("0123456789abcdef", 20), qr.ECQuartile, 2, 4, 0, false},
+		{"unicode", "WIFI:T:WPA;S:кафе ☕;P:пароль;;", qr.ECLow, 3, 4, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			code, err := qr.Encode([]byte(tt.data), tt.level)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dark, light := color.Color(color.Black), color.Color(color.White)
+			if tt.inverted {
+				dark, light = light, dark
+			}
+			img := codeImage(code, tt.scale, tt.quietZone, tt.quarterTurns, dark, light)
+
+			got, err := qr.Decode(img)
+			if err != nil {
+				t.Fatalf("Decode() error: %v", err)
+			}
+			if string(got) != tt.data {
+				t.Errorf("Decode() = %q, want %q", got, tt.data)
+			}
+		})
+	}
+}
+
+func TestDecodeDamaged(t *testing.T) {
+	t.Parallel()
+
+	data := "WIFI:T:WPA;S:home;P:correct horse;;"
+	code, err := qr.Encode([]byte(data), qr.ECHigh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := codeImage(code, 4, 4, 0, color.Black, color.White).(*image.RGBA)
+
+	// A stain over the lower right data area, away from the finder patterns.
+	stain := image.Rect((code.Size-6)*4, (code.Size-2)*4, (code.Size+2)*4, (code.Size+4)*4)
+	for py := stain.Min.Y; py < stain.Max.Y; py++ {
+		for px := stain.Min.X; px < stain.Max.X; px++ {
+			img.Set(px, py, color.Black)
+		}
+	}
+
+	got, err := qr.Decode(img)
+	if err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+	if string(got) != data {
+		t.Errorf("Decode() = %q, want %q", got, data)
+	}
+}
+
+func TestDecodeNotFound(t *testing.T) {
+	t.Parallel()
+
+	img := image.NewGray(image.Rect(0, 0, 64, 64))
+	for i := range img.Pix {
+		img.Pix[i] = byte(i * 7)
+	}
+	if _, err := qr.Decode(img); !errors.Is(err, qr.ErrNotFound) {
+		t.Errorf("Decode(noise) error = %v, want %v", err, qr.ErrNotFound)
+	}
+}
@@ -0,0 +1,192 @@
+package qr
+
+// reedSolomonDivisor returns the generator polynomial of the given degree,
+// without its leading term, with coefficients from highest to lowest power.
+func reedSolomonDivisor(degree int) []byte {
+	res := make([]byte, degree)
+	res[degree-1] = 1
+
+	root := byte(1)
+	for range degree {
+		for j := range res {
+			res[j] = gfMultiply(res[j], root)
+			if j+1 < len(res) {
+				res[j] ^= res[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return res
+}
+
+// reedSolomonRemainder returns the error correction codewords for data.
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	res := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ res[0]
+		copy(res, res[1:])
+		res[len(res)-1] = 0
+		for i, coef := range divisor {
+			res[i] ^= gfMultiply(coef, factor)
+		}
+	}
+	return res
+}
+
+// gfMultiply multiplies two elements of GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1.
+func gfMultiply(x, y byte) byte {
+	var z int
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x11D
+		z ^= (int(y) >> i & 1) * int(x)
+	}
+	return byte(z)
+}
+
+// gfExp and gfLog map between elements of GF(2^8) and powers of the
+// generator 0x02. gfExp is doubled so sums of logarithms need no reduction.
+var gfExp, gfLog = func() ([510]byte, [256]int) {
+	var exp [510]byte
+	var log [256]int
+	x := byte(1)
+	for i := range 255 {
+		exp[i], exp[i+255] = x, x
+		log[x] = i
+		x = gfMultiply(x, 0x02)
+	}
+	return exp, log
+}()
+
+func gfPow2(e int) byte {
+	return gfExp[(e%255+255)%255]
+}
+
+func gfDivide(x, y byte) byte {
+	if x == 0 {
+		return 0
+	}
+	return gfExp[gfLog[x]+255-gfLog[y]]
+}
+
+// gfEval evaluates a polynomial with coefficients from lowest to highest
+// power at x.
+func gfEval(poly []byte, x byte) byte {
+	var res byte
+	for i := len(poly) - 1; i >= 0; i-- {
+		res = gfMultiply(res, x) ^ poly[i]
+	}
+	return res
+}
+
+// reedSolomonSyndromes evaluates the received block at the roots of the
+// generator polynomial. All of them are zero for an intact block.
+func reedSolomonSyndromes(block []byte, eccLen int) ([]byte, bool) {
+	syndromes := make([]byte, eccLen)
+	clean := true
+	for i := range syndromes {
+		root := gfPow2(i)
+		var s byte
+		for _, b := range block {
+			s = gfMultiply(s, root) ^ b
+		}
+		syndromes[i] = s
+		if s != 0 {
+			clean = false
+		}
+	}
+	return syndromes, clean
+}
+
+// reedSolomonCorrect fixes up to eccLen/2 erroneous codewords of block, which
+// holds data followed by eccLen error correction codewords, in place.
+func reedSolomonCorrect(block []byte, eccLen int) error {
+	syndromes, clean := reedSolomonSyndromes(block, eccLen)
+	if clean {
+		return nil
+	}
+
+	locator := berlekampMassey(syndromes)
+	numErrors := len(locator) - 1
+	if numErrors*2 > eccLen {
+		return ErrTooManyErrors
+	}
+
+	// Forney's algorithm with the evaluator Ω(x) = S(x)Λ(x) mod x^eccLen.
+	evaluator := make([]byte, eccLen)
+	for i, s := range syndromes {
+		for j, l := range locator {
+			if i+j < eccLen {
+				evaluator[i+j] ^= gfMultiply(s, l)
+			}
+		}
+	}
+	derivative := make([]byte, len(locator))
+	for i := 1; i < len(locator); i += 2 {
+		derivative[i-1] = locator[i]
+	}
+
+	found := 0
+	for k := range block {
+		degree := len(block) - 1 - k
+		xInv := gfPow2(-degree)
+		if gfEval(locator, xInv) != 0 {
+			continue
+		}
+		denominator := gfEval(derivative, xInv)
+		if denominator == 0 {
+			return ErrTooManyErrors
+		}
+		magnitude := gfMultiply(gfPow2(degree), gfDivide(gfEval(evaluator, xInv), denominator))
+		block[k] ^= magnitude
+		found++
+	}
+	if found != numErrors {
+		return ErrTooManyErrors
+	}
+	if _, clean := reedSolomonSyndromes(block, eccLen); !clean {
+		return ErrTooManyErrors
+	}
+	return nil
+}
+
+// berlekampMassey returns the error locator polynomial for the syndromes,
+// with coefficients from lowest to highest power.
+func berlekampMassey(syndromes []byte) []byte {
+	locator := []byte{1}
+	prev := []byte{1}
+	length, shift := 0, 1
+	prevDiscrepancy := byte(1)
+
+	for n := range syndromes {
+		discrepancy := syndromes[n]
+		for i := 1; i <= length && i < len(locator); i++ {
+			discrepancy ^= gfMultiply(locator[i], syndromes[n-i])
+		}
+		if discrepancy == 0 {
+			shift++
+			continue
+		}
+
+		scale := gfDivide(discrepancy, prevDiscrepancy)
+		next := make([]byte, max(len(locator), len(prev)+shift))
+		copy(next, locator)
+		for i, p := range prev {
+			next[i+shift] ^= gfMultiply(scale, p)
+		}
+
+		if 2*length <= n {
+			prev = locator
+			length = n + 1 - length
+			prevDiscrepancy = discrepancy
+			shift = 1
+		} else {
+			shift++
+		}
+		locator = next
+	}
+
+	for len(locator) > 1 && locator[len(locator)-1] == 0 {
+		locator = locator[:len(locator)-1]
+	}
+	return locator
+}
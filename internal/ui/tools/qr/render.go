@@ -0,0 +1,54 @@
+package qr
+
+import "strings"
+
+// DefaultQuietZone is the margin, in modules, that scanners need around the
+// symbol. The QR specification requires 4 modules.
+const DefaultQuietZone = 4
+
+// Blocks are the glyphs used to draw two vertically stacked modules in one
+// terminal cell. Each glyph paints the light modules with the foreground.
+type Blocks struct {
+	Full  string
+	Upper string
+	Lower string
+	Empty string
+}
+
+func HalfBlocks() Blocks {
+	return Blocks{
+		Full:  "█",
+		Upper: "▀",
+		Lower: "▄",
+		Empty: " ",
+	}
+}
+
+// Render draws the code surrounded by a quiet zone of the given width. Each
+// text line covers two module rows, so the output must be printed with a
+// light foreground on a dark background to keep the symbol scannable
+// regardless of the terminal color scheme.
+func Render(c *Code, quietZone int, blocks Blocks) string {
+	var sb strings.Builder
+	from, to := -quietZone, c.Size+quietZone
+	for y := from; y < to; y += 2 {
+		if y > from {
+			sb.WriteByte('\n')
+		}
+		for x := from; x < to; x++ {
+			upperLight := !c.Dark(x, y)
+			lowerLight := !c.Dark(x, y+1) && y+1 < to
+			switch {
+			case upperLight && lowerLight:
+				sb.WriteString(blocks.Full)
+			case upperLight:
+				sb.WriteString(blocks.Upper)
+			case lowerLight:
+				sb.WriteString(blocks.Lower)
+			default:
+				sb.WriteString(blocks.Empty)
+			}
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,132 @@
+// Package zone tracks where marked parts of a view end up on screen, so mouse
+// events can be hit-tested after layout and composition have moved them.
+//
+// Marks are zero-width escape sequences placed around rendered strings. Once
+// the final view is assembled, [Manager.Scan] records their positions and
+// strips them before the view reaches the terminal.
+package zone
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Rect is a rectangle of terminal cells.
+type Rect struct {
+	X, Y          int
+	Width, Height int
+}
+
+// Contains reports whether the cell at (x, y) lies inside r.
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// markerRe matches the private CSI sequences produced by [Manager.Mark].
+// Terminals never see them, but ansi-aware width calculations treat them as
+// zero-width, so layout is unaffected.
+var markerRe = regexp.MustCompile(`\x1b\[(\d+)z`)
+
+type point struct{ x, y int }
+
+type Manager struct {
+	mu sync.Mutex
+
+	codes map[string]int
+	ids   []string
+	zones map[string]Rect
+}
+
+func New() *Manager {
+	return &Manager{
+		codes: make(map[string]int),
+		zones: make(map[string]Rect),
+	}
+}
+
+// Mark wraps s so that its position is recorded by the next [Manager.Scan].
+// A multi-line s is expected to be rectangular, as lipgloss renders blocks.
+func (m *Manager) Mark(id, s string) string {
+	m.mu.Lock()
+	code, ok := m.codes[id]
+	if !ok {
+		code = len(m.ids)
+		m.codes[id] = code
+		m.ids = append(m.ids, id)
+	}
+	m.mu.Unlock()
+
+	return marker(2*code) + s + marker(2*code+1)
+}
+
+// Scan records the positions of all marks in view and returns view without
+// them. Zones missing from view, or partly covered by another layer, are
+// forgotten.
+func (m *Manager) Scan(view string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	clear(m.zones)
+	starts := make(map[int]point)
+
+	lines := strings.Split(view, "\n")
+	for y, line := range lines {
+		locs := markerRe.FindAllStringSubmatchIndex(line, -1)
+		if locs == nil {
+			continue
+		}
+
+		var sb strings.Builder
+		prev := 0
+		for _, loc := range locs {
+			sb.WriteString(line[prev:loc[0]])
+			prev = loc[1]
+
+			code, err := strconv.Atoi(line[loc[2]:loc[3]])
+			if err != nil || code/2 >= len(m.ids) {
+				continue
+			}
+			p := point{x: ansi.StringWidth(sb.String()), y: y}
+			if code%2 == 0 {
+				starts[code/2] = p
+				continue
+			}
+			start, ok := starts[code/2]
+			if !ok || p.y < start.y || p.x < start.x {
+				continue
+			}
+			m.zones[m.ids[code/2]] = Rect{
+				X:      start.x,
+				Y:      start.y,
+				Width:  p.x - start.x,
+				Height: p.y - start.y + 1,
+			}
+		}
+		sb.WriteString(line[prev:])
+		lines[y] = sb.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Get returns the rectangle of the zone id as of the last scan.
+func (m *Manager) Get(id string) (Rect, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r, ok := m.zones[id]
+	return r, ok
+}
+
+// Contains reports whether the zone id contained (x, y) at the last scan.
+func (m *Manager) Contains(id string, x, y int) bool {
+	r, ok := m.Get(id)
+	return ok && r.Contains(x, y)
+}
+
+func marker(code int) string {
+	return "\x1b[" + strconv.Itoa(code) + "z"
+}
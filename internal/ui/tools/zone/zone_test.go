@@ -0,0 +1,53 @@
+package zone_test
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/alphameo/nm-tui/internal/ui/tools/compositor"
+	"github.com/alphameo/nm-tui/internal/ui/tools/zone"
+)
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+
+	zones := zone.New()
+	box := lipgloss.NewStyle().Border(lipgloss.NormalBorder())
+	left := zones.Mark("left", box.Render("ab"))
+	right := zones.Mark("right", box.Render("cdef"))
+	bg := lipgloss.JoinHorizontal(lipgloss.Top, left, " ", right)
+	bg = lipgloss.JoinVertical(lipgloss.Left, "title", bg)
+	fg := zones.Mark("popup", "XY")
+	view := compositor.Compose(fg, bg, compositor.Begin, compositor.Begin, 1, 2)
+
+	got := zones.Scan(view)
+	if want := lipgloss.JoinVertical(lipgloss.Left, "title", "┌──┐ ┌────┐", "│XY│ │cdef│", "└──┘ └────┘"); got != want {
+		t.Errorf("Scan() left view\n%q, want\n%q", got, want)
+	}
+
+	tests := []struct {
+		id     string
+		want   zone.Rect
+		wantOk bool
+	}{
+		{"left", zone.Rect{X: 0, Y: 1, Width: 4, Height: 3}, true},
+		{"right", zone.Rect{X: 5, Y: 1, Width: 6, Height: 3}, true},
+		{"popup", zone.Rect{X: 1, Y: 2, Width: 2, Height: 1}, true},
+		{"unknown", zone.Rect{}, false},
+	}
+	for _, tt := range tests {
+		r, ok := zones.Get(tt.id)
+		if ok != tt.wantOk || r != tt.want {
+			t.Errorf("Get(%q) = %+v, %v, want %+v, %v", tt.id, r, ok, tt.want, tt.wantOk)
+		}
+	}
+
+	if !zones.Contains("right", 10, 3) || zones.Contains("right", 11, 3) {
+		t.Error("Contains() disagrees with the right zone bounds")
+	}
+
+	zones.Scan("plain")
+	if _, ok := zones.Get("left"); ok {
+		t.Error("zone survived a scan of a view without it")
+	}
+}
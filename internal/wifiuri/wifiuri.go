@@ -0,0 +1,245 @@
+// Package wifiuri provides the WIFI: URI format used to share network
+// credentials through QR codes
+package wifiuri
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const Scheme = "WIFI:"
+
+type Security string
+
+const (
+	SecurityNone Security = "nopass"
+	SecurityWPA  Security = "WPA"
+	SecurityWEP  Security = "WEP"
+)
+
+type Credentials struct {
+	SSID     string
+	Security Security
+	Password string
+	Hidden   bool
+}
+
+// Format renders credentials as a WIFI: URI, e.g.
+// "WIFI:T:WPA;S:home;P:secret;;". Special characters are backslash-escaped.
+func Format(c Credentials) string {
+	security := c.Security
+	if security == "" {
+		security = SecurityWPA
+		if c.Password == "" {
+			security = SecurityNone
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(Scheme)
+	sb.WriteString("T:")
+	sb.WriteString(string(security))
+	sb.WriteString(";S:")
+	sb.WriteString(Escape(c.SSID))
+	sb.WriteByte(';')
+	if security != SecurityNone {
+		sb.WriteString("P:")
+		sb.WriteString(Escape(c.Password))
+		sb.WriteByte(';')
+	}
+	if c.Hidden {
+		sb.WriteString("H:true;")
+	}
+	sb.WriteByte(';')
+	return sb.String()
+}
+
+// specialChars must be escaped with a backslash inside WIFI: URI values.
+const specialChars = `\;,:"`
+
+// Escape backslash-escapes the characters that are special in WIFI: URI values.
+func Escape(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(specialChars, r) {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+var (
+	ErrNotWifiURI         = errors.New("not a WIFI: uri")
+	ErrMissingSSID        = errors.New("missing ssid")
+	ErrUnknownSecurity    = errors.New("unknown security type")
+	ErrInvalidHiddenFlag  = errors.New("invalid hidden flag")
+	ErrMalformedField     = errors.New("malformed field")
+	ErrUnterminatedEscape = errors.New("unterminated escape sequence")
+)
+
+// Parse reads a WIFI: URI as produced by the Android and iOS share sheets.
+// Fields may come in any order, unknown fields are ignored and the closing
+// ";;" is optional. Values wrapped in unescaped double quotes are unquoted.
+func Parse(uri string) (Credentials, error) {
+	uri = strings.TrimSpace(uri)
+	if len(uri) < len(Scheme) || !strings.EqualFold(uri[:len(Scheme)], Scheme) {
+		return Credentials{}, ErrNotWifiURI
+	}
+
+	fields, err := splitFields(uri[len(Scheme):])
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	var (
+		c        Credentials
+		security string
+	)
+	for _, f := range fields {
+		switch strings.ToUpper(f.key) {
+		case "T":
+			security = f.value
+		case "S":
+			c.SSID = f.value
+		case "P":
+			c.Password = f.value
+		case "H":
+			c.Hidden, err = parseHidden(f.value)
+			if err != nil {
+				return Credentials{}, err
+			}
+		}
+	}
+
+	if c.SSID == "" {
+		return Credentials{}, ErrMissingSSID
+	}
+	c.Security, err = parseSecurity(security, c.Password)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if c.Security == SecurityNone {
+		c.Password = ""
+	}
+	return c, nil
+}
+
+type field struct {
+	key   string
+	value string
+}
+
+// fieldBuilder accumulates one "K:value" field while tracking which value
+// runes were escaped, so that escaped quotes are never stripped.
+type fieldBuilder struct {
+	key     strings.Builder
+	value   []rune
+	escaped []bool
+	inValue bool
+}
+
+func (b *fieldBuilder) write(r rune, escaped bool) {
+	if !b.inValue {
+		if r == ':' && !escaped {
+			b.inValue = true
+			return
+		}
+		b.key.WriteRune(r)
+		return
+	}
+	b.value = append(b.value, r)
+	b.escaped = append(b.escaped, escaped)
+}
+
+func (b *fieldBuilder) empty() bool {
+	return !b.inValue && b.key.Len() == 0
+}
+
+func (b *fieldBuilder) build() (field, error) {
+	if !b.inValue {
+		return field{}, fmt.Errorf("%w: %q", ErrMalformedField, b.key.String())
+	}
+	value := b.value
+	if n := len(value); n >= 2 &&
+		value[0] == '"' && !b.escaped[0] &&
+		value[n-1] == '"' && !b.escaped[n-1] {
+		value = value[1 : n-1]
+	}
+	return field{key: b.key.String(), value: string(value)}, nil
+}
+
+// splitFields splits the URI body on unescaped semicolons and resolves
+// backslash escapes. Empty fields, such as the closing ";;", are dropped.
+func splitFields(body string) ([]field, error) {
+	var (
+		fields  []field
+		b       fieldBuilder
+		escaped bool
+	)
+	flush := func() error {
+		if b.empty() {
+			return nil
+		}
+		f, err := b.build()
+		if err != nil {
+			return err
+		}
+		fields = append(fields, f)
+		b = fieldBuilder{}
+		return nil
+	}
+
+	for _, r := range body {
+		switch {
+		case escaped:
+			b.write(r, true)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ';':
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		default:
+			b.write(r, false)
+		}
+	}
+	if escaped {
+		return nil, ErrUnterminatedEscape
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func parseHidden(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "true":
+		return true, nil
+	case "false", "":
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w: %q", ErrInvalidHiddenFlag, value)
+	}
+}
+
+func parseSecurity(value, password string) (Security, error) {
+	switch strings.ToUpper(value) {
+	case "":
+		if password == "" {
+			return SecurityNone, nil
+		}
+		return SecurityWPA, nil
+	case "NOPASS":
+		return SecurityNone, nil
+	case "WPA", "WPA2", "WPA3", "SAE":
+		return SecurityWPA, nil
+	case "WEP":
+		return SecurityWEP, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownSecurity, value)
+	}
+}
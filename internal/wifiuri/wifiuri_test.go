@@ -0,0 +1,262 @@
+package wifiuri_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alphameo/nm-tui/internal/wifiuri"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   wifiuri.Credentials
+		want string
+	}{
+		{
+			"wpa",
+			wifiuri.Credentials{SSID: "home", Security: wifiuri.SecurityWPA, Password: "secret"},
+			"WIFI:T:WPA;S:home;P:secret;;",
+		},
+		{
+			"security inferred from password",
+			wifiuri.Credentials{SSID: "home", Password: "secret"},
+			"WIFI:T:WPA;S:home;P:secret;;",
+		},
+		{
+			"open network omits password",
+			wifiuri.Credentials{SSID: "cafe"},
+			"WIFI:T:nopass;S:cafe;;",
+		},
+		{
+			"hidden",
+			wifiuri.Credentials{SSID: "lab", Password: "12345678", Hidden: true},
+			"WIFI:T:WPA;S:lab;P:12345678;H:true;;",
+		},
+		{
+			"special characters escaped",
+			wifiuri.Credentials{SSID: `a;b,c`, Password: `p:w\d"`},
+			`WIFI:T:WPA;S:a\;b\,c;P:p\:w\\d\";;`,
+		},
+		{
+			"unicode untouched",
+			wifiuri.Credentials{SSID: "кафе ☕", Password: "пароль123"},
+			"WIFI:T:WPA;S:кафе ☕;P:пароль123;;",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := wifiuri.Format(tt.in); got != tt.want {
+				t.Errorf("Format(%+v) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		want wifiuri.Credentials
+	}{
+		{
+			"wpa",
+			"WIFI:T:WPA;S:home;P:secret;;",
+			wifiuri.Credentials{SSID: "home", Security: wifiuri.SecurityWPA, Password: "secret"},
+		},
+		{
+			"fields in any order",
+			"WIFI:P:secret;S:home;T:WPA;;",
+			wifiuri.Credentials{SSID: "home", Security: wifiuri.SecurityWPA, Password: "secret"},
+		},
+		{
+			"lowercase scheme and keys",
+			"wifi:t:wpa;s:home;p:secret;;",
+			wifiuri.Credentials{SSID: "home", Security: wifiuri.SecurityWPA, Password: "secret"},
+		},
+		{
+			"closing semicolons optional",
+			"WIFI:T:WPA;S:home;P:secret",
+			wifiuri.Credentials{SSID: "home", Security: wifiuri.SecurityWPA, Password: "secret"},
+		},
+		{
+			"surrounding whitespace",
+			"  WIFI:T:WPA;S:home;P:secret;;\n",
+			wifiuri.Credentials{SSID: "home", Security: wifiuri.SecurityWPA, Password: "secret"},
+		},
+		{
+			"open network",
+			"WIFI:T:nopass;S:cafe;;",
+			wifiuri.Credentials{SSID: "cafe", Security: wifiuri.SecurityNone},
+		},
+		{
+			"open network drops password",
+			"WIFI:T:nopass;S:cafe;P:ignored;;",
+			wifiuri.Credentials{SSID: "cafe", Security: wifiuri.SecurityNone},
+		},
+		{
+			"security inferred from password",
+			"WIFI:S:home;P:secret;;",
+			wifiuri.Credentials{SSID: "home", Security: wifiuri.SecurityWPA, Password: "secret"},
+		},
+		{
+			"security inferred without password",
+			"WIFI:S:cafe;;",
+			wifiuri.Credentials{SSID: "cafe", Security: wifiuri.SecurityNone},
+		},
+		{
+			"wpa3 maps to wpa",
+			"WIFI:T:SAE;S:home;P:secret;;",
+			wifiuri.Credentials{SSID: "home", Security: wifiuri.SecurityWPA, Password: "secret"},
+		},
+		{
+			"wep",
+			"WIFI:T:WEP;S:old;P:abcde;;",
+			wifiuri.Credentials{SSID: "old", Security: wifiuri.SecurityWEP, Password: "abcde"},
+		},
+		{
+			"hidden",
+			"WIFI:T:WPA;S:lab;P:12345678;H:true;;",
+			wifiuri.Credentials{SSID: "lab", Security: wifiuri.SecurityWPA, Password: "12345678", Hidden: true},
+		},
+		{
+			"hidden false",
+			"WIFI:T:WPA;S:lab;P:12345678;H:false;;",
+			wifiuri.Credentials{SSID: "lab", Security: wifiuri.SecurityWPA, Password: "12345678"},
+		},
+		{
+			"unknown fields ignored",
+			"WIFI:T:WPA;S:home;P:secret;R:1;I:user;;",
+			wifiuri.Credentials{SSID: "home", Security: wifiuri.SecurityWPA, Password: "secret"},
+		},
+		{
+			"escaped semicolon",
+			`WIFI:T:WPA;S:a\;b;P:c\;d;;`,
+			wifiuri.Credentials{SSID: "a;b", Security: wifiuri.SecurityWPA, Password: "c;d"},
+		},
+		{
+			"escaped comma",
+			`WIFI:T:WPA;S:a\,b;P:pw;;`,
+			wifiuri.Credentials{SSID: "a,b", Security: wifiuri.SecurityWPA, Password: "pw"},
+		},
+		{
+			"escaped colon",
+			`WIFI:T:WPA;S:a\:b;P:p\:w;;`,
+			wifiuri.Credentials{SSID: "a:b", Security: wifiuri.SecurityWPA, Password: "p:w"},
+		},
+		{
+			"unescaped colon inside value",
+			`WIFI:T:WPA;S:a:b;P:pw;;`,
+			wifiuri.Credentials{SSID: "a:b", Security: wifiuri.SecurityWPA, Password: "pw"},
+		},
+		{
+			"escaped backslash",
+			`WIFI:T:WPA;S:a\\b;P:pw\\;;`,
+			wifiuri.Credentials{SSID: `a\b`, Security: wifiuri.SecurityWPA, Password: `pw\`},
+		},
+		{
+			"escaped quote",
+			`WIFI:T:WPA;S:say \"hi\";P:pw;;`,
+			wifiuri.Credentials{SSID: `say "hi"`, Security: wifiuri.SecurityWPA, Password: "pw"},
+		},
+		{
+			"quoted values unquoted",
+			`WIFI:T:WPA;S:"home";P:"secret";;`,
+			wifiuri.Credentials{SSID: "home", Security: wifiuri.SecurityWPA, Password: "secret"},
+		},
+		{
+			"escaped surrounding quotes kept",
+			`WIFI:T:WPA;S:\"home\";P:pw;;`,
+			wifiuri.Credentials{SSID: `"home"`, Security: wifiuri.SecurityWPA, Password: "pw"},
+		},
+		{
+			"single quote kept",
+			`WIFI:T:WPA;S:";P:pw;;`,
+			wifiuri.Credentials{SSID: `"`, Security: wifiuri.SecurityWPA, Password: "pw"},
+		},
+		{
+			"escaped ordinary character",
+			`WIFI:T:WPA;S:\a\b;P:pw;;`,
+			wifiuri.Credentials{SSID: "ab", Security: wifiuri.SecurityWPA, Password: "pw"},
+		},
+		{
+			"unicode",
+			"WIFI:T:WPA;S:кафе ☕;P:пароль123;;",
+			wifiuri.Credentials{SSID: "кафе ☕", Security: wifiuri.SecurityWPA, Password: "пароль123"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := wifiuri.Parse(tt.in)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		want error
+	}{
+		{"empty", "", wifiuri.ErrNotWifiURI},
+		{"other scheme", "https://example.com", wifiuri.ErrNotWifiURI},
+		{"scheme only", "WIFI:", wifiuri.ErrMissingSSID},
+		{"no ssid", "WIFI:T:WPA;P:secret;;", wifiuri.ErrMissingSSID},
+		{"empty ssid", "WIFI:T:WPA;S:;P:secret;;", wifiuri.ErrMissingSSID},
+		{"unknown security", "WIFI:T:EAP;S:corp;;", wifiuri.ErrUnknownSecurity},
+		{"invalid hidden", "WIFI:S:lab;H:maybe;;", wifiuri.ErrInvalidHiddenFlag},
+		{"field without key", "WIFI:S:home;garbage;;", wifiuri.ErrMalformedField},
+		{"trailing backslash", `WIFI:S:home;P:secret\`, wifiuri.ErrUnterminatedEscape},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := wifiuri.Parse(tt.in)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Parse(%q) error = %v, want %v", tt.in, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatParseRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	specials := []string{`\`, ";", ",", ":", `"`}
+	for _, special := range specials {
+		for _, tmpl := range []string{"%s", "a%sb", "%s%s", "\"%s\""} {
+			value := strings.ReplaceAll(tmpl, "%s", special)
+			in := wifiuri.Credentials{
+				SSID:     value,
+				Security: wifiuri.SecurityWPA,
+				Password: value + value,
+				Hidden:   true,
+			}
+			got, err := wifiuri.Parse(wifiuri.Format(in))
+			if err != nil {
+				t.Fatalf("roundtrip of %+v: %v", in, err)
+			}
+			if got != in {
+				t.Errorf("roundtrip of %+v = %+v", in, got)
+			}
+		}
+	}
+}
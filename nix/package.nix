@@ -12,6 +12,8 @@ buildGoModule {
     pname = "nm-tui";
     version = "0.2.1";
 
+    # Hash of the `go mod vendor` tree: refresh it when the required module
+    # versions change. Moving a module between direct and indirect doesn't.
     vendorHash = "sha256-AyYJFuuURz+QDe69iAgWlN1Xd7+Ofh4hVL0Xya706N8=";
 
     nativeBuildInputs = [ makeWrapper ];